@@ -1,6 +1,9 @@
 package handlers
 
 import (
+	"khelogames/api/sports"
+	crickethelper "khelogames/api/sports/cricket_helper"
+	footballhelper "khelogames/api/sports/football_helper"
 	db "khelogames/database"
 	"khelogames/database/models"
 	errorhandler "khelogames/error_handler"
@@ -152,7 +155,7 @@ func (s *HandlersServer) GetMatchesByLocationFunc(ctx *gin.Context) {
 	}
 
 	var matches []map[string]interface{}
-	if game.Name == "badminton" {
+	if scorer, ok := sports.LookupMatchScorer(game.Name); ok {
 		for _, match := range listMatches {
 			matchPublicIDStr, ok := match["public_id"].(string)
 			if !ok {
@@ -166,13 +169,13 @@ func (s *HandlersServer) GetMatchesByLocationFunc(ctx *gin.Context) {
 				continue
 			}
 
-			badmintonScore, err := s.store.GetBadmintonMatchScore(ctx, matchPublicID)
+			homeScore, awayScore, err := scorer.MatchScore(ctx, matchPublicID)
 			if err != nil {
-				s.logger.Error("Failed to get badminton match score: ", err)
+				s.logger.Error("Failed to get match score: ", err)
 				continue
 			}
-			match["homeScore"] = badmintonScore.HomeSetsWon
-			match["awayScore"] = badmintonScore.AwaySetsWon
+			match["homeScore"] = homeScore
+			match["awayScore"] = awayScore
 			matches = append(matches, match)
 		}
 	} else {
@@ -247,7 +250,7 @@ func (s *HandlersServer) GetAllMatchesFunc(ctx *gin.Context) {
 		return
 	}
 	var matches []map[string]interface{}
-	if game.Name == "badminton" {
+	if scorer, ok := sports.LookupMatchScorer(game.Name); ok {
 		for _, match := range res {
 			matchPublicIDStr, ok := match["public_id"].(string)
 			if !ok {
@@ -261,13 +264,13 @@ func (s *HandlersServer) GetAllMatchesFunc(ctx *gin.Context) {
 				continue
 			}
 
-			badmintonScore, err := s.store.GetBadmintonMatchScore(ctx, matchPublicID)
+			homeScore, awayScore, err := scorer.MatchScore(ctx, matchPublicID)
 			if err != nil {
-				s.logger.Error("Failed to get badminton match score: ", err)
+				s.logger.Error("Failed to get match score: ", err)
 				continue
 			}
-			match["homeScore"] = badmintonScore.HomeSetsWon
-			match["awayScore"] = badmintonScore.AwaySetsWon
+			match["homeScore"] = homeScore
+			match["awayScore"] = awayScore
 			matches = append(matches, match)
 		}
 	} else {
@@ -311,7 +314,7 @@ func (s *HandlersServer) GetLiveMatchesFunc(ctx *gin.Context) {
 	}
 
 	var matches []map[string]interface{}
-	if game.Name == "badminton" {
+	if scorer, ok := sports.LookupMatchScorer(game.Name); ok {
 		for _, match := range res {
 			matchPublicIDStr, ok := match["public_id"].(string)
 			if !ok {
@@ -325,13 +328,13 @@ func (s *HandlersServer) GetLiveMatchesFunc(ctx *gin.Context) {
 				continue
 			}
 
-			badmintonScore, err := s.store.GetBadmintonMatchScore(ctx, matchPublicID)
+			homeScore, awayScore, err := scorer.MatchScore(ctx, matchPublicID)
 			if err != nil {
-				s.logger.Error("Failed to get badminton match score: ", err)
+				s.logger.Error("Failed to get match score: ", err)
 				continue
 			}
-			match["homeScore"] = badmintonScore.HomeSetsWon
-			match["awayScore"] = badmintonScore.AwaySetsWon
+			match["homeScore"] = homeScore
+			match["awayScore"] = awayScore
 			matches = append(matches, match)
 		}
 	} else {
@@ -476,11 +479,43 @@ func (s *HandlersServer) GetMatchByMatchIDFunc(ctx *gin.Context) {
 			}
 		}
 		match["awayScore"] = aScore
+
+		counts, err := s.store.GetFootballIncidentCounts(ctx, int32(matchData.ID))
+		if err != nil {
+			s.logger.Error("Failed to get football incident counts: ", err)
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error": gin.H{
+					"code":    "INTERNAL_ERROR",
+					"message": "Failed to get football incident counts",
+				},
+				"request_id": ctx.GetString("request_id"),
+			})
+			return
+		}
+		possession, err := s.store.GetFootballPossession(ctx, int32(matchData.ID))
+		if err != nil {
+			s.logger.Error("Failed to get football possession: ", err)
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error": gin.H{
+					"code":    "INTERNAL_ERROR",
+					"message": "Failed to get football possession",
+				},
+				"request_id": ctx.GetString("request_id"),
+			})
+			return
+		}
+		match["statistics"] = footballhelper.MatchStatistics(matchData, counts, possession)
 	} else if sport == "cricket" {
 		matchScore, err := s.store.GetCricketScores(ctx, int32(matchData.ID))
 		if err != nil {
 			s.logger.Error("Failed to get cricket scores: ", err)
 		} else {
+			err = s.store.AttachCricketExtras(ctx, int32(matchData.ID), matchScore)
+			if err != nil {
+				s.logger.Error("Failed to get cricket extras: ", err)
+			}
 			var homeScore []models.CricketScore
 			var awayScore []models.CricketScore
 			for _, score := range matchScore {
@@ -492,22 +527,31 @@ func (s *HandlersServer) GetMatchByMatchIDFunc(ctx *gin.Context) {
 			}
 			match["homeScore"] = homeScore
 			match["awayScore"] = awayScore
+			if crickethelper.IsMultiDayFormat(matchData.MatchFormat) {
+				result := crickethelper.DecideMultiDayMatch(crickethelper.InningSummaries(matchScore), matchData.StatusCode == "finished")
+				if result.Decided {
+					match["resultDescription"] = result.Description
+				}
+			} else if len(matchScore) > 2 {
+				result := crickethelper.DecideSuperOver(crickethelper.InningSummaries(matchScore))
+				if result.Decided {
+					match["resultDescription"] = result.Description
+				}
+			}
 		}
-	} else if sport == "badminton" {
-		score, err := s.store.GetBadmintonMatchScore(ctx, matchData.PublicID)
+		revisedTarget, err := s.store.GetCricketRevisedTarget(ctx, int32(matchData.ID))
 		if err != nil {
-			s.logger.Error("Failed to get badminton match score:", err)
-		} else if score != nil {
-			var hScore int
-			var aScore int
-			if score.HomeSetsWon != nil {
-				hScore = *score.HomeSetsWon
-			}
-			if score.AwaySetsWon != nil {
-				aScore = *score.AwaySetsWon
-			}
-			match["homeScore"] = hScore
-			match["awayScore"] = aScore
+			s.logger.Error("Failed to get cricket revised target: ", err)
+		} else if revisedTarget != nil {
+			match["revisedTarget"] = revisedTarget
+		}
+	} else if scorer, ok := sports.LookupMatchScorer(sport); ok {
+		homeScore, awayScore, err := scorer.MatchScore(ctx, matchData.PublicID)
+		if err != nil {
+			s.logger.Error("Failed to get match score: ", err)
+		} else {
+			match["homeScore"] = homeScore
+			match["awayScore"] = awayScore
 		}
 	}
 
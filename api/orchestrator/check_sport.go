@@ -2,9 +2,7 @@ package orchestrator
 
 import (
 	"khelogames/api/shared"
-	"khelogames/api/sports/badminton"
-	"khelogames/api/sports/cricket"
-	"khelogames/api/sports/football"
+	"khelogames/api/sports"
 	"khelogames/api/transactions"
 	db "khelogames/database"
 	"khelogames/logger"
@@ -23,19 +21,11 @@ func NewCheckSport(store *db.Store, logger *logger.Logger, scoreBroadcaster shar
 	return &CheckSportServer{store: store, logger: logger}
 }
 
-func (s *CheckSportServer) CheckSport(sports string, matches []db.GetMatchByIDRow, tournamentPublicID uuid.UUID) []map[string]interface{} {
-	footballServer := football.NewFootballServer(s.store, s.logger, s.scoreBroadcaster, s.txStore)
-	cricketServer := cricket.NewCricketServer(s.store, s.logger, s.scoreBroadcaster, s.txStore)
-	badmintonServer := badminton.NewBadmintonServer(s.store, s.logger, s.scoreBroadcaster, s.txStore)
-	switch sports {
-	case "cricket":
-		return cricketServer.GetCricketScore(matches, tournamentPublicID)
-	case "football":
-		return footballServer.GetFootballScore(matches, tournamentPublicID)
-	case "badminton":
-		return badmintonServer.GetBadmintonScore(matches, tournamentPublicID)
-	default:
-		s.logger.Error("Unsupported sport type:", sports)
+func (s *CheckSportServer) CheckSport(sportName string, matches []db.GetMatchByIDRow, tournamentPublicID uuid.UUID) []map[string]interface{} {
+	sport, ok := sports.Lookup(sportName)
+	if !ok {
+		s.logger.Error("Unsupported sport type:", sportName)
 		return nil
 	}
+	return sport.ScoreSummary(matches, tournamentPublicID)
 }
@@ -2,6 +2,7 @@ package players
 
 import (
 	"fmt"
+	"khelogames/api/sports"
 	"khelogames/core/token"
 	db "khelogames/database"
 	errorhandler "khelogames/error_handler"
@@ -341,7 +342,7 @@ func (s *PlayerServer) GetMatchesByPlayerFunc(ctx *gin.Context) {
 
 		for _, match := range teamMatches {
 			// Deduplicate matches (player may appear in both home and away via different teams)
-			if game.Name == "badminton" {
+			if scorer, ok := sports.LookupMatchScorer(game.Name); ok {
 				matchPublicID, _ := match["public_id"].(string)
 				if seen[matchPublicID] {
 					continue
@@ -349,14 +350,14 @@ func (s *PlayerServer) GetMatchesByPlayerFunc(ctx *gin.Context) {
 
 				seen[matchPublicID] = true
 
-				// Add badminton scores if available
+				// Add the score if available
 				if matchPublicID != "" {
 					matchUUID, err := uuid.Parse(matchPublicID)
 					if err == nil {
-						matchScore, err := s.store.GetBadmintonMatchScore(ctx, matchUUID)
-						if err == nil && matchScore != nil {
-							match["homeScore"] = matchScore.HomeSetsWon
-							match["awayScore"] = matchScore.AwaySetsWon
+						homeScore, awayScore, err := scorer.MatchScore(ctx, matchUUID)
+						if err == nil {
+							match["homeScore"] = homeScore
+							match["awayScore"] = awayScore
 							resultFloat64, ok := match["result"].(float64)
 							if ok {
 								match["isWin"] = team.ID == int64(resultFloat64)
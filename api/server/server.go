@@ -11,6 +11,9 @@ import (
 	"khelogames/api/sports/badminton"
 	"khelogames/api/sports/cricket"
 	"khelogames/api/sports/football"
+	"khelogames/api/sports/kabaddi"
+	tabletennis "khelogames/api/sports/table_tennis"
+	"khelogames/api/sports/volleyball"
 	"khelogames/api/teams"
 	apiToken "khelogames/api/token"
 	"khelogames/api/tournaments"
@@ -114,6 +117,9 @@ func NewServer(config util.Config,
 	footballServer *football.FootballServer,
 	cricketServer *cricket.CricketServer,
 	badmintonServer *badminton.BadmintonServer,
+	tableTennisServer *tabletennis.TableTennisServer,
+	volleyballServer *volleyball.VolleyballServer,
+	kabaddiServer *kabaddi.KabaddiServer,
 	teamsServer *teams.TeamsServer,
 	messageServer *messenger.MessageServer,
 	playersServer *players.PlayerServer,
@@ -296,7 +302,6 @@ func NewServer(config util.Config,
 	sportRouter.PUT("/removePlayerFromTeam", server.RequiredPermission(PermUpdateTeam), teamsServer.RemovePlayerFromTeamFunc)
 	sportRouter.GET("/getTeamsMemberFunc/:team_public_id", teamsServer.GetTeamsMemberFunc)
 	sportRouter.GET("/getTeamsBySport/:game_id", teamsServer.GetTeamsBySportFunc)
-	// sportRouter.GET("/getMatchByTeamFunc", teamsServer.GetMatchByTeamFunc)
 	sportRouter.GET("/getMatchesByTeam/:team_public_id", teamsServer.GetMatchesByTeamFunc)
 	//sportRouter.GET("/getTournamentByTeamFunc/:team_public_id", teamsServer.GetTournamentbyTeamFunc)
 
@@ -308,6 +313,13 @@ func NewServer(config util.Config,
 	sportRouter.POST("/addFootballIncidents", server.RequiredPermission(PermUpdateMatch), footballServer.AddFootballIncidentsFunc)
 	sportRouter.GET("/getFootballIncidents/:match_public_id", footballServer.GetFootballIncidentsFunc)
 	sportRouter.POST("/addFootballIncidentsSubs", server.RequiredPermission(PermUpdateMatch), footballServer.AddFootballIncidentsSubs)
+	sportRouter.PUT("/updateFootballClock", server.RequiredPermission(PermUpdateMatch), footballServer.UpdateFootballClockFunc)
+	sportRouter.GET("/getFootballClock/:match_public_id", footballServer.GetFootballClockFunc)
+	sportRouter.POST("/addFootballVarReview", server.RequiredPermission(PermUpdateMatch), footballServer.AddFootballVarReviewFunc)
+	sportRouter.PUT("/updateFootballIncident", server.RequiredPermission(PermUpdateMatch), footballServer.UpdateFootballIncidentFunc)
+	sportRouter.DELETE("/deleteFootballIncident/:match_public_id/:incident_public_id", server.RequiredPermission(PermUpdateMatch), footballServer.DeleteFootballIncidentFunc)
+	sportRouter.POST("/addFootballShootoutKick", server.RequiredPermission(PermUpdateMatch), footballServer.AddFootballShootoutKickFunc)
+	sportRouter.GET("/getFootballShootout/:match_public_id", footballServer.GetFootballShootoutFunc)
 	// sportRouter.PUT("/updateFootballFirstHalfScore", footballServer.UpdateFootballMatchScoreFirstHalfFunc)
 	// sportRouter.PUT("/updateFootballSecondHalfScore", footballServer.UpdateFootballMatchScoreSecondHalfFunc)
 	// sportRouter.PUT("/updateFootballMatchScore", footballServer.UpdateFootballMatchScoreFunc)
@@ -321,14 +333,22 @@ func NewServer(config util.Config,
 	// sportRouter.GET("/getFootballSubstitution", footballServer.GetFootballSubstitutionFunc)
 	// sportRouter.PUT("/updateFootballSubsAndLineUp", footballServer.UpdateFootballSubsAndLineUpFunc)
 
-	// sportRouter.POST("/addFootballStatistics", footballServer.AddFootballStatisticsFunc)
-	//sportRouter.GET("/getFootballStatistics", footballServer.GetFootballStatisticsFunc)
+	sportRouter.PUT("/updateFootballStatistics", server.RequiredPermission(PermUpdateMatch), footballServer.UpdateFootballStatisticsFunc)
+	sportRouter.GET("/getFootballStatistics/:match_public_id", footballServer.GetFootballStatisticsFunc)
 	sportRouter.POST("/addFootballMatchSquad", server.RequiredPermission(PermManageMatchSquad), footballServer.AddFootballSquadFunc)
+	sportRouter.POST("/addFootballLineup", server.RequiredPermission(PermManageMatchSquad), footballServer.AddFootballLineupFunc)
+	sportRouter.GET("/getFootballFormation/:match_public_id/:team_public_id", footballServer.GetFootballFormationFunc)
 	sportRouter.GET("/getFootballTournamentPlayerGoal/:tournament_public_id", tournamentServer.GetFootballTournamentPlayersGoalsFunc)
 	sportRouter.GET("/getFootballTournamentPlayerYellowCard/:tournament_public_id", tournamentServer.GetFootballTournamentPlayersYellowCardFunc)
 	sportRouter.GET("/getFootballTournamentPlayerRedCard/:tournament_public_id", tournamentServer.GetFootballTournamentPlayersRedCardFunc)
-
-	// sportRouter.PUT("/updateFootballStatistics", footballServer.UpdateFootballStatisticsFunc)
+	sportRouter.PUT("/updateFootballDisciplinaryRules/:tournament_public_id", server.RequiredPermission(PermUpdateTournament), tournamentServer.UpdateFootballDisciplinaryRulesFunc)
+	sportRouter.GET("/getFootballDisciplinaryRules/:tournament_public_id", tournamentServer.GetFootballDisciplinaryRulesFunc)
+	sportRouter.PUT("/updateBadmintonScoringProfile/:tournament_public_id", server.RequiredPermission(PermUpdateTournament), tournamentServer.UpdateBadmintonScoringProfileFunc)
+	sportRouter.GET("/getBadmintonScoringProfile/:tournament_public_id", tournamentServer.GetBadmintonScoringProfileFunc)
+	sportRouter.GET("/getFootballTournamentSuspensions/:tournament_public_id", tournamentServer.GetFootballTournamentSuspensionsFunc)
+	sportRouter.GET("/getFootballSuspendedPlayers/:match_public_id", tournamentServer.GetFootballSuspendedPlayersFunc)
+	sportRouter.GET("/getFootballMatchRatings/:match_public_id", footballServer.GetFootballMatchRatingsFunc)
+	sportRouter.GET("/getFootballTournamentRatings/:tournament_public_id", tournamentServer.GetFootballTournamentRatingsFunc)
 
 	//cricket
 	sportRouter.POST("/addCricketScore", server.RequiredPermission(PermUpdateMatch), cricketServer.AddCricketScoreFunc)
@@ -339,6 +359,15 @@ func NewServer(config util.Config,
 	sportRouter.PUT("/updateCricketNoBall", server.RequiredPermission(PermUpdateMatch), cricketServer.UpdateNoBallsRunsFunc)
 	sportRouter.PUT("/updateCricketWide", server.RequiredPermission(PermUpdateMatch), cricketServer.UpdateWideBallFunc)
 	sportRouter.PUT("/updateCricketRegularScore", server.RequiredPermission(PermUpdateMatch), cricketServer.UpdateInningScoreFunc)
+	sportRouter.PUT("/updateCricketExtras", server.RequiredPermission(PermUpdateMatch), cricketServer.UpdateCricketExtrasFunc)
+	sportRouter.PUT("/undoCricketDelivery", server.RequiredPermission(PermUpdateMatch), cricketServer.UndoCricketDeliveryFunc)
+	sportRouter.PUT("/redoCricketDelivery", server.RequiredPermission(PermUpdateMatch), cricketServer.RedoCricketDeliveryFunc)
+	sportRouter.PUT("/addCricketInterruption", server.RequiredPermission(PermUpdateMatch), cricketServer.AddCricketInterruptionFunc)
+	sportRouter.PUT("/declareCricketInning", server.RequiredPermission(PermUpdateMatch), cricketServer.DeclareCricketInningFunc)
+	sportRouter.PUT("/updateCricketSession", server.RequiredPermission(PermUpdateMatch), cricketServer.UpdateCricketSessionFunc)
+	sportRouter.GET("/getCricketCommentary/:match_public_id", cricketServer.GetCricketCommentaryFunc)
+	sportRouter.GET("/getCricketPartnerships/:match_public_id", cricketServer.GetCricketPartnershipsFunc)
+	sportRouter.GET("/getCricketOverSummary/:match_public_id", cricketServer.GetCricketOverSummaryFunc)
 	sportRouter.GET("/getCurrentBatsman", cricketServer.GetCurrentBatsmanFunc)
 	sportRouter.GET("/getCurrentBowler", cricketServer.GetCurrentBowlerFunc)
 	//squad
@@ -367,6 +396,7 @@ func NewServer(config util.Config,
 	// sportRouter.PUT("/updateCricketBall", cricketServer.UpdateCricketBallFunc)
 	sportRouter.GET("/getCricketWickets", cricketServer.GetCricketWicketsFunc)
 	sportRouter.POST("/wickets", server.RequiredPermission(PermUpdateMatch), cricketServer.AddCricketWicketsFunc)
+	sportRouter.PUT("/resumeCricketBatsman", server.RequiredPermission(PermUpdateMatch), cricketServer.ResumeCricketBatsmanFunc)
 	sportRouter.PUT("/updateBowlingBowlerStatus", server.RequiredPermission(PermUpdateMatch), cricketServer.UpdateBowlingBowlerFunc)
 
 	sportRouter.GET("/getLiveMatches", handlersServer.GetLiveMatchesFunc)
@@ -375,10 +405,40 @@ func NewServer(config util.Config,
 
 	//Badminton
 	sportRouter.GET("/get-badminton-sets-score/:match_public_id", badmintonServer.GetBadmintonScoreFunc)
-	sportRouter.POST("/update-badminton-score", badmintonServer.UpdateBadmintonScoreFunc)
+	sportRouter.POST("/update-badminton-score", server.RequiredPermission(PermUpdateMatch), badmintonServer.UpdateBadmintonScoreFunc)
+	sportRouter.POST("/undo-badminton-point", server.RequiredPermission(PermUpdateMatch), badmintonServer.UndoBadmintonPointFunc)
+	sportRouter.GET("/get-badminton-rallies/:match_public_id", badmintonServer.GetBadmintonRalliesFunc)
+	sportRouter.POST("/update-badminton-service", server.RequiredPermission(PermUpdateMatch), badmintonServer.UpdateBadmintonServiceFunc)
+	sportRouter.GET("/get-badminton-rally-state/:match_public_id", badmintonServer.GetBadmintonRallyStateFunc)
+	sportRouter.PUT("/update-badminton-scoring-profile", server.RequiredPermission(PermUpdateMatch), badmintonServer.UpdateBadmintonScoringProfileFunc)
+	sportRouter.GET("/get-badminton-scoring-profile/:match_public_id", badmintonServer.GetBadmintonScoringProfileFunc)
 	sportRouter.GET("/get-badminton-match-team-stats/:match_public_id/:team_public_id", badmintonServer.GetBadmintonSetsPointsByTeamFunc)
 	sportRouter.GET("/getBadmintonPlayerStats/:player_public_id", badmintonServer.GetBadmintonPlayerStatsFunc)
 
+	//Table Tennis
+	sportRouter.GET("/get-table-tennis-score/:match_public_id", tableTennisServer.GetTableTennisScoreFunc)
+	sportRouter.POST("/update-table-tennis-score", server.RequiredPermission(PermUpdateMatch), tableTennisServer.UpdateTableTennisScoreFunc)
+	sportRouter.GET("/get-table-tennis-player-stats/:player_public_id", tableTennisServer.GetTableTennisPlayerStatsFunc)
+
+	//Volleyball
+	sportRouter.GET("/get-volleyball-score/:match_public_id", volleyballServer.GetVolleyballScoreFunc)
+	sportRouter.POST("/update-volleyball-score", server.RequiredPermission(PermUpdateMatch), volleyballServer.UpdateVolleyballScoreFunc)
+	sportRouter.PUT("/update-volleyball-lineup", server.RequiredPermission(PermUpdateMatch), volleyballServer.UpdateVolleyballLineupFunc)
+	sportRouter.GET("/get-volleyball-rotation/:match_public_id", volleyballServer.GetVolleyballRotationFunc)
+	sportRouter.GET("/get-volleyball-player-stats/:player_public_id", volleyballServer.GetVolleyballPlayerStatsFunc)
+
+	//Kabaddi
+	sportRouter.GET("/get-kabaddi-score/:match_public_id", kabaddiServer.GetKabaddiScoreFunc)
+	sportRouter.POST("/add-kabaddi-raid", server.RequiredPermission(PermUpdateMatch), kabaddiServer.AddKabaddiRaidFunc)
+	sportRouter.GET("/get-kabaddi-raids/:match_public_id", kabaddiServer.GetKabaddiRaidsFunc)
+	sportRouter.PUT("/update-kabaddi-clock", server.RequiredPermission(PermUpdateMatch), kabaddiServer.UpdateKabaddiClockFunc)
+	sportRouter.GET("/get-kabaddi-clock/:match_public_id", kabaddiServer.GetKabaddiClockFunc)
+	sportRouter.GET("/get-kabaddi-player-stats/:player_public_id", kabaddiServer.GetKabaddiPlayerStatsFunc)
+	sportRouter.GET("/getKabaddiStanding/:tournament_public_id", tournamentServer.GetKabaddiStandingFunc)
+	sportRouter.GET("/getKabaddiTournamentRaidPoints/:tournament_public_id", tournamentServer.GetKabaddiTournamentRaidPointsFunc)
+	sportRouter.GET("/getKabaddiTournamentTacklePoints/:tournament_public_id", tournamentServer.GetKabaddiTournamentTacklePointsFunc)
+	sportRouter.GET("/getKabaddiTournamentSuperTackles/:tournament_public_id", tournamentServer.GetKabaddiTournamentSuperTacklesFunc)
+
 	server.router = router
 	return server, nil
 }
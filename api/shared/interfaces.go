@@ -1,6 +1,7 @@
 package shared
 
 import (
+	"context"
 	"khelogames/logger"
 
 	"github.com/gin-gonic/gin"
@@ -13,6 +14,7 @@ type ScoreBroadcaster interface {
 	BroadcastCricketEvent(ctx *gin.Context, eventType string, payload map[string]interface{}) error
 	BroadcastFootballEvent(ctx *gin.Context, eventType string, payload map[string]interface{}) error
 	BroadcastTournamentEvent(ctx *gin.Context, eventType string, payload map[string]interface{}) error
+	BroadcastMatchEvent(ctx context.Context, eventType string, payload map[string]interface{}) error
 }
 
 type MessageBroadcaster interface {
@@ -2,12 +2,14 @@ package badminton
 
 import (
 	shared "khelogames/api/shared"
+	"khelogames/api/sports"
 	"khelogames/api/transactions"
 	db "khelogames/database"
 	"khelogames/logger"
 )
 
 type BadmintonServer struct {
+	sports.SetSport
 	store            *db.Store
 	logger           *logger.Logger
 	scoreBroadcaster shared.ScoreBroadcaster
@@ -16,6 +18,7 @@ type BadmintonServer struct {
 
 func NewBadmintonServer(store *db.Store, logger *logger.Logger, scoreBroadcaster shared.ScoreBroadcaster, txStore *transactions.SQLStore) *BadmintonServer {
 	server := &BadmintonServer{
+		SetSport:         sports.NewSetSport(store, logger, store.GetBadmintonMatchScore),
 		store:            store,
 		logger:           logger,
 		scoreBroadcaster: scoreBroadcaster,
@@ -0,0 +1,121 @@
+package badminton
+
+import (
+	"net/http"
+
+	badmintonhelper "khelogames/api/sports/badminton_helper"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+type updateBadmintonScoringProfileRequest struct {
+	MatchPublicID string `json:"match_public_id" binding:"required"`
+	badmintonhelper.ScoringFormat
+}
+
+// UpdateBadmintonScoringProfileFunc sets the scoring format and handicaps of a match before it
+// starts, overriding the format of its tournament
+func (s *BadmintonServer) UpdateBadmintonScoringProfileFunc(ctx *gin.Context) {
+	s.logger.Info("Received request to update badminton scoring profile")
+	var req updateBadmintonScoringProfileRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		s.logger.Error("Failed to bind request: ", err)
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format: ", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	rules, msg := req.Rules()
+	if msg != "" {
+		fieldErrors := map[string]string{"rules": msg}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	profile, err := s.txStore.UpdateBadmintonMatchProfileTx(ctx, matchPublicID, rules)
+	if err != nil {
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
+		s.logger.Error("Failed to update badminton scoring profile: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to update badminton scoring profile",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	s.logger.Info("Successfully updated badminton scoring profile")
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    profile,
+	})
+}
+
+// GetBadmintonScoringProfileFunc returns the scoring rules played by the match
+func (s *BadmintonServer) GetBadmintonScoringProfileFunc(ctx *gin.Context) {
+	var req struct {
+		MatchPublicID string `uri:"match_public_id" binding:"required"`
+	}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format: ", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	match, err := s.store.GetMatchModelByPublicId(ctx, matchPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get match: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get match",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	profile, err := s.store.GetBadmintonScoringProfile(ctx, int32(match.ID), match.TournamentID)
+	if err != nil {
+		s.logger.Error("Failed to get badminton scoring profile: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get badminton scoring profile",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    badmintonhelper.ProfileRules(profile),
+	})
+}
@@ -0,0 +1,131 @@
+package badminton
+
+import (
+	"net/http"
+
+	errorhandler "khelogames/error_handler"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+type updateBadmintonServiceRequest struct {
+	MatchPublicID    string `json:"match_public_id" binding:"required"`
+	SetNumber        int    `json:"set_number" binding:"required"`
+	ServerPublicID   string `json:"server_public_id" binding:"required"`
+	ReceiverPublicID string `json:"receiver_public_id" binding:"required"`
+}
+
+// UpdateBadmintonServiceFunc records who serves and who receives the opening rally of a set
+func (s *BadmintonServer) UpdateBadmintonServiceFunc(ctx *gin.Context) {
+	s.logger.Info("Received request to update badminton service")
+	var req updateBadmintonServiceRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		s.logger.Error("Failed to bind request: ", err)
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format: ", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+	serverPublicID, err := uuid.Parse(req.ServerPublicID)
+	if err != nil {
+		s.logger.Error("Invalid server UUID format: ", err)
+		fieldErrors := map[string]string{"server_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+	receiverPublicID, err := uuid.Parse(req.ReceiverPublicID)
+	if err != nil {
+		s.logger.Error("Invalid receiver UUID format: ", err)
+		fieldErrors := map[string]string{"receiver_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	rallyState, err := s.txStore.UpdateBadmintonServiceTx(ctx, matchPublicID, req.SetNumber, serverPublicID, receiverPublicID)
+	if err != nil {
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
+		s.logger.Error("Failed to update badminton service: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to update badminton service",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	if s.scoreBroadcaster != nil {
+		payload := map[string]interface{}{
+			"type": "SERVICE_UPDATED",
+			"data": map[string]interface{}{
+				"match_public_id": matchPublicID,
+				"rally_state":     rallyState,
+			},
+		}
+		err := s.scoreBroadcaster.BroadcastBadmintonEvent(ctx, "UPDATE_BADMINTON_SERVICE", payload)
+		if err != nil {
+			s.logger.Warn("Broadcast failed: ", err)
+		}
+	}
+
+	s.logger.Info("Successfully updated badminton service")
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    rallyState,
+	})
+}
+
+// GetBadmintonRallyStateFunc returns the server, receiver and courts of the current set
+func (s *BadmintonServer) GetBadmintonRallyStateFunc(ctx *gin.Context) {
+	var req struct {
+		MatchPublicID string `uri:"match_public_id" binding:"required"`
+	}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format: ", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	rallyState, err := s.txStore.GetBadmintonRallyStateTx(ctx, matchPublicID)
+	if err != nil {
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
+		s.logger.Error("Failed to get badminton rally state: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get badminton rally state",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    rallyState,
+	})
+}
@@ -0,0 +1,25 @@
+package badminton
+
+import (
+	"context"
+	"khelogames/api/sports"
+	db "khelogames/database"
+	"khelogames/database/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+var _ sports.Sport = (*BadmintonServer)(nil)
+var _ sports.MatchScorer = (*BadmintonServer)(nil)
+
+func (s *BadmintonServer) Name() string {
+	return "badminton"
+}
+
+func (s *BadmintonServer) StartMatch(ctx context.Context, q *db.Queries, match *models.Match) error {
+	return s.txStore.StartBadmintonMatch(ctx, q, match)
+}
+
+func (s *BadmintonServer) GetTopPerformerFunc(ctx *gin.Context) {
+	s.GetBadmintonTopPerformerFunc(ctx)
+}
@@ -0,0 +1,138 @@
+package badminton
+
+import (
+	"net/http"
+
+	errorhandler "khelogames/error_handler"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+// UndoBadmintonPointFunc removes the latest point of a match awarded by mistake and broadcasts the
+// corrected score
+func (s *BadmintonServer) UndoBadmintonPointFunc(ctx *gin.Context) {
+	s.logger.Info("Received request to undo badminton point")
+	var req struct {
+		MatchPublicID string `json:"match_public_id" binding:"required"`
+	}
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		s.logger.Error("Failed to bind request: ", err)
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format: ", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchResult, setScore, point, removedSet, rallyState, err := s.txStore.UndoBadmintonPointTx(ctx, matchPublicID)
+	if err != nil {
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
+		s.logger.Error("Failed to undo badminton point: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to undo badminton point",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	matchScore, err := s.store.GetBadmintonMatchScore(ctx, matchPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get badminton match score: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get badminton score",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	data := map[string]interface{}{
+		"score":         setScore,
+		"match_result":  matchResult,
+		"removed_point": point,
+		"removed_set":   removedSet,
+		"rally_state":   rallyState,
+		"match_score": map[string]interface{}{
+			"match_public_id": matchPublicID,
+			"homeScore":       matchScore.HomeSetsWon,
+			"awayScore":       matchScore.AwaySetsWon,
+		},
+	}
+
+	if s.scoreBroadcaster != nil {
+		payload := map[string]interface{}{
+			"type": "POINT_UNDONE",
+			"data": data,
+		}
+		err := s.scoreBroadcaster.BroadcastBadmintonEvent(ctx, "UPDATE_BADMINTON_SCORE", payload)
+		if err != nil {
+			s.logger.Warn("Broadcast failed: ", err)
+		}
+	}
+
+	s.logger.Info("Successfully undid badminton point")
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    data,
+	})
+}
+
+// GetBadmintonRalliesFunc returns the rally log of a match: every point set by set, with who
+// served it and from which court
+func (s *BadmintonServer) GetBadmintonRalliesFunc(ctx *gin.Context) {
+	var req struct {
+		MatchPublicID string `uri:"match_public_id" binding:"required"`
+	}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format: ", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	sets, err := s.txStore.GetBadmintonRalliesTx(ctx, matchPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get badminton rallies: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get badminton rallies",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"match_public_id": matchPublicID,
+			"sets":            sets,
+		},
+	})
+}
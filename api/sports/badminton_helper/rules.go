@@ -0,0 +1,151 @@
+package badmintonutils
+
+import (
+	"fmt"
+
+	"khelogames/database/models"
+)
+
+// Courts the server serves from, taken from the serving side's own half
+const (
+	CourtRight = "right"
+	CourtLeft  = "left"
+)
+
+// Rules are the scoring rules of a badminton match. A game is won at PointsToWin with a lead of
+// WinBy, or at MaxPoints; the players get an interval and change ends in the deciding game once
+// the leading score reaches IntervalAt. Every game starts at the handicaps of the two sides.
+type Rules struct {
+	PointsToWin  int `json:"points_to_win"`
+	WinBy        int `json:"win_by"`
+	MaxPoints    int `json:"max_points"`
+	SetsToWin    int `json:"sets_to_win"`
+	IntervalAt   int `json:"interval_at"`
+	HomeHandicap int `json:"home_handicap"`
+	AwayHandicap int `json:"away_handicap"`
+}
+
+// DefaultRules are the rules of the laws of badminton: best of three games of 21 points
+var DefaultRules = Rules{
+	PointsToWin: 21,
+	WinBy:       2,
+	MaxPoints:   30,
+	SetsToWin:   2,
+	IntervalAt:  11,
+}
+
+// Formats are the scoring formats a tournament or match can start from
+var Formats = map[string]Rules{
+	"best_of_3_21": DefaultRules,
+	"best_of_3_15": {PointsToWin: 15, WinBy: 2, MaxPoints: 21, SetsToWin: 2, IntervalAt: 8},
+	"best_of_5_11": {PointsToWin: 11, WinBy: 2, MaxPoints: 15, SetsToWin: 3, IntervalAt: 6},
+}
+
+// ValidateRules returns a message describing why the rules cannot be played, empty when they can
+func ValidateRules(rules Rules) string {
+	switch {
+	case rules.PointsToWin < 1:
+		return "Points per game must be at least 1"
+	case rules.WinBy < 1:
+		return "Win by margin must be at least 1"
+	case rules.MaxPoints != 0 && rules.MaxPoints < rules.PointsToWin:
+		return fmt.Sprintf("Hard cap must be at least %d points", rules.PointsToWin)
+	case rules.SetsToWin < 1:
+		return "Games to win must be at least 1"
+	case rules.IntervalAt < 0 || rules.IntervalAt > rules.PointsToWin:
+		return fmt.Sprintf("Interval must be between 0 and %d points", rules.PointsToWin)
+	case rules.HomeHandicap < 0 || rules.AwayHandicap < 0:
+		return "Handicap cannot be negative"
+	case rules.HomeHandicap >= rules.PointsToWin || rules.AwayHandicap >= rules.PointsToWin:
+		return fmt.Sprintf("Handicap must be below %d points", rules.PointsToWin)
+	}
+	return ""
+}
+
+// IsSetFinished reports whether a game is complete under the rules. A MaxPoints of zero plays the
+// game without a hard cap.
+func IsSetFinished(rules Rules, homeScore, awayScore int) bool {
+	if rules.MaxPoints > 0 && (homeScore >= rules.MaxPoints || awayScore >= rules.MaxPoints) {
+		return true
+	}
+
+	diff := homeScore - awayScore
+	if diff < 0 {
+		diff = -diff
+	}
+
+	return (homeScore >= rules.PointsToWin || awayScore >= rules.PointsToWin) && diff >= rules.WinBy
+}
+
+// IsDecidingSet reports whether the game is the last one the match can go to
+func IsDecidingSet(rules Rules, setNumber int) bool {
+	return setNumber == 2*rules.SetsToWin-1
+}
+
+// CourtForScore returns the court the server serves from, the right court on an even score
+func CourtForScore(score int) string {
+	if score%2 == 0 {
+		return CourtRight
+	}
+	return CourtLeft
+}
+
+// ProfileRules returns the rules of a scoring profile, the default rules without one
+func ProfileRules(profile *models.BadmintonScoringProfile) Rules {
+	if profile == nil {
+		return DefaultRules
+	}
+	return Rules{
+		PointsToWin:  profile.PointsPerGame,
+		WinBy:        profile.WinBy,
+		MaxPoints:    profile.MaxPoints,
+		SetsToWin:    profile.GamesToWin,
+		IntervalAt:   profile.IntervalAt,
+		HomeHandicap: profile.HomeHandicap,
+		AwayHandicap: profile.AwayHandicap,
+	}
+}
+
+// ScoringFormat is a scoring format as entered by an organiser: a named format, the default one
+// when empty, with any of its values overridden
+type ScoringFormat struct {
+	Format        string `json:"format"`
+	PointsPerGame *int   `json:"points_per_game"`
+	WinBy         *int   `json:"win_by"`
+	MaxPoints     *int   `json:"max_points"`
+	GamesToWin    *int   `json:"games_to_win"`
+	IntervalAt    *int   `json:"interval_at"`
+	HomeHandicap  *int   `json:"home_handicap"`
+	AwayHandicap  *int   `json:"away_handicap"`
+}
+
+// Rules returns the rules of the format and a message describing why they cannot be played, empty
+// when they can
+func (f ScoringFormat) Rules() (Rules, string) {
+	rules := DefaultRules
+	if f.Format != "" {
+		format, ok := Formats[f.Format]
+		if !ok {
+			return rules, fmt.Sprintf("Unknown scoring format %s", f.Format)
+		}
+		rules = format
+	}
+
+	for _, value := range []struct {
+		field *int
+		rule  *int
+	}{
+		{f.PointsPerGame, &rules.PointsToWin},
+		{f.WinBy, &rules.WinBy},
+		{f.MaxPoints, &rules.MaxPoints},
+		{f.GamesToWin, &rules.SetsToWin},
+		{f.IntervalAt, &rules.IntervalAt},
+		{f.HomeHandicap, &rules.HomeHandicap},
+		{f.AwayHandicap, &rules.AwayHandicap},
+	} {
+		if value.field != nil {
+			*value.rule = *value.field
+		}
+	}
+	return rules, ValidateRules(rules)
+}
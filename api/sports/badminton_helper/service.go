@@ -0,0 +1,204 @@
+package badmintonutils
+
+// Side is a team on court with its players, one for singles and two for doubles
+type Side struct {
+	TeamID  int32
+	Players []int32
+}
+
+// Service is the player serving and the player receiving the next rally
+type Service struct {
+	ServerTeamID     int32 `json:"server_team_id"`
+	ServerPlayerID   int32 `json:"server_player_id"`
+	ReceiverTeamID   int32 `json:"receiver_team_id"`
+	ReceiverPlayerID int32 `json:"receiver_player_id"`
+}
+
+// Courts are the players of a side in its right and left service court. Both hold the same player
+// in singles.
+type Courts struct {
+	Right int32 `json:"right"`
+	Left  int32 `json:"left"`
+}
+
+// RallyState is a game after the rallies played so far. Interval and EndChange are raised by the
+// last rally only: an interval is due when the leading score reaches IntervalAt, and the players
+// change ends after a game and at the interval of the deciding game. NextService is the opening
+// service of the next game, set once the game is won and the match goes on.
+type RallyState struct {
+	SetNumber    int      `json:"set_number"`
+	HomeScore    int      `json:"home_score"`
+	AwayScore    int      `json:"away_score"`
+	Service      Service  `json:"service"`
+	ServiceCourt string   `json:"service_court"`
+	HomeCourts   Courts   `json:"home_courts"`
+	AwayCourts   Courts   `json:"away_courts"`
+	Interval     bool     `json:"interval"`
+	EndChange    bool     `json:"end_change"`
+	EndsChanged  bool     `json:"ends_changed"`
+	SetFinished  bool     `json:"set_finished"`
+	WinnerTeamID int32    `json:"winner_team_id"`
+	NextService  *Service `json:"next_service"`
+
+	homeTeamID int32
+	awayTeamID int32
+}
+
+// DefaultService is the opening service of a game when the umpire has not recorded one. The home
+// side serves the first game and the winner of the previous game serves the next, each side
+// starting with its first player.
+func DefaultService(setNumber int, home, away Side, previousWinnerTeamID int32) Service {
+	server, receiver := home, away
+	if setNumber > 1 && previousWinnerTeamID == away.TeamID {
+		server, receiver = away, home
+	}
+	return Service{
+		ServerTeamID:     server.TeamID,
+		ServerPlayerID:   firstPlayer(server),
+		ReceiverTeamID:   receiver.TeamID,
+		ReceiverPlayerID: firstPlayer(receiver),
+	}
+}
+
+// Rally is a rally of a game: who served it, from which court, who won it and the score after it
+type Rally struct {
+	Service       Service `json:"service"`
+	ServiceCourt  string  `json:"service_court"`
+	ScoringTeamID int32   `json:"scoring_team_id"`
+	HomeScore     int     `json:"home_score"`
+	AwayScore     int     `json:"away_score"`
+}
+
+// SetState replays the rallies of a game from its opening service and the handicap scores,
+// scoringTeamIDs holding the team that won each rally in order. Rallies after the game was won are
+// ignored.
+func SetState(rules Rules, setNumber int, home, away Side, first Service, scoringTeamIDs []int32) RallyState {
+	state := openingState(rules, setNumber, home, away, first)
+	for _, teamID := range scoringTeamIDs {
+		if state.SetFinished {
+			break
+		}
+		addRally(rules, &state, teamID)
+	}
+	return state
+}
+
+// SetRallies replays the rallies of a game like SetState and returns each of them in order
+func SetRallies(rules Rules, setNumber int, home, away Side, first Service, scoringTeamIDs []int32) []Rally {
+	state := openingState(rules, setNumber, home, away, first)
+	rallies := make([]Rally, 0, len(scoringTeamIDs))
+	for _, teamID := range scoringTeamIDs {
+		if state.SetFinished {
+			break
+		}
+		rally := Rally{Service: state.Service, ServiceCourt: state.ServiceCourt, ScoringTeamID: teamID}
+		addRally(rules, &state, teamID)
+		rally.HomeScore, rally.AwayScore = state.HomeScore, state.AwayScore
+		rallies = append(rallies, rally)
+	}
+	return rallies
+}
+
+func openingState(rules Rules, setNumber int, home, away Side, first Service) RallyState {
+	serverScore := rules.HomeHandicap
+	if first.ServerTeamID == away.TeamID {
+		serverScore = rules.AwayHandicap
+	}
+	court := CourtForScore(serverScore)
+
+	return RallyState{
+		SetNumber:    setNumber,
+		HomeScore:    rules.HomeHandicap,
+		AwayScore:    rules.AwayHandicap,
+		Service:      first,
+		ServiceCourt: court,
+		HomeCourts:   startingCourts(home, first, court),
+		AwayCourts:   startingCourts(away, first, court),
+		homeTeamID:   home.TeamID,
+		awayTeamID:   away.TeamID,
+	}
+}
+
+// startingCourts puts the player serving or receiving the opening rally in the service court and
+// the partner in the other
+func startingCourts(side Side, first Service, court string) Courts {
+	player := first.ReceiverPlayerID
+	if side.TeamID == first.ServerTeamID {
+		player = first.ServerPlayerID
+	}
+
+	partner := player
+	for _, id := range side.Players {
+		if id != player {
+			partner = id
+			break
+		}
+	}
+	if court == CourtLeft {
+		return Courts{Right: partner, Left: player}
+	}
+	return Courts{Right: player, Left: partner}
+}
+
+// addRally scores a rally. A side winning its own service changes courts and serves again from
+// the other court; a side winning the opponent's service serves from the court of its score
+// parity without changing courts.
+func addRally(rules Rules, state *RallyState, scoringTeamID int32) {
+	leadBefore := leadingScore(state.HomeScore, state.AwayScore)
+
+	courts, opponents := &state.HomeCourts, &state.AwayCourts
+	opponentTeamID := state.awayTeamID
+	score := &state.HomeScore
+	if scoringTeamID != state.homeTeamID {
+		courts, opponents = &state.AwayCourts, &state.HomeCourts
+		opponentTeamID = state.homeTeamID
+		score = &state.AwayScore
+	}
+	*score++
+
+	if scoringTeamID == state.Service.ServerTeamID {
+		courts.Right, courts.Left = courts.Left, courts.Right
+	}
+
+	state.ServiceCourt = CourtForScore(*score)
+	server, receiver := courts.Right, opponents.Right
+	if state.ServiceCourt == CourtLeft {
+		server, receiver = courts.Left, opponents.Left
+	}
+	state.Service = Service{
+		ServerTeamID:     scoringTeamID,
+		ServerPlayerID:   server,
+		ReceiverTeamID:   opponentTeamID,
+		ReceiverPlayerID: receiver,
+	}
+
+	state.Interval, state.EndChange = false, false
+	if IsSetFinished(rules, state.HomeScore, state.AwayScore) {
+		state.SetFinished = true
+		state.WinnerTeamID = scoringTeamID
+		state.EndChange = !IsDecidingSet(rules, state.SetNumber)
+		return
+	}
+
+	if leadBefore < rules.IntervalAt && leadingScore(state.HomeScore, state.AwayScore) == rules.IntervalAt {
+		state.Interval = true
+		if IsDecidingSet(rules, state.SetNumber) {
+			state.EndChange = true
+			state.EndsChanged = true
+		}
+	}
+}
+
+func leadingScore(homeScore, awayScore int) int {
+	if homeScore > awayScore {
+		return homeScore
+	}
+	return awayScore
+}
+
+func firstPlayer(side Side) int32 {
+	if len(side.Players) == 0 {
+		return 0
+	}
+	return side.Players[0]
+}
@@ -0,0 +1,49 @@
+package badmintonutils
+
+import (
+	"math"
+
+	"khelogames/database/models"
+)
+
+// CareerStats are the totals of a player over the finished matches of one play type
+type CareerStats struct {
+	Matches        int
+	Wins           int
+	Losses         int
+	SetsWon        int
+	SetsLost       int
+	PointsScored   int
+	PointsConceded int
+	WinPercentage  float64
+	CurrentStreak  int
+	BestStreak     int
+}
+
+// PlayerCareerStats adds up the results of a player, given in the order the matches were played.
+// The streaks count consecutive wins, the current one ending at the latest match.
+func PlayerCareerStats(results []models.BadmintonPlayerMatchResult) CareerStats {
+	var stats CareerStats
+	for _, result := range results {
+		stats.Matches++
+		stats.SetsWon += result.SetsWon
+		stats.SetsLost += result.SetsLost
+		stats.PointsScored += result.PointsScored
+		stats.PointsConceded += result.PointsConceded
+		if result.Won {
+			stats.Wins++
+			stats.CurrentStreak++
+			if stats.CurrentStreak > stats.BestStreak {
+				stats.BestStreak = stats.CurrentStreak
+			}
+		} else {
+			stats.Losses++
+			stats.CurrentStreak = 0
+		}
+	}
+
+	if stats.Matches > 0 {
+		stats.WinPercentage = math.Round(float64(stats.Wins)/float64(stats.Matches)*10000) / 100
+	}
+	return stats
+}
@@ -0,0 +1,139 @@
+package sports
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	shared "khelogames/api/shared"
+	errorhandler "khelogames/error_handler"
+	"khelogames/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// clockTickInterval is how often the running clocks are checked for a new minute and for the end
+// of the half
+const clockTickInterval = 5 * time.Second
+
+// RunningClock is a running match clock at the time of a tick
+type RunningClock struct {
+	MatchID       int32
+	MatchPublicID uuid.UUID
+	State         string
+	// Minute is the minute shown by the clock, a tick is broadcast whenever it changes
+	Minute string
+	// HalfOver is set once the half has been played for its full length
+	HalfOver bool
+	// Payload is the clock as sent to the clients
+	Payload map[string]interface{}
+}
+
+// MatchClock is the match clock shared by the sports played in timed halves. Each sport configures
+// it with its running clocks, which know the states and half length of the sport, and the events
+// it broadcasts.
+type MatchClock struct {
+	// Sport names the clock in the logs and error messages
+	Sport string
+	// TickEvent is broadcast to the match subscribers whenever the minute of a clock changes
+	TickEvent   string
+	Logger      *logger.Logger
+	Broadcaster func() shared.ScoreBroadcaster
+
+	// Running returns the clocks running at the unix time now
+	Running func(ctx context.Context, now int64) ([]RunningClock, error)
+	// Clock returns the clock of a match at the unix time now as sent to the clients
+	Clock func(ctx context.Context, matchPublicID uuid.UUID, now int64) (map[string]interface{}, error)
+	// EndHalf moves a clock whose half has run out to the next state, nil when the halves are
+	// only ended by hand
+	EndHalf func(ctx context.Context, clock RunningClock, now int64)
+}
+
+// GetClockFunc returns the current state and minute of the match clock
+func (c *MatchClock) GetClockFunc(ctx *gin.Context) {
+	var req struct {
+		MatchPublicID string `uri:"match_public_id" binding:"required"`
+	}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		c.Logger.Error("Invalid match UUID format: ", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	payload, err := c.Clock(ctx, matchPublicID, time.Now().Unix())
+	if err != nil {
+		c.Logger.Errorf("Failed to get %s clock: %v", c.Sport, err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get " + c.Sport + " clock",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    payload,
+	})
+}
+
+// StartTicker broadcasts a tick to the match subscribers whenever the minute of a running clock
+// changes and ends the half once it has been played for its full length, until ctx is done
+func (c *MatchClock) StartTicker(ctx context.Context) {
+	c.Logger.Infof("%s clock ticker started", c.Sport)
+	ticker := time.NewTicker(clockTickInterval)
+	defer ticker.Stop()
+
+	lastMinute := make(map[int32]string)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		now := time.Now().Unix()
+		clocks, err := c.Running(ctx, now)
+		if err != nil {
+			c.Logger.Errorf("Failed to get running %s clocks: %v", c.Sport, err)
+			continue
+		}
+
+		broadcaster := c.Broadcaster()
+		minutes := make(map[int32]string, len(clocks))
+		for _, running := range clocks {
+			if running.HalfOver && c.EndHalf != nil {
+				c.EndHalf(ctx, running, now)
+				continue
+			}
+
+			if broadcaster == nil {
+				continue
+			}
+			key := running.State + running.Minute
+			minutes[running.MatchID] = key
+			if lastMinute[running.MatchID] == key {
+				continue
+			}
+			err := broadcaster.BroadcastMatchEvent(ctx, c.TickEvent, running.Payload)
+			if err != nil {
+				c.Logger.Warnf("Failed to broadcast %s clock tick: %v", c.Sport, err)
+				delete(minutes, running.MatchID)
+			}
+		}
+		// paused and stopped clocks drop out so they tick again once resumed
+		lastMinute = minutes
+	}
+}
@@ -0,0 +1,103 @@
+package cricket
+
+import (
+	"net/http"
+	"strconv"
+
+	"khelogames/database/models"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetCricketCommentaryFunc returns the commentary of a match, latest first, paged with limit and offset
+func (s *CricketServer) GetCricketCommentaryFunc(ctx *gin.Context) {
+	var req struct {
+		MatchPublicID string `uri:"match_public_id" binding:"required"`
+	}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	limit, err := strconv.Atoi(ctx.Query("limit"))
+	if err != nil || limit <= 0 {
+		limit = 30 // default batch size
+	}
+
+	offset, err := strconv.Atoi(ctx.Query("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	commentary, err := s.store.GetCricketCommentary(ctx, matchPublicID, limit, offset)
+	if err != nil {
+		s.logger.Error("Failed to get cricket commentary: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get cricket commentary",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	if commentary == nil {
+		commentary = []models.CricketCommentary{}
+	}
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    commentary,
+	})
+}
+
+// pushCricketCommentary sends the commentary of the latest delivery to the match subscribers
+func (s *CricketServer) pushCricketCommentary(ctx *gin.Context, matchPublicID uuid.UUID) {
+	if s.scoreBroadcaster == nil {
+		return
+	}
+
+	commentary, err := s.store.GetLatestCricketCommentary(ctx, matchPublicID)
+	if err != nil {
+		s.logger.Warn("Failed to get latest commentary: ", err)
+		return
+	}
+	if len(commentary) == 0 {
+		return
+	}
+
+	err = s.scoreBroadcaster.BroadcastMatchEvent(ctx, "ADD_COMMENTARY", map[string]interface{}{
+		"match_public_id": matchPublicID,
+		"commentary":      commentary,
+	})
+	if err != nil {
+		s.logger.Warn("Failed to broadcast commentary: ", err)
+	}
+}
+
+// removeCricketCommentary tells the match subscribers to drop the commentary of an undone delivery
+func (s *CricketServer) removeCricketCommentary(ctx *gin.Context, matchPublicID uuid.UUID, delivery *models.CricketDelivery) {
+	if s.scoreBroadcaster == nil {
+		return
+	}
+
+	err := s.scoreBroadcaster.BroadcastMatchEvent(ctx, "REMOVE_COMMENTARY", map[string]interface{}{
+		"match_public_id": matchPublicID,
+		"delivery_id":     delivery.ID,
+	})
+	if err != nil {
+		s.logger.Warn("Failed to broadcast removed commentary: ", err)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"khelogames/pkg"
 	"net/http"
 
+	crickethelper "khelogames/api/sports/cricket_helper"
+
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/google/uuid"
@@ -45,7 +47,7 @@ func (s *CricketServer) AddCricketScoreFunc(ctx *gin.Context) {
 		return
 	}
 
-	_, err = s.store.GetMatchModelByPublicId(ctx, matchPublicID)
+	matchModel, err := s.store.GetMatchModelByPublicId(ctx, matchPublicID)
 	if err != nil {
 		s.logger.Error("Failed to get match details: ", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{
@@ -128,6 +130,50 @@ func (s *CricketServer) AddCricketScoreFunc(ctx *gin.Context) {
 		return
 	}
 
+	inningScores, err := s.store.GetCricketScores(ctx, int32(matchModel.ID))
+	if err != nil {
+		s.logger.Error("Failed to get cricket scores: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get cricket scores",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	// innings follow one another, in a multi day match the side batting first may enforce the follow-on
+	var followOn bool
+	var message string
+	if crickethelper.IsSuperOverInning(matchModel.MatchFormat, req.InningNumber) {
+		// super overs are only played once a knockout has been tied
+		if matchModel.SubStatus == nil || *matchModel.SubStatus != crickethelper.SuperOverSubStatus {
+			message = "Super over is only played after a tied knockout match"
+		} else {
+			message = crickethelper.ValidateSuperOverInning(crickethelper.InningSummaries(inningScores), int32(team.ID), req.InningNumber)
+		}
+	} else {
+		followOn, message = crickethelper.ValidateNextInning(
+			crickethelper.InningSummaries(inningScores),
+			int32(team.ID),
+			req.InningNumber,
+			crickethelper.MaxInnings(matchModel.MatchFormat),
+			crickethelper.MatchDays(matchModel.MatchFormat),
+		)
+	}
+	if message != "" {
+		fieldErrors := map[string]string{"inning_number": message}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+	if req.FollowOn && !followOn {
+		fieldErrors := map[string]string{"follow_on": "Follow-on cannot be enforced for this inning"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
 	arg := db.NewCricketScoreParams{
 		MatchPublicID:     matchPublicID,
 		TeamPublicID:      teamPublicID,
@@ -137,7 +183,7 @@ func (s *CricketServer) AddCricketScoreFunc(ctx *gin.Context) {
 		Overs:             0,
 		RunRate:           "0.00",
 		TargetRunRate:     "0.00",
-		FollowOn:          req.FollowOn,
+		FollowOn:          followOn,
 		IsInningCompleted: false,
 		Declared:          false,
 		InningStatus:      "not_started",
@@ -422,9 +468,10 @@ func (s *CricketServer) UpdateCricketEndInningsFunc(ctx *gin.Context) {
 	ctx.JSON(http.StatusAccepted, gin.H{
 		"success": true,
 		"data": gin.H{
-			"inning":  inningResponse,
-			"batsman": batsman,
-			"bowler":  bowler,
+			"inning":      inningResponse,
+			"batsman":     batsman,
+			"bowler":      bowler,
+			"next_inning": s.nextInningPayload(ctx, matchData),
 		},
 	})
 }
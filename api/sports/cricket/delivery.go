@@ -0,0 +1,229 @@
+package cricket
+
+import (
+	"khelogames/core/token"
+	"khelogames/database/models"
+	"khelogames/pkg"
+	"net/http"
+
+	errorhandler "khelogames/error_handler"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+type cricketDeliveryRequest struct {
+	MatchPublicID       string `json:"match_public_id"`
+	BattingTeamPublicID string `json:"batting_team_public_id"`
+	InningNumber        int    `json:"inning_number"`
+}
+
+// checkMatchUpdatePermission writes the error response and returns false when the user
+// is not allowed to score the tournament match
+func (s *CricketServer) checkMatchUpdatePermission(ctx *gin.Context, matchPublicID uuid.UUID) bool {
+	authPayload := ctx.MustGet(pkg.AuthorizationPayloadKey).(*token.Payload)
+
+	match, err := s.store.GetTournamentMatchByMatchID(ctx, matchPublicID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get tournament match",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return false
+	}
+
+	isExists, err := s.store.GetTournamentUserRole(ctx, int32(match.TournamentID), authPayload.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get user role",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return false
+	}
+	if !isExists {
+		ctx.JSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "FORBIDDEN",
+				"message": "You don't have permission to update this match",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return false
+	}
+	return true
+}
+
+func (s *CricketServer) UndoCricketDeliveryFunc(ctx *gin.Context) {
+	s.logger.Info("Received request to undo cricket delivery")
+	s.updateCricketDelivery(ctx, "UNDO_DELIVERY")
+}
+
+func (s *CricketServer) RedoCricketDeliveryFunc(ctx *gin.Context) {
+	s.logger.Info("Received request to redo cricket delivery")
+	s.updateCricketDelivery(ctx, "REDO_DELIVERY")
+}
+
+func (s *CricketServer) updateCricketDelivery(ctx *gin.Context, eventType string) {
+	var req cricketDeliveryRequest
+	err := ctx.ShouldBindBodyWith(&req, binding.JSON)
+	if err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	battingTeamPublicID, err := uuid.Parse(req.BattingTeamPublicID)
+	if err != nil {
+		s.logger.Error("Invalid batting team UUID format", err)
+		fieldErrors := map[string]string{"batting_team_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	if !s.checkMatchUpdatePermission(ctx, matchPublicID) {
+		return
+	}
+
+	var delivery *models.CricketDelivery
+	var currentBatsman []models.BatsmanScore
+	var currentBowler *models.BowlerScore
+	var inningScore *models.CricketScore
+	if eventType == "UNDO_DELIVERY" {
+		delivery, currentBatsman, currentBowler, inningScore, err = s.txStore.UndoCricketDeliveryTx(ctx, matchPublicID, battingTeamPublicID, req.InningNumber)
+	} else {
+		delivery, currentBatsman, currentBowler, inningScore, err = s.txStore.RedoCricketDeliveryTx(ctx, matchPublicID, battingTeamPublicID, req.InningNumber)
+	}
+	if err != nil {
+		s.logger.Error("Failed to update cricket delivery: ", err)
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to update cricket delivery",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	if delivery == nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "NO_DELIVERY",
+				"message": "There is no delivery to update",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	batsmen := s.currentBatsmenPayload(ctx, currentBatsman)
+	bowler := s.bowlerPayload(ctx, currentBowler)
+
+	payload := map[string]interface{}{
+		"delivery":     delivery,
+		"batsman":      batsmen,
+		"bowler":       bowler,
+		"inning_score": inningScore,
+		"event_type":   eventType,
+	}
+
+	if s.scoreBroadcaster != nil {
+		err := s.scoreBroadcaster.BroadcastCricketEvent(ctx, eventType, payload)
+		if err != nil {
+			s.logger.Warn("Failed to broadcast cricket delivery update: ", err)
+		}
+	}
+	if eventType == "UNDO_DELIVERY" {
+		s.removeCricketCommentary(ctx, matchPublicID, delivery)
+	} else {
+		s.pushCricketCommentary(ctx, matchPublicID)
+	}
+	s.pushCricketPartnerships(ctx, matchPublicID)
+
+	s.logger.Info("Successfully updated cricket delivery")
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"type":    eventType,
+			"payload": payload,
+		},
+	})
+}
+
+func (s *CricketServer) currentBatsmenPayload(ctx *gin.Context, currentBatsman []models.BatsmanScore) []map[string]interface{} {
+	var batsmen []map[string]interface{}
+	for _, batsman := range currentBatsman {
+		playerData, err := s.store.GetPlayerByID(ctx, int64(batsman.BatsmanID))
+		if err != nil {
+			s.logger.Error("Failed to get batsman player: ", err)
+			continue
+		}
+		batsmen = append(batsmen, map[string]interface{}{
+			"player":               map[string]interface{}{"id": playerData.ID, "public_id": playerData.PublicID, "name": playerData.Name, "slug": playerData.Slug, "shortName": playerData.ShortName, "position": playerData.Positions},
+			"id":                   batsman.ID,
+			"public_id":            batsman.PublicID,
+			"match_id":             batsman.MatchID,
+			"team_id":              batsman.TeamID,
+			"batsman_id":           batsman.BatsmanID,
+			"runs_scored":          batsman.RunsScored,
+			"balls_faced":          batsman.BallsFaced,
+			"fours":                batsman.Fours,
+			"sixes":                batsman.Sixes,
+			"batting_status":       batsman.BattingStatus,
+			"is_striker":           batsman.IsStriker,
+			"is_currently_batting": batsman.IsCurrentlyBatting,
+			"inning_number":        batsman.InningNumber,
+		})
+	}
+	return batsmen
+}
+
+func (s *CricketServer) bowlerPayload(ctx *gin.Context, currentBowler *models.BowlerScore) map[string]interface{} {
+	if currentBowler == nil {
+		return nil
+	}
+	playerData, err := s.store.GetPlayerByID(ctx, int64(currentBowler.BowlerID))
+	if err != nil {
+		s.logger.Error("Failed to get bowler player: ", err)
+		return nil
+	}
+	return map[string]interface{}{
+		"player":            map[string]interface{}{"id": playerData.ID, "public_id": playerData.PublicID, "name": playerData.Name, "slug": playerData.Slug, "shortName": playerData.ShortName, "position": playerData.Positions},
+		"id":                currentBowler.ID,
+		"public_id":         currentBowler.PublicID,
+		"match_id":          currentBowler.MatchID,
+		"team_id":           currentBowler.TeamID,
+		"bowler_id":         currentBowler.BowlerID,
+		"ball_number":       currentBowler.BallNumber,
+		"runs":              currentBowler.Runs,
+		"wide":              currentBowler.Wide,
+		"no_ball":           currentBowler.NoBall,
+		"wickets":           currentBowler.Wickets,
+		"bowling_status":    currentBowler.BowlingStatus,
+		"is_current_bowler": currentBowler.IsCurrentBowler,
+		"inning_number":     currentBowler.InningNumber,
+	}
+}
@@ -0,0 +1,95 @@
+package cricket
+
+import (
+	"net/http"
+
+	errorhandler "khelogames/error_handler"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+// ResumeCricketBatsmanFunc brings a retired hurt batsman back in when a wicket falls or another batsman retires
+func (s *CricketServer) ResumeCricketBatsmanFunc(ctx *gin.Context) {
+	s.logger.Info("Received request to resume cricket batsman")
+	var req struct {
+		MatchPublicID       string `json:"match_public_id"`
+		BattingTeamPublicID string `json:"batting_team_public_id"`
+		BatsmanPublicID     string `json:"batsman_public_id"`
+		InningNumber        int    `json:"inning_number"`
+	}
+
+	err := ctx.ShouldBindBodyWith(&req, binding.JSON)
+	if err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	battingTeamPublicID, err := uuid.Parse(req.BattingTeamPublicID)
+	if err != nil {
+		s.logger.Error("Invalid batting team UUID format", err)
+		fieldErrors := map[string]string{"batting_team_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	batsmanPublicID, err := uuid.Parse(req.BatsmanPublicID)
+	if err != nil {
+		s.logger.Error("Invalid batsman UUID format", err)
+		fieldErrors := map[string]string{"batsman_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	if !s.checkMatchUpdatePermission(ctx, matchPublicID) {
+		return
+	}
+
+	_, currentBatsman, err := s.txStore.ResumeCricketBatsmanTx(ctx, matchPublicID, battingTeamPublicID, batsmanPublicID, req.InningNumber)
+	if err != nil {
+		s.logger.Error("Failed to resume batsman: ", err)
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to resume batsman.",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	payload := map[string]interface{}{
+		"batsman":    s.currentBatsmenPayload(ctx, currentBatsman),
+		"event_type": "resume_batsman",
+	}
+
+	if s.scoreBroadcaster != nil {
+		err := s.scoreBroadcaster.BroadcastCricketEvent(ctx, "RESUME_BATSMAN", payload)
+		if err != nil {
+			s.logger.Warn("Failed to broadcast resumed batsman: ", err)
+		}
+	}
+
+	s.logger.Info("Successfully resumed cricket batsman")
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"type":    "RESUME_BATSMAN",
+			"payload": payload,
+		},
+	})
+}
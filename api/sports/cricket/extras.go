@@ -0,0 +1,201 @@
+package cricket
+
+import (
+	"net/http"
+
+	errorhandler "khelogames/error_handler"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+type updateCricketExtrasRequest struct {
+	MatchPublicID         string `json:"match_public_id"`
+	BattingTeamPublicID   string `json:"batting_team_public_id"`
+	BowlerPublicID        string `json:"bowler_public_id"`
+	AwardedToTeamPublicID string `json:"awarded_to_team_public_id"`
+	ExtrasType            string `json:"extras_type"`
+	RunsScored            int    `json:"runs_scored"`
+	InningNumber          int    `json:"inning_number"`
+}
+
+// UpdateCricketExtrasFunc adds byes or leg byes to the batting team, or a five run penalty to the
+// team it is awarded to. The runs are not credited to the batsman and are not charged to the bowler.
+func (s *CricketServer) UpdateCricketExtrasFunc(ctx *gin.Context) {
+	s.logger.Info("Received request to update cricket extras")
+	var req updateCricketExtrasRequest
+
+	err := ctx.ShouldBindBodyWith(&req, binding.JSON)
+	if err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	if req.ExtrasType != "bye" && req.ExtrasType != "leg_bye" && req.ExtrasType != "penalty" {
+		fieldErrors := map[string]string{"extras_type": "Must be one of bye, leg_bye or penalty"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	if req.RunsScored < 0 {
+		fieldErrors := map[string]string{"runs_scored": "Must not be negative"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	if req.ExtrasType == "penalty" {
+		s.addCricketPenaltyRuns(ctx, req)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	battingTeamPublicID, err := uuid.Parse(req.BattingTeamPublicID)
+	if err != nil {
+		s.logger.Error("Invalid batting team UUID format", err)
+		fieldErrors := map[string]string{"batting_team_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	bowlerPublicID, err := uuid.Parse(req.BowlerPublicID)
+	if err != nil {
+		s.logger.Error("Invalid bowler UUID format", err)
+		fieldErrors := map[string]string{"bowler_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	if !s.checkMatchUpdatePermission(ctx, matchPublicID) {
+		return
+	}
+
+	_, currentBatsman, bowlerResponse, inningScore, err := s.txStore.AddCricketExtrasTx(ctx, matchPublicID, battingTeamPublicID, bowlerPublicID, req.ExtrasType, int32(req.RunsScored), req.InningNumber)
+	if err != nil {
+		s.logger.Error("Failed to update extras: ", err)
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to update extras.",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	var striker map[string]interface{}
+	var nonStriker map[string]interface{}
+	for _, batsman := range s.currentBatsmenPayload(ctx, currentBatsman) {
+		if batsman["is_striker"].(bool) {
+			striker = batsman
+		} else {
+			nonStriker = batsman
+		}
+	}
+
+	payload := map[string]interface{}{
+		"striker_batsman":     striker,
+		"non_striker_batsman": nonStriker,
+		"bowler":              s.bowlerPayload(ctx, bowlerResponse),
+		"inning_score":        inningScore,
+		"event_type":          req.ExtrasType,
+	}
+
+	if s.scoreBroadcaster != nil {
+		err := s.scoreBroadcaster.BroadcastCricketEvent(ctx, "UPDATE_SCORE", payload)
+		if err != nil {
+			s.logger.Warn("Failed to broadcast cricket extras: ", err)
+		}
+	}
+	s.pushCricketCommentary(ctx, matchPublicID)
+	s.pushCricketPartnerships(ctx, matchPublicID)
+
+	s.logger.Info("Successfully updated cricket extras")
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"type":    "UPDATE_SCORE",
+			"payload": payload,
+		},
+	})
+}
+
+// addCricketPenaltyRuns awards a penalty outside of a delivery, no bowler is involved
+func (s *CricketServer) addCricketPenaltyRuns(ctx *gin.Context, req updateCricketExtrasRequest) {
+	if req.RunsScored != 0 && req.RunsScored != 5 {
+		fieldErrors := map[string]string{"runs_scored": "Penalty runs are always 5"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	awardedToTeamPublicID, err := uuid.Parse(req.AwardedToTeamPublicID)
+	if err != nil {
+		s.logger.Error("Invalid awarded team UUID format", err)
+		fieldErrors := map[string]string{"awarded_to_team_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	if !s.checkMatchUpdatePermission(ctx, matchPublicID) {
+		return
+	}
+
+	penalty, inningScore, err := s.txStore.AddCricketPenaltyRunsTx(ctx, matchPublicID, awardedToTeamPublicID)
+	if err != nil {
+		s.logger.Error("Failed to add penalty runs: ", err)
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to add penalty runs.",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	payload := map[string]interface{}{
+		"penalty":      penalty,
+		"inning_score": inningScore,
+		"event_type":   req.ExtrasType,
+	}
+
+	if s.scoreBroadcaster != nil {
+		err := s.scoreBroadcaster.BroadcastCricketEvent(ctx, "UPDATE_SCORE", payload)
+		if err != nil {
+			s.logger.Warn("Failed to broadcast cricket penalty runs: ", err)
+		}
+	}
+
+	s.logger.Info("Successfully added cricket penalty runs")
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"type":    "UPDATE_SCORE",
+			"payload": payload,
+		},
+	})
+}
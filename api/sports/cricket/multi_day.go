@@ -0,0 +1,222 @@
+package cricket
+
+import (
+	"net/http"
+
+	crickethelper "khelogames/api/sports/cricket_helper"
+	"khelogames/database/models"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+var cricketSessions = map[string]bool{
+	"lunch":      true,
+	"tea":        true,
+	"drinks":     true,
+	"rain_delay": true,
+	"bad_light":  true,
+	"stumps":     true,
+	"play":       true,
+}
+
+// DeclareCricketInningFunc closes the running inning of a multi day match by declaration
+func (s *CricketServer) DeclareCricketInningFunc(ctx *gin.Context) {
+	s.logger.Info("Received request to declare cricket inning")
+	var req struct {
+		MatchPublicID string `json:"match_public_id" binding:"required"`
+		TeamPublicID  string `json:"team_public_id" binding:"required"`
+		InningNumber  int    `json:"inning_number" binding:"required,min=1"`
+	}
+
+	err := ctx.ShouldBindBodyWith(&req, binding.JSON)
+	if err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	teamPublicID, err := uuid.Parse(req.TeamPublicID)
+	if err != nil {
+		s.logger.Error("Invalid team UUID format", err)
+		fieldErrors := map[string]string{"team_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	if !s.checkMatchUpdatePermission(ctx, matchPublicID) {
+		return
+	}
+
+	inningScore, _, _, err := s.txStore.DeclareCricketInningTx(ctx, matchPublicID, teamPublicID, req.InningNumber)
+	if err != nil {
+		s.logger.Error("Failed to declare inning: ", err)
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to declare inning.",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	matchData, err := s.store.GetMatchModelByPublicId(ctx, matchPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get match model: ", err)
+	} else if matchData != nil {
+		err = s.UpdateMatchStatusAndResult(ctx, inningScore, matchData, matchData.ID)
+		if err != nil {
+			s.logger.Error("Failed to update match status and result: ", err)
+		}
+	}
+
+	payload := map[string]interface{}{
+		"match_public_id": matchPublicID,
+		"inning":          inningScore,
+		"next_inning":     s.nextInningPayload(ctx, matchData),
+	}
+	if matchData != nil {
+		payload["status_code"] = matchData.StatusCode
+		payload["result"] = matchData.Result
+	}
+
+	if s.scoreBroadcaster != nil {
+		err := s.scoreBroadcaster.BroadcastCricketEvent(ctx, "DECLARE_INNING", payload)
+		if err != nil {
+			s.logger.Warn("Failed to broadcast declared inning: ", err)
+		}
+	}
+
+	s.logger.Info("Successfully declared cricket inning")
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"type":    "DECLARE_INNING",
+			"payload": payload,
+		},
+	})
+}
+
+// UpdateCricketSessionFunc records a break in play (lunch, tea, drinks, rain, bad light, stumps)
+// or its resumption. Play after stumps starts the next day, stumps on the last day ends the match.
+func (s *CricketServer) UpdateCricketSessionFunc(ctx *gin.Context) {
+	s.logger.Info("Received request to update cricket session")
+	var req struct {
+		MatchPublicID string `json:"match_public_id" binding:"required"`
+		Session       string `json:"session" binding:"required"`
+	}
+
+	err := ctx.ShouldBindBodyWith(&req, binding.JSON)
+	if err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	if !cricketSessions[req.Session] {
+		fieldErrors := map[string]string{"session": "Must be one of lunch, tea, drinks, rain_delay, bad_light, stumps or play"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	if !s.checkMatchUpdatePermission(ctx, matchPublicID) {
+		return
+	}
+
+	match, result, err := s.txStore.UpdateCricketSessionTx(ctx, matchPublicID, req.Session)
+	if err != nil {
+		s.logger.Error("Failed to update session: ", err)
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to update session.",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	payload := map[string]interface{}{
+		"match_public_id": matchPublicID,
+		"day_number":      match.DayNumber,
+		"sub_status":      match.SubStatus,
+		"status_code":     match.StatusCode,
+		"result":          match.Result,
+	}
+	if result != nil {
+		payload["result_description"] = result.Description
+	}
+
+	if s.scoreBroadcaster != nil {
+		err := s.scoreBroadcaster.BroadcastCricketEvent(ctx, "UPDATE_SESSION", payload)
+		if err != nil {
+			s.logger.Warn("Failed to broadcast session: ", err)
+		}
+	}
+
+	s.logger.Info("Successfully updated cricket session")
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"type":    "UPDATE_SESSION",
+			"payload": payload,
+		},
+	})
+}
+
+// nextInningPayload describes the inning to be played next, including super overs of a
+// tied knockout, nil once the match is over
+func (s *CricketServer) nextInningPayload(ctx *gin.Context, matchData *models.Match) map[string]interface{} {
+	if matchData == nil || matchData.StatusCode == "finished" {
+		return nil
+	}
+
+	inningScores, err := s.store.GetCricketScores(ctx, int32(matchData.ID))
+	if err != nil {
+		s.logger.Error("Failed to get cricket scores: ", err)
+		return nil
+	}
+
+	innings := crickethelper.InningSummaries(inningScores)
+	maxInnings := crickethelper.MaxInnings(matchData.MatchFormat)
+	superOver := matchData.SubStatus != nil && *matchData.SubStatus == crickethelper.SuperOverSubStatus
+	if superOver {
+		maxInnings = len(innings) + 1
+	}
+	inningNumber := crickethelper.NextInningNumber(innings, maxInnings)
+	if inningNumber == 0 {
+		return nil
+	}
+	return map[string]interface{}{
+		"inning_number":       inningNumber,
+		"super_over":          superOver,
+		"follow_on_available": inningNumber == 3 && crickethelper.CanEnforceFollowOn(innings, crickethelper.MatchDays(matchData.MatchFormat)),
+	}
+}
@@ -0,0 +1,149 @@
+package cricket
+
+import (
+	"khelogames/database/models"
+	"net/http"
+
+	crickethelper "khelogames/api/sports/cricket_helper"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetCricketOverSummaryFunc returns the runs and wickets of every over of each inning with the
+// running totals, for the worm and Manhattan charts. Chasing innings of a limited overs match
+// also carry the required run rate after each over.
+func (s *CricketServer) GetCricketOverSummaryFunc(ctx *gin.Context) {
+	var req struct {
+		MatchPublicID string `uri:"match_public_id" binding:"required"`
+	}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	match, err := s.store.GetMatchModelByPublicId(ctx, matchPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get match: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get match",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	deliveries, err := s.store.GetCricketMatchDeliveries(ctx, int32(match.ID))
+	if err != nil {
+		s.logger.Error("Failed to get cricket deliveries: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get cricket deliveries",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	inningScores, err := s.store.GetCricketScores(ctx, int32(match.ID))
+	if err != nil {
+		s.logger.Error("Failed to get cricket scores: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get cricket scores",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	revision, err := s.store.GetCricketRevisedTarget(ctx, int32(match.ID))
+	if err != nil {
+		s.logger.Error("Failed to get revised target: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get revised target",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	penalties, err := s.store.GetCricketMatchPenaltyRuns(ctx, int32(match.ID))
+	if err != nil {
+		s.logger.Error("Failed to get cricket penalty runs: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get cricket penalty runs",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	innings := crickethelper.InningSummaries(inningScores)
+	limitedOvers := crickethelper.MaxOvers(match.MatchFormat) > 0
+
+	data := []map[string]interface{}{}
+	for start := 0; start < len(deliveries); {
+		end := start
+		for end < len(deliveries) && deliveries[end].InningNumber == deliveries[start].InningNumber {
+			end++
+		}
+		inningNumber := deliveries[start].InningNumber
+
+		var inningPenalties []models.CricketPenaltyRuns
+		for _, penalty := range penalties {
+			if *penalty.InningNumber == inningNumber {
+				inningPenalties = append(inningPenalties, penalty)
+			}
+		}
+
+		overs := crickethelper.OverSummaries(deliveries[start:end], inningPenalties)
+		target := 0
+		if limitedOvers {
+			target = crickethelper.ChaseTarget(innings, inningNumber)
+			if inningNumber == 2 && revision != nil {
+				target = revision.RevisedTarget
+			}
+			crickethelper.ApplyRequiredRunRate(overs, target, crickethelper.AllottedOvers(match.MatchFormat, revision, inningNumber))
+		}
+
+		inning := map[string]interface{}{
+			"inning_number": inningNumber,
+			"team_id":       deliveries[start].TeamID,
+			"overs":         overs,
+		}
+		if target > 0 {
+			inning["target"] = target
+		}
+		data = append(data, inning)
+		start = end
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    data,
+	})
+}
@@ -0,0 +1,163 @@
+package cricket
+
+import (
+	"net/http"
+
+	crickethelper "khelogames/api/sports/cricket_helper"
+	"khelogames/database/models"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetCricketPartnershipsFunc returns the partnerships and fall of wickets of every inning of a match
+func (s *CricketServer) GetCricketPartnershipsFunc(ctx *gin.Context) {
+	var req struct {
+		MatchPublicID string `uri:"match_public_id" binding:"required"`
+	}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	innings, err := s.cricketPartnershipsPayload(ctx, matchPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get cricket partnerships: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get cricket partnerships",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    innings,
+	})
+}
+
+// cricketPartnershipsPayload groups the partnerships and fall of wickets of the match by inning
+func (s *CricketServer) cricketPartnershipsPayload(ctx *gin.Context, matchPublicID uuid.UUID) ([]map[string]interface{}, error) {
+	match, err := s.store.GetMatchModelByPublicId(ctx, matchPublicID)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.store.GetCricketMatchDeliveries(ctx, int32(match.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	wickets, err := s.store.GetCricketMatchWickets(ctx, int32(match.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	players := make(map[int32]map[string]interface{})
+	player := func(playerID int32) map[string]interface{} {
+		if data, ok := players[playerID]; ok {
+			return data
+		}
+		playerData, err := s.store.GetPlayerByID(ctx, int64(playerID))
+		if err != nil {
+			s.logger.Error("Failed to get player: ", err)
+			return nil
+		}
+		players[playerID] = map[string]interface{}{"id": playerData.ID, "public_id": playerData.PublicID, "name": playerData.Name, "slug": playerData.Slug, "shortName": playerData.ShortName, "position": playerData.Positions}
+		return players[playerID]
+	}
+
+	var innings []map[string]interface{}
+	for start := 0; start < len(deliveries); {
+		end := start
+		for end < len(deliveries) && deliveries[end].InningNumber == deliveries[start].InningNumber {
+			end++
+		}
+		inningNumber := deliveries[start].InningNumber
+
+		var partnerships []map[string]interface{}
+		for _, partnership := range crickethelper.Partnerships(deliveries[start:end]) {
+			partnerships = append(partnerships, map[string]interface{}{
+				"wicket_number": partnership.WicketNumber,
+				"runs":          partnership.Runs,
+				"balls":         partnership.Balls,
+				"extras":        partnership.Extras,
+				"unbeaten":      partnership.Unbeaten,
+				"first_batsman": map[string]interface{}{
+					"player": player(partnership.FirstBatsmanID),
+					"runs":   partnership.FirstBatsmanRuns,
+					"balls":  partnership.FirstBatsmanBalls,
+				},
+				"second_batsman": map[string]interface{}{
+					"player": player(partnership.SecondBatsmanID),
+					"runs":   partnership.SecondBatsmanRuns,
+					"balls":  partnership.SecondBatsmanBalls,
+				},
+			})
+		}
+
+		var inningWickets []models.Wicket
+		for _, wicket := range wickets {
+			if wicket.InningNumber == inningNumber {
+				inningWickets = append(inningWickets, wicket)
+			}
+		}
+		fallOfWickets := []map[string]interface{}{}
+		for _, fall := range crickethelper.FallOfWickets(inningWickets) {
+			fallOfWickets = append(fallOfWickets, map[string]interface{}{
+				"wicket_number": fall.WicketNumber,
+				"score":         fall.Score,
+				"over":          fall.Over,
+				"batsman":       player(fall.BatsmanID),
+			})
+		}
+
+		innings = append(innings, map[string]interface{}{
+			"inning_number":   inningNumber,
+			"team_id":         deliveries[start].TeamID,
+			"partnerships":    partnerships,
+			"fall_of_wickets": fallOfWickets,
+		})
+		start = end
+	}
+
+	if innings == nil {
+		innings = []map[string]interface{}{}
+	}
+	return innings, nil
+}
+
+// pushCricketPartnerships sends the refreshed partnerships and fall of wickets to the match subscribers
+func (s *CricketServer) pushCricketPartnerships(ctx *gin.Context, matchPublicID uuid.UUID) {
+	if s.scoreBroadcaster == nil {
+		return
+	}
+
+	innings, err := s.cricketPartnershipsPayload(ctx, matchPublicID)
+	if err != nil {
+		s.logger.Warn("Failed to get cricket partnerships: ", err)
+		return
+	}
+
+	err = s.scoreBroadcaster.BroadcastMatchEvent(ctx, "UPDATE_PARTNERSHIPS", map[string]interface{}{
+		"match_public_id": matchPublicID,
+		"innings":         innings,
+	})
+	if err != nil {
+		s.logger.Warn("Failed to broadcast partnerships: ", err)
+	}
+}
@@ -424,6 +424,9 @@ func (s *CricketServer) AddCricketBallFunc(ctx *gin.Context) {
 	newBowlerResponse, prevBowler, err = s.txStore.AddCricketBlowerTx(ctx, matchPublicID, teamPublicID, bowlerPublicID, prevBowlerPublicID, req.InningNumber)
 	if err != nil {
 		s.logger.Error("Failed to add cricket bowler: ", err)
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
 			"error": gin.H{
@@ -592,6 +595,41 @@ func (s *CricketServer) GetPlayerScoreFunc(ctx *gin.Context) {
 		return
 	}
 
+	matchData, err := s.store.GetMatchModelByPublicId(ctx, matchPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get match model:", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get match data.",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	matchExtras, err := s.store.GetCricketMatchExtras(ctx, int32(matchData.ID))
+	if err != nil {
+		s.logger.Error("Failed to get extras: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get extras data.",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	inningExtras := make(map[string]models.CricketExtras)
+	for _, extras := range matchExtras {
+		if int64(extras.TeamID) == battingTeam.ID {
+			inningExtras[strconv.Itoa(extras.InningNumber)] = extras
+		}
+	}
+
 	inningData := make(map[string][]map[string]interface{})
 	for _, playerScore := range teamPlayerScore {
 		playerData, err := s.store.GetPlayerByID(ctx, int64(playerScore.BatsmanID))
@@ -656,6 +694,7 @@ func (s *CricketServer) GetPlayerScoreFunc(ctx *gin.Context) {
 		scoreDetails = map[string]interface{}{
 			"battingTeam": map[string]interface{}{"id": battingTeam.ID, "public_id": battingTeam.PublicID, "name": battingTeam.Name, "slug": battingTeam.Slug, "shortName": battingTeam.Shortname, "gender": battingTeam.Gender, "national": battingTeam.National, "country": battingTeam.Country, "type": battingTeam.Type},
 			"innings":     inningData,
+			"extras":      inningExtras,
 		}
 	} else {
 		scoreDetails = map[string]interface{}{
@@ -992,11 +1031,44 @@ func (s *CricketServer) GetCricketWicketsFunc(ctx *gin.Context) {
 
 	s.logger.Debug("Successfully update the wickets: ", wicketsData)
 
+	fieldingResponse, err := s.store.GetCricketFieldingScores(ctx, int32(match.ID), int32(team.ID))
+	if err != nil {
+		s.logger.Error("Failed to get fielding scores : ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get fielding data.",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	fieldingData := make(map[string][]map[string]interface{})
+	for _, fielding := range fieldingResponse {
+		fielderPlayerData, err := s.store.GetPlayerByID(ctx, int64(fielding.PlayerID))
+		if err != nil {
+			s.logger.Error("Failed to get fielder data : ", err)
+			continue
+		}
+		fieldingInningNumber := strconv.Itoa(fielding.InningNumber)
+		fieldingData[fieldingInningNumber] = append(fieldingData[fieldingInningNumber], map[string]interface{}{
+			"player":        map[string]interface{}{"id": fielderPlayerData.ID, "public_id": fielderPlayerData.PublicID, "name": fielderPlayerData.Name, "slug": fielderPlayerData.Slug, "shortName": fielderPlayerData.ShortName, "position": fielderPlayerData.Positions},
+			"team_id":       fielding.TeamID,
+			"inning_number": fielding.InningNumber,
+			"catches":       fielding.Catches,
+			"stumpings":     fielding.Stumpings,
+			"run_outs":      fielding.RunOuts,
+		})
+	}
+
 	s.logger.Info("Successfully retrieved cricket wickets")
 	ctx.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data": gin.H{
-			"inning": inningData,
+			"inning":   inningData,
+			"fielding": fieldingData,
 		},
 	})
 }
@@ -1126,6 +1198,9 @@ func (s *CricketServer) UpdateWideBallFunc(ctx *gin.Context) {
 	batsmanResponse, currentBatsman, bowlerResponse, inningScore, err := s.txStore.UpdateWideRunsTx(ctx, matchPublicID, bowlerPublicID, batsmanTeamPublicID, int32(runsScored), inningNumber)
 	if err != nil {
 		s.logger.Error("Failed to update wide: ", err)
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
 			"error": gin.H{
@@ -1264,6 +1339,8 @@ func (s *CricketServer) UpdateWideBallFunc(ctx *gin.Context) {
 			s.logger.Warn("Failed to broadcast cricket wide ball: ", err)
 		}
 	}
+	s.pushCricketCommentary(ctx, matchPublicID)
+	s.pushCricketPartnerships(ctx, matchPublicID)
 
 	data := map[string]interface{}{
 		"type": "UPDATE_SCORE",
@@ -1378,6 +1455,9 @@ func (s *CricketServer) UpdateNoBallsRunsFunc(ctx *gin.Context) {
 	batsmanResponse, currentBatsman, bowlerResponse, inningScore, err := s.txStore.UpdateCricketNoBallTx(ctx, matchPublicID, bowlerPublicID, battingTeamPublicID, batsmanPublicID, int32(runsScored), inningNumber)
 	if err != nil {
 		s.logger.Error("Failed to update no_ball: ", err)
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
 			"error": gin.H{
@@ -1519,6 +1599,8 @@ func (s *CricketServer) UpdateNoBallsRunsFunc(ctx *gin.Context) {
 			s.logger.Warn("Broadcast failed (non-blocking): ", err)
 		}
 	}
+	s.pushCricketCommentary(ctx, matchPublicID)
+	s.pushCricketPartnerships(ctx, matchPublicID)
 
 	data := map[string]interface{}{
 		"type": "UPDATE_SCORE",
@@ -1595,18 +1677,19 @@ func (s *CricketServer) AddCricketWicketsFunc(ctx *gin.Context) {
 		return
 	}
 
-	fielderPublicID, err := uuid.Parse(req.FielderPublicID)
-	if err != nil {
-		s.logger.Error("Invalid fielder UUID format", err)
-		fieldErrors := map[string]string{"fielder_public_id": "Invalid UUID format"}
-		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
-		return
+	var fielderPublicID uuid.UUID
+	if req.FielderPublicID != "" {
+		fielderPublicID, err = uuid.Parse(req.FielderPublicID)
+		if err != nil {
+			s.logger.Error("Invalid fielder UUID format", err)
+			fieldErrors := map[string]string{"fielder_public_id": "Invalid UUID format"}
+			errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+			return
+		}
 	}
 
 	runsScored := int(req.RunsScored)
 	inningNumber := int(req.InningNumber)
-	wicketNumber := int(req.WicketNumber)
-	ballNumber := int(req.BallNumber)
 	wicketType := req.WicketType
 	toggleStriker := req.ToggleStriker
 
@@ -1623,6 +1706,17 @@ func (s *CricketServer) AddCricketWicketsFunc(ctx *gin.Context) {
 		})
 		return
 	}
+	if cricketScore == nil || cricketScore.IsInningCompleted {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INNING_COMPLETED",
+				"message": "Inning is not in progress, cannot add wicket",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
 
 	authPayload := ctx.MustGet(pkg.AuthorizationPayloadKey).(*token.Payload)
 
@@ -1674,18 +1768,18 @@ func (s *CricketServer) AddCricketWicketsFunc(ctx *gin.Context) {
 		battingTeamID,
 		batsmanPublicID,
 		bowlerPublicID,
-		wicketNumber,
 		wicketType,
-		ballNumber,
 		fielderPublicID,
-		*cricketScore,
-		&req.BowlType,
+		req.BowlType,
 		int32(runsScored),
 		inningNumber,
 		toggleStriker,
 	)
 	if err != nil {
 		s.logger.Error("Failed to add the cricket wicket: ", err)
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
 			"error": gin.H{
@@ -1869,6 +1963,11 @@ func (s *CricketServer) AddCricketWicketsFunc(ctx *gin.Context) {
 			s.logger.Warn("Failed to broadcast cricket add wicket: ", err)
 		}
 	}
+	// retirements happen between deliveries and have no commentary of their own
+	if dismissal, ok := db.GetCricketDismissal(wicketType); ok && !dismissal.BetweenDeliveries {
+		s.pushCricketCommentary(ctx, matchPublicID)
+	}
+	s.pushCricketPartnerships(ctx, matchPublicID)
 
 	data := map[string]interface{}{
 		"type": "UPDATE_SCORE",
@@ -2015,17 +2114,19 @@ func (s *CricketServer) UpdateInningScoreFunc(ctx *gin.Context) {
 	}
 
 	// Update inning score
-	batsmanResponse, bowlerResponse, inningScore, err := s.store.UpdateInningScore(
+	_, currentBatsman, bowlerResponse, inningScore, err := s.txStore.UpdateInningScoreTx(
 		ctx,
 		matchPublicID,
 		batsmanTeamPublicID,
-		batsmanPublicID,
 		bowlerPublicID,
 		int32(runsScored),
 		inningNumber,
 	)
 	if err != nil {
 		s.logger.Error("Failed to update innings: ", err)
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
 			"error": gin.H{
@@ -2037,8 +2138,6 @@ func (s *CricketServer) UpdateInningScoreFunc(ctx *gin.Context) {
 		return
 	}
 
-	fmt.Println("Batsman Score: ", batsmanResponse)
-
 	// Get match data
 	matchData, err := s.store.GetMatchModelByPublicId(ctx, matchPublicID)
 	if err != nil {
@@ -2069,59 +2168,12 @@ func (s *CricketServer) UpdateInningScoreFunc(ctx *gin.Context) {
 		inningsCompleted = true
 	}
 
-	// Handle striker rotation BEFORE innings completion
-	var currentBatsman []models.BatsmanScore
 	var strikerResponse models.BatsmanScore
 	var nonStrikerResponse models.BatsmanScore
 	var striker interface{}
 	var nonStriker interface{}
 	var bowler interface{}
 
-	// if !inningsCompleted {
-	// Determine if striker should rotate
-	shouldRotateStriker := false
-
-	if bowlerResponse.BallNumber%6 == 0 && runsScored%2 == 0 {
-		// Last ball of over, even runs -> rotate
-		shouldRotateStriker = true
-		fmt.Println("Rotating: Last ball of over with even runs")
-	} else if bowlerResponse.BallNumber%6 != 0 && runsScored%2 != 0 {
-		// Not last ball, odd runs -> rotate
-		shouldRotateStriker = true
-		fmt.Println("Rotating: Odd runs scored")
-	}
-
-	if shouldRotateStriker {
-		currentBatsman, err = s.store.ToggleCricketStricker(ctx, matchPublicID, inningNumber)
-		if err != nil {
-			s.logger.Error("Failed to toggle striker: ", err)
-			ctx.JSON(http.StatusInternalServerError, gin.H{
-				"success": false,
-				"error": gin.H{
-					"code":    "INTERNAL_ERROR",
-					"message": "Failed to toggle striker",
-				},
-				"request_id": ctx.GetString("request_id"),
-			})
-			return
-		}
-	} else {
-		currentBatsman, err = s.store.GetCurrentBattingBatsman(ctx, matchPublicID, batsmanTeamPublicID, inningNumber)
-		if err != nil {
-			s.logger.Error("Failed to get current batsmen: ", err)
-			ctx.JSON(http.StatusInternalServerError, gin.H{
-				"success": false,
-				"error": gin.H{
-					"code":    "INTERNAL_ERROR",
-					"message": "Failed to get current batsmen",
-				},
-				"request_id": ctx.GetString("request_id"),
-			})
-			return
-		}
-
-	}
-
 	fmt.Println("Current batsmen after rotation: ", currentBatsman)
 
 	// Assign striker and non-striker
@@ -2277,11 +2329,15 @@ func (s *CricketServer) UpdateInningScoreFunc(ctx *gin.Context) {
 		"inning_status":       inningScore.InningStatus,
 	}
 
+	// a legal ball ending the over means the next over needs a new bowler
+	overCompleted := inningScore.Overs > 0 && inningScore.Overs%6 == 0
+
 	inningData := map[string]interface{}{
 		"striker_batsman":     striker,
 		"non_striker_batsman": nonStriker,
 		"bowler":              bowler,
 		"inning_score":        inningPayload,
+		"over_completed":      overCompleted,
 		"event_type":          "normal",
 	}
 
@@ -2300,6 +2356,8 @@ func (s *CricketServer) UpdateInningScoreFunc(ctx *gin.Context) {
 			return
 		}
 	}
+	s.pushCricketCommentary(ctx, matchPublicID)
+	s.pushCricketPartnerships(ctx, matchPublicID)
 
 	data := map[string]interface{}{
 		"type": "UPDATE_SCORE",
@@ -2308,6 +2366,7 @@ func (s *CricketServer) UpdateInningScoreFunc(ctx *gin.Context) {
 			"non_striker_batsman": nonStriker,
 			"bowler":              bowler,
 			"inning_score":        inningPayload,
+			"over_completed":      overCompleted,
 			"event_type":          "normal",
 		},
 	}
@@ -2650,6 +2709,9 @@ func (s *CricketServer) UpdateBowlingBowlerFunc(ctx *gin.Context) {
 	currentBowlerResponse, nextBowlerResponse, err := s.txStore.UpdateBowlingBowlerStatusTx(ctx, matchPublicID, teamPublicID, currentBowlerPublicID, nextBowlerPublicID, req.InningNumber)
 	if err != nil {
 		s.logger.Error("Failed to update current bowler status: ", err)
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
 			"error": gin.H{
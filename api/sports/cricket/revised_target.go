@@ -0,0 +1,98 @@
+package cricket
+
+import (
+	"net/http"
+
+	errorhandler "khelogames/error_handler"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+// AddCricketInterruptionFunc records overs lost to rain or bad light in a limited overs match.
+// The revised target and par score are recomputed with the DLS resource table.
+func (s *CricketServer) AddCricketInterruptionFunc(ctx *gin.Context) {
+	s.logger.Info("Received request to add cricket interruption")
+	var req struct {
+		MatchPublicID       string `json:"match_public_id"`
+		BattingTeamPublicID string `json:"batting_team_public_id"`
+		InningNumber        int    `json:"inning_number"`
+		OversLost           int    `json:"overs_lost"`
+	}
+
+	err := ctx.ShouldBindBodyWith(&req, binding.JSON)
+	if err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	if req.InningNumber != 1 && req.InningNumber != 2 {
+		fieldErrors := map[string]string{"inning_number": "Must be 1 or 2"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	if req.OversLost <= 0 {
+		fieldErrors := map[string]string{"overs_lost": "Must be greater than 0"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	battingTeamPublicID, err := uuid.Parse(req.BattingTeamPublicID)
+	if err != nil {
+		s.logger.Error("Invalid batting team UUID format", err)
+		fieldErrors := map[string]string{"batting_team_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	if !s.checkMatchUpdatePermission(ctx, matchPublicID) {
+		return
+	}
+
+	revision, inningScore, err := s.txStore.AddCricketInterruptionTx(ctx, matchPublicID, battingTeamPublicID, req.InningNumber, req.OversLost)
+	if err != nil {
+		s.logger.Error("Failed to add interruption: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to add interruption.",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	payload := map[string]interface{}{
+		"match_public_id": matchPublicID,
+		"revised_target":  revision,
+		"inning_score":    inningScore,
+	}
+
+	if s.scoreBroadcaster != nil {
+		err := s.scoreBroadcaster.BroadcastCricketEvent(ctx, "UPDATE_REVISED_TARGET", payload)
+		if err != nil {
+			s.logger.Warn("Failed to broadcast revised target: ", err)
+		}
+	}
+
+	s.logger.Info("Successfully added cricket interruption")
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"type":    "UPDATE_REVISED_TARGET",
+			"payload": payload,
+		},
+	})
+}
@@ -0,0 +1,47 @@
+package cricket
+
+import (
+	"context"
+	"khelogames/api/sports"
+	db "khelogames/database"
+	"khelogames/database/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var _ sports.Sport = (*CricketServer)(nil)
+
+func (s *CricketServer) Name() string {
+	return "cricket"
+}
+
+func (s *CricketServer) ScoreSummary(matches []db.GetMatchByIDRow, tournamentPublicID uuid.UUID) []map[string]interface{} {
+	return s.GetCricketScore(matches, tournamentPublicID)
+}
+
+// StartMatch has nothing to set up, each inning is added as it begins (AddCricketScoreFunc)
+func (s *CricketServer) StartMatch(ctx context.Context, q *db.Queries, match *models.Match) error {
+	return nil
+}
+
+func (s *CricketServer) MatchResult(ctx context.Context, q *db.Queries, match *models.Match) (*int32, error) {
+	return s.txStore.CricketMatchResult(ctx, q, match)
+}
+
+func (s *CricketServer) UpdateStandings(ctx context.Context, q *db.Queries, match *models.Match) error {
+	return s.txStore.UpdateCricketStandings(ctx, q, match)
+}
+
+// FinishMatch has nothing more to record, the player scores are kept ball by ball
+func (s *CricketServer) FinishMatch(ctx context.Context, q *db.Queries, match *models.Match) error {
+	return nil
+}
+
+func (s *CricketServer) CreateStanding(ctx context.Context, tournamentPublicID uuid.UUID, groupID int32, teamPublicID uuid.UUID) (interface{}, error) {
+	return s.store.CreateCricketStanding(ctx, tournamentPublicID, groupID, teamPublicID)
+}
+
+func (s *CricketServer) GetTopPerformerFunc(ctx *gin.Context) {
+	s.GetCricketTopPerformerFunc(ctx)
+}
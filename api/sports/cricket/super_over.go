@@ -0,0 +1,33 @@
+package cricket
+
+import (
+	"context"
+	"khelogames/database/models"
+
+	crickethelper "khelogames/api/sports/cricket_helper"
+)
+
+// recordCricketTie finishes a tied match with no winner. A tied limited overs knockout
+// needs a winner, so it is kept in progress and goes to a super over instead.
+func (s *CricketServer) recordCricketTie(ctx context.Context, matchData *models.Match) error {
+	if matchData.Stage != "knockout" || crickethelper.IsMultiDayFormat(matchData.MatchFormat) {
+		updateMatchStatusResponse, err := s.store.UpdateMatchResult(ctx, int32(matchData.ID), 0)
+		if err != nil {
+			s.logger.Error("Failed to update match result (tie): ", err)
+			return err
+		}
+		matchData.StatusCode = updateMatchStatusResponse.StatusCode
+		matchData.Result = updateMatchStatusResponse.Result
+		return nil
+	}
+
+	updatedMatch, err := s.store.UpdateMatchSubStatus(ctx, matchData.PublicID, crickethelper.SuperOverSubStatus)
+	if err != nil {
+		s.logger.Error("Failed to start super over: ", err)
+		return err
+	}
+	if updatedMatch != nil {
+		matchData.SubStatus = updatedMatch.SubStatus
+	}
+	return nil
+}
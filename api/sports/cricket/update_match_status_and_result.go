@@ -3,6 +3,8 @@ package cricket
 import (
 	"context"
 	"khelogames/database/models"
+
+	crickethelper "khelogames/api/sports/cricket_helper"
 )
 
 func (s *CricketServer) UpdateMatchStatusAndResult(ctx context.Context, inningScore *models.CricketScore, matchData *models.Match, matchID int64) error {
@@ -10,46 +12,98 @@ func (s *CricketServer) UpdateMatchStatusAndResult(ctx context.Context, inningSc
 		return nil
 	}
 
+	// the revised target of a shortened match is set once the first inning is over
+	revision, err := s.txStore.RefreshCricketRevisedTargetTx(ctx, matchData.PublicID)
+	if err != nil {
+		s.logger.Error("Failed to update revised target: ", err)
+		return err
+	}
+
 	matchInningScore, err := s.store.GetCricketScores(ctx, int32(matchID))
 	if err != nil {
 		s.logger.Error("Failed to get both inning scores: ", err)
 		return err
 	}
 
+	if crickethelper.IsMultiDayFormat(matchData.MatchFormat) {
+		// two innings a side: decided by an innings, runs or wickets, a draw comes only at stumps on the last day
+		result := crickethelper.DecideMultiDayMatch(crickethelper.InningSummaries(matchInningScore), false)
+		if !result.Decided {
+			return nil
+		}
+		updateMatchStatusResponse, err := s.store.UpdateMatchResult(ctx, int32(matchID), result.WinnerTeamID)
+		if err != nil {
+			s.logger.Error("Failed to update match result: ", err)
+			return err
+		}
+		matchData.StatusCode = updateMatchStatusResponse.StatusCode
+		matchData.Result = updateMatchStatusResponse.Result
+		return nil
+	}
+
+	if len(matchInningScore) > 2 {
+		// tied knockout: decided by the latest super over, a tied super over is followed by another
+		result := crickethelper.DecideSuperOver(crickethelper.InningSummaries(matchInningScore))
+		if !result.Decided {
+			return nil
+		}
+		updateMatchStatusResponse, err := s.store.UpdateMatchResult(ctx, int32(matchID), result.WinnerTeamID)
+		if err != nil {
+			s.logger.Error("Failed to update match result: ", err)
+			return err
+		}
+		matchData.StatusCode = updateMatchStatusResponse.StatusCode
+		matchData.Result = updateMatchStatusResponse.Result
+		return nil
+	}
+
 	if len(matchInningScore) == 2 {
 		// ODI / T20 format: 2 innings total
 		if !matchInningScore[0].IsInningCompleted || !matchInningScore[1].IsInningCompleted {
 			return nil
 		}
-		if matchInningScore[0].Score > matchInningScore[1].Score {
-			updateMatchStatusResponse, err := s.store.UpdateMatchResult(ctx, int32(matchID), int32(matchInningScore[0].TeamID))
+
+		if revision != nil && revision.RevisedTarget > 0 {
+			// shortened match: the chase is measured against the revised target
+			var winnerTeamID int32
+			switch crickethelper.ResultByRevisedTarget(revision.RevisedTarget, revision.ParScore, matchInningScore[1].Score, true) {
+			case crickethelper.ChaseWon:
+				winnerTeamID = matchInningScore[1].TeamID
+			case crickethelper.ChaseLost:
+				winnerTeamID = matchInningScore[0].TeamID
+			case crickethelper.ChaseTied:
+				return s.recordCricketTie(ctx, matchData)
+			}
+			updateMatchStatusResponse, err := s.store.UpdateMatchResult(ctx, int32(matchID), winnerTeamID)
 			if err != nil {
 				s.logger.Error("Failed to update match result: ", err)
 				return err
 			}
 			matchData.StatusCode = updateMatchStatusResponse.StatusCode
 			matchData.Result = updateMatchStatusResponse.Result
-		} else if matchInningScore[0].Score < matchInningScore[1].Score {
-			updateMatchStatusResponse, err := s.store.UpdateMatchResult(ctx, int32(matchID), int32(matchInningScore[1].TeamID))
+			return nil
+		}
+
+		if matchInningScore[0].Score > matchInningScore[1].Score {
+			updateMatchStatusResponse, err := s.store.UpdateMatchResult(ctx, int32(matchID), int32(matchInningScore[0].TeamID))
 			if err != nil {
 				s.logger.Error("Failed to update match result: ", err)
 				return err
 			}
 			matchData.StatusCode = updateMatchStatusResponse.StatusCode
 			matchData.Result = updateMatchStatusResponse.Result
-		} else {
-			// Scores are equal - it's a tie (result=0 means no winner)
-			updateMatchStatusResponse, err := s.store.UpdateMatchResult(ctx, int32(matchID), 0)
+		} else if matchInningScore[0].Score < matchInningScore[1].Score {
+			updateMatchStatusResponse, err := s.store.UpdateMatchResult(ctx, int32(matchID), int32(matchInningScore[1].TeamID))
 			if err != nil {
-				s.logger.Error("Failed to update match result (tie): ", err)
+				s.logger.Error("Failed to update match result: ", err)
 				return err
 			}
 			matchData.StatusCode = updateMatchStatusResponse.StatusCode
 			matchData.Result = updateMatchStatusResponse.Result
+		} else {
+			// Scores are equal - it's a tie (result=0 means no winner), a knockout goes to a super over
+			return s.recordCricketTie(ctx, matchData)
 		}
-	} else if len(matchInningScore) == 4 {
-		// Adding the test functionality in future
-		return nil
 	}
 
 	return nil
@@ -0,0 +1,164 @@
+package cricketutils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Commentary event types
+const (
+	CommentaryBall       = "ball"
+	CommentaryBoundary   = "boundary"
+	CommentaryExtras     = "extras"
+	CommentaryWicket     = "wicket"
+	CommentaryOverEnd    = "over_end"
+	CommentaryMilestone  = "milestone"
+	CommentaryFiveWicket = "five_wicket_haul"
+)
+
+// CommentaryLine is one templated line of commentary
+type CommentaryLine struct {
+	EventType string
+	Text      string
+}
+
+// DeliveryCommentary holds what a delivery line is generated from
+type DeliveryCommentary struct {
+	OverNumber       int
+	BallNumber       int
+	Bowler           string
+	Striker          string
+	Runs             int
+	ExtrasType       *string
+	ExtrasRuns       int
+	IsWicket         bool
+	WicketType       *string
+	DismissedBatsman string
+	Fielder          string
+}
+
+// BallLabel formats the over and ball of a delivery as in a scorecard, 12.3 is the third ball of the thirteenth over
+func BallLabel(overNumber, ballNumber int) string {
+	return fmt.Sprintf("%d.%d", overNumber, ballNumber)
+}
+
+func runsText(runs int) string {
+	switch runs {
+	case 0:
+		return "no run"
+	case 1:
+		return "1 run"
+	}
+	return fmt.Sprintf("%d runs", runs)
+}
+
+func extrasText(extrasType string, runs int) string {
+	name := strings.ReplaceAll(extrasType, "_", " ")
+	switch extrasType {
+	case "wide", "no_ball":
+		if runs <= 1 {
+			return name
+		}
+		return fmt.Sprintf("%s, %d runs", name, runs)
+	}
+	if runs == 1 {
+		return "1 " + name
+	}
+	return fmt.Sprintf("%d %ss", runs, name)
+}
+
+func dismissalText(in DeliveryCommentary) string {
+	wicketType := ""
+	if in.WicketType != nil {
+		wicketType = *in.WicketType
+	}
+	batsman := in.DismissedBatsman
+	if batsman == "" {
+		batsman = in.Striker
+	}
+	switch wicketType {
+	case "Bowled":
+		return fmt.Sprintf("%s b %s", batsman, in.Bowler)
+	case "Caught":
+		if in.Fielder == in.Bowler {
+			return fmt.Sprintf("%s c & b %s", batsman, in.Bowler)
+		}
+		return fmt.Sprintf("%s c %s b %s", batsman, in.Fielder, in.Bowler)
+	case "LBW":
+		return fmt.Sprintf("%s lbw b %s", batsman, in.Bowler)
+	case "Stumped":
+		return fmt.Sprintf("%s st %s b %s", batsman, in.Fielder, in.Bowler)
+	case "Hit Wicket":
+		return fmt.Sprintf("%s hit wicket b %s", batsman, in.Bowler)
+	case "Run Out":
+		if in.Fielder != "" {
+			return fmt.Sprintf("%s run out (%s)", batsman, in.Fielder)
+		}
+		return fmt.Sprintf("%s run out", batsman)
+	}
+	if wicketType == "" {
+		return fmt.Sprintf("%s is out", batsman)
+	}
+	return fmt.Sprintf("%s %s", batsman, strings.ToLower(wicketType))
+}
+
+// DeliveryLine generates the line describing a single delivery
+func DeliveryLine(in DeliveryCommentary) CommentaryLine {
+	prefix := fmt.Sprintf("%s %s to %s, ", BallLabel(in.OverNumber, in.BallNumber), in.Bowler, in.Striker)
+
+	if in.IsWicket {
+		text := prefix + "OUT! " + dismissalText(in)
+		if in.Runs+in.ExtrasRuns > 0 {
+			text += fmt.Sprintf(", %s completed", runsText(in.Runs+in.ExtrasRuns))
+		}
+		return CommentaryLine{EventType: CommentaryWicket, Text: text}
+	}
+
+	if in.ExtrasType != nil {
+		text := prefix + extrasText(*in.ExtrasType, in.ExtrasRuns)
+		if in.Runs > 0 {
+			text += fmt.Sprintf(" and %s off the bat", runsText(in.Runs))
+		}
+		return CommentaryLine{EventType: CommentaryExtras, Text: text}
+	}
+
+	switch in.Runs {
+	case 4:
+		return CommentaryLine{EventType: CommentaryBoundary, Text: prefix + "FOUR!"}
+	case 6:
+		return CommentaryLine{EventType: CommentaryBoundary, Text: prefix + "SIX!"}
+	}
+	return CommentaryLine{EventType: CommentaryBall, Text: prefix + runsText(in.Runs)}
+}
+
+// OverEndLine summarises a completed over with the score of the inning
+func OverEndLine(overNumber int, bowler string, overRuns int, score int, wickets int) CommentaryLine {
+	return CommentaryLine{
+		EventType: CommentaryOverEnd,
+		Text:      fmt.Sprintf("End of over %d: %s from %s, score %d/%d", overNumber+1, runsText(overRuns), bowler, score, wickets),
+	}
+}
+
+// MilestoneLine returns a line when the batsman has just reached fifty or a hundred
+func MilestoneLine(batsman string, runsBefore int, runs int, balls int) *CommentaryLine {
+	for _, milestone := range []int{200, 150, 100, 50} {
+		if runsBefore < milestone && runs >= milestone {
+			return &CommentaryLine{
+				EventType: CommentaryMilestone,
+				Text:      fmt.Sprintf("%d up for %s, off %d balls", milestone, batsman, balls),
+			}
+		}
+	}
+	return nil
+}
+
+// FiveWicketLine returns a line when the bowler has just taken a fifth wicket
+func FiveWicketLine(bowler string, wickets int, runs int, bowlerWicket bool) *CommentaryLine {
+	if !bowlerWicket || wickets != 5 {
+		return nil
+	}
+	return &CommentaryLine{
+		EventType: CommentaryFiveWicket,
+		Text:      fmt.Sprintf("Five-wicket haul for %s, %d/%d", bowler, wickets, runs),
+	}
+}
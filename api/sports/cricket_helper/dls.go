@@ -0,0 +1,214 @@
+package cricketutils
+
+import "math"
+
+const (
+	// MaxTableOvers is the number of overs the resource table is built for
+	MaxTableOvers = 50
+	// MaxTableWickets is the number of wickets the resource table is built for
+	MaxTableWickets = 10
+	// DefaultG50 is the average first innings score used when the chasing side has more resources
+	DefaultG50 = 245
+	// T20G50 is the G50 of a T20 match, where the chasing side gains fewer runs from the extra
+	// resources than in a 50 over match
+	T20G50 = 200
+)
+
+// Decay of the run scoring resource per over used by the default table
+const resourceDecay = 0.0275
+
+// Asymptotic share of the resources left with w wickets lost, fitted so that
+// 50 overs remaining matches the published resource percentages
+var resourceAsymptote = [MaxTableWickets]float64{
+	1.0, 0.885, 0.7606, 0.631, 0.5006, 0.3758, 0.2621, 0.1644, 0.0889, 0.0351,
+}
+
+// ResourceTable holds the percentage of resources remaining indexed by
+// overs remaining (0 to 50) and wickets lost (0 to 9)
+type ResourceTable struct {
+	rows [][]float64
+}
+
+// DefaultResourceTable builds an approximation of the published resource table
+// from an exponential run scoring model
+func DefaultResourceTable() *ResourceTable {
+	total := 1 - math.Exp(-resourceDecay*MaxTableOvers)
+	rows := make([][]float64, MaxTableOvers+1)
+	for overs := 0; overs <= MaxTableOvers; overs++ {
+		rows[overs] = make([]float64, MaxTableWickets)
+		for wickets := 0; wickets < MaxTableWickets; wickets++ {
+			f := resourceAsymptote[wickets]
+			resource := 100 * f * (1 - math.Exp(-resourceDecay*float64(overs)/f)) / total
+			rows[overs][wickets] = math.Round(resource*10) / 10
+		}
+	}
+	return &ResourceTable{rows: rows}
+}
+
+// Resource returns the percentage of resources remaining with the given balls
+// left and wickets lost, partial overs are interpolated by ball
+func (t *ResourceTable) Resource(ballsRemaining int, wicketsLost int) float64 {
+	if wicketsLost >= MaxTableWickets || ballsRemaining <= 0 {
+		return 0
+	}
+	if wicketsLost < 0 {
+		wicketsLost = 0
+	}
+	if ballsRemaining >= MaxTableOvers*6 {
+		return t.rows[MaxTableOvers][wicketsLost]
+	}
+	overs := ballsRemaining / 6
+	balls := ballsRemaining % 6
+	lower := t.rows[overs][wicketsLost]
+	if balls == 0 {
+		return lower
+	}
+	upper := t.rows[overs+1][wicketsLost]
+	return lower + (upper-lower)*float64(balls)/6
+}
+
+// FormatG50 returns the G50 used for the revised targets of the format
+func FormatG50(matchFormat *string) int {
+	if matchFormat != nil && *matchFormat == "T20" {
+		return T20G50
+	}
+	return DefaultG50
+}
+
+// Interruption is a stoppage in play during which overs were lost
+type Interruption struct {
+	InningNumber int
+	BallsBowled  int
+	Wickets      int
+	OversLost    int
+}
+
+// DLSInput describes a limited overs match affected by interruptions
+type DLSInput struct {
+	MaxOvers         int
+	FirstInningScore int
+	Interruptions    []Interruption
+	G50              int
+}
+
+// DLSResult is the revision for the chasing side
+type DLSResult struct {
+	FirstInningOvers      int
+	SecondInningOvers     int
+	FirstInningResources  float64
+	SecondInningResources float64
+	RevisedTarget         int
+}
+
+// InningOvers returns the overs allotted to each side once the lost overs are removed.
+// Overs lost in the first inning are taken off both sides.
+func InningOvers(maxOvers int, interruptions []Interruption) (int, int) {
+	firstInningOvers := maxOvers
+	secondInningOvers := maxOvers
+	for _, interruption := range interruptions {
+		if interruption.InningNumber == 1 {
+			firstInningOvers -= interruption.OversLost
+			secondInningOvers -= interruption.OversLost
+		} else {
+			secondInningOvers -= interruption.OversLost
+		}
+	}
+	return max(firstInningOvers, 0), max(secondInningOvers, 0)
+}
+
+// inningResources returns the resources available to a side starting with the
+// given overs, less the resources lost at each of its interruptions
+func (t *ResourceTable) inningResources(startOvers int, interruptions []Interruption) float64 {
+	resources := t.Resource(startOvers*6, 0)
+	oversLeft := startOvers
+	for _, interruption := range interruptions {
+		before := oversLeft*6 - interruption.BallsBowled
+		after := before - interruption.OversLost*6
+		resources -= t.Resource(before, interruption.Wickets) - t.Resource(after, interruption.Wickets)
+		oversLeft -= interruption.OversLost
+	}
+	return resources
+}
+
+// RevisedTarget computes the target of the chasing side, a G50 of 0 uses DefaultG50
+func (t *ResourceTable) RevisedTarget(input DLSInput) DLSResult {
+	g50 := input.G50
+	if g50 == 0 {
+		g50 = DefaultG50
+	}
+
+	var firstInning, secondInning []Interruption
+	for _, interruption := range input.Interruptions {
+		if interruption.InningNumber == 1 {
+			firstInning = append(firstInning, interruption)
+		} else {
+			secondInning = append(secondInning, interruption)
+		}
+	}
+
+	firstInningOvers, secondInningOvers := InningOvers(input.MaxOvers, input.Interruptions)
+
+	// the first inning started with the full overs, the second with what was left after the first inning
+	r1 := t.inningResources(input.MaxOvers, firstInning)
+	secondInningStart := input.MaxOvers
+	for _, interruption := range firstInning {
+		secondInningStart -= interruption.OversLost
+	}
+	r2 := t.inningResources(secondInningStart, secondInning)
+
+	var target int
+	if r1 <= 0 {
+		target = 0
+	} else if r2 < r1 {
+		target = int(math.Floor(float64(input.FirstInningScore)*r2/r1)) + 1
+	} else {
+		target = int(math.Floor(float64(input.FirstInningScore)+float64(g50)*(r2-r1)/100)) + 1
+	}
+
+	return DLSResult{
+		FirstInningOvers:      firstInningOvers,
+		SecondInningOvers:     secondInningOvers,
+		FirstInningResources:  math.Round(r1*10) / 10,
+		SecondInningResources: math.Round(r2*10) / 10,
+		RevisedTarget:         target,
+	}
+}
+
+// ParScore returns the score the chasing side needs to be level with the target at
+// the given point of the second inning
+func (t *ResourceTable) ParScore(result DLSResult, ballsBowled, wicketsLost int) int {
+	if result.SecondInningResources <= 0 || result.RevisedTarget == 0 {
+		return 0
+	}
+	remaining := t.Resource(result.SecondInningOvers*6-ballsBowled, wicketsLost)
+	used := result.SecondInningResources - remaining
+	if used < 0 {
+		used = 0
+	}
+	return int(math.Floor(float64(result.RevisedTarget-1) * used / result.SecondInningResources))
+}
+
+// Outcome of a shortened match from the chasing side's point of view
+const (
+	ChaseLost = -1
+	ChaseTied = 0
+	ChaseWon  = 1
+)
+
+// ResultByRevisedTarget decides a shortened match. A completed chase is measured against
+// the revised target, a chase stopped with no further play against the par score.
+func ResultByRevisedTarget(revisedTarget, parScore, chaseScore int, chaseCompleted bool) int {
+	if chaseScore >= revisedTarget {
+		return ChaseWon
+	}
+	tieScore := revisedTarget - 1
+	if !chaseCompleted {
+		tieScore = parScore
+	}
+	if chaseScore > tieScore {
+		return ChaseWon
+	} else if chaseScore == tieScore {
+		return ChaseTied
+	}
+	return ChaseLost
+}
@@ -0,0 +1,214 @@
+package cricketutils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestResource(t *testing.T) {
+	table := DefaultResourceTable()
+
+	tests := []struct {
+		name           string
+		ballsRemaining int
+		wicketsLost    int
+		want           float64
+	}{
+		{name: "full innings", ballsRemaining: 300, wicketsLost: 0, want: 100},
+		{name: "twenty overs", ballsRemaining: 120, wicketsLost: 0, want: 56.6},
+		{name: "twenty overs five down", ballsRemaining: 120, wicketsLost: 5, want: 38.7},
+		{name: "one over", ballsRemaining: 6, wicketsLost: 0, want: 3.6},
+		{name: "half an over is interpolated", ballsRemaining: 9, wicketsLost: 0, want: 5.4},
+		{name: "more than the table is the full innings", ballsRemaining: 600, wicketsLost: 3, want: table.Resource(300, 3)},
+		{name: "negative wickets count as none", ballsRemaining: 120, wicketsLost: -1, want: 56.6},
+		{name: "no balls left", ballsRemaining: 0, wicketsLost: 0, want: 0},
+		{name: "all out", ballsRemaining: 120, wicketsLost: MaxTableWickets, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := table.Resource(tt.ballsRemaining, tt.wicketsLost)
+			if math.Abs(got-tt.want) > 0.05 {
+				t.Errorf("Resource(%d, %d) = %v, want %v", tt.ballsRemaining, tt.wicketsLost, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatG50(t *testing.T) {
+	t20, odi := "T20", "ODI"
+
+	tests := []struct {
+		name        string
+		matchFormat *string
+		want        int
+	}{
+		{name: "T20", matchFormat: &t20, want: T20G50},
+		{name: "ODI", matchFormat: &odi, want: DefaultG50},
+		{name: "no format", want: DefaultG50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatG50(tt.matchFormat); got != tt.want {
+				t.Errorf("FormatG50() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInningOvers(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxOvers      int
+		interruptions []Interruption
+		wantFirst     int
+		wantSecond    int
+	}{
+		{name: "no interruption", maxOvers: 50, wantFirst: 50, wantSecond: 50},
+		{
+			name:          "first inning overs are lost by both sides",
+			maxOvers:      50,
+			interruptions: []Interruption{{InningNumber: 1, OversLost: 10}},
+			wantFirst:     40,
+			wantSecond:    40,
+		},
+		{
+			name:          "second inning overs are lost by the chasing side",
+			maxOvers:      50,
+			interruptions: []Interruption{{InningNumber: 1, OversLost: 5}, {InningNumber: 2, OversLost: 10}},
+			wantFirst:     45,
+			wantSecond:    35,
+		},
+		{
+			name:          "never below zero",
+			maxOvers:      20,
+			interruptions: []Interruption{{InningNumber: 2, OversLost: 25}},
+			wantFirst:     20,
+			wantSecond:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, second := InningOvers(tt.maxOvers, tt.interruptions)
+			if first != tt.wantFirst || second != tt.wantSecond {
+				t.Errorf("InningOvers() = %d, %d, want %d, %d", first, second, tt.wantFirst, tt.wantSecond)
+			}
+		})
+	}
+}
+
+func TestRevisedTarget(t *testing.T) {
+	table := DefaultResourceTable()
+
+	tests := []struct {
+		name  string
+		input DLSInput
+		want  DLSResult
+	}{
+		{
+			name:  "uninterrupted match",
+			input: DLSInput{MaxOvers: 50, FirstInningScore: 250},
+			want:  DLSResult{FirstInningOvers: 50, SecondInningOvers: 50, FirstInningResources: 100, SecondInningResources: 100, RevisedTarget: 251},
+		},
+		{
+			name:  "uninterrupted twenty over match",
+			input: DLSInput{MaxOvers: 20, FirstInningScore: 160},
+			want:  DLSResult{FirstInningOvers: 20, SecondInningOvers: 20, FirstInningResources: 56.6, SecondInningResources: 56.6, RevisedTarget: 161},
+		},
+		{
+			name: "chase shortened, target scaled down",
+			input: DLSInput{
+				MaxOvers:         50,
+				FirstInningScore: 250,
+				Interruptions:    []Interruption{{InningNumber: 2, BallsBowled: 120, Wickets: 2, OversLost: 10}},
+			},
+			want: DLSResult{FirstInningOvers: 50, SecondInningOvers: 40, FirstInningResources: 100, SecondInningResources: 85, RevisedTarget: 213},
+		},
+		{
+			name: "first inning cut short, target raised with G50",
+			input: DLSInput{
+				MaxOvers:         50,
+				FirstInningScore: 200,
+				Interruptions:    []Interruption{{InningNumber: 1, BallsBowled: 240, Wickets: 3, OversLost: 10}},
+			},
+			want: DLSResult{FirstInningOvers: 40, SecondInningOvers: 40, FirstInningResources: 70.2, SecondInningResources: 89.3, RevisedTarget: 247},
+		},
+		{
+			name: "configured G50",
+			input: DLSInput{
+				MaxOvers:         50,
+				FirstInningScore: 200,
+				G50:              200,
+				Interruptions:    []Interruption{{InningNumber: 1, BallsBowled: 240, Wickets: 3, OversLost: 10}},
+			},
+			want: DLSResult{FirstInningOvers: 40, SecondInningOvers: 40, FirstInningResources: 70.2, SecondInningResources: 89.3, RevisedTarget: 239},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := table.RevisedTarget(tt.input)
+			if got != tt.want {
+				t.Errorf("RevisedTarget() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParScore(t *testing.T) {
+	table := DefaultResourceTable()
+	result := table.RevisedTarget(DLSInput{
+		MaxOvers:         50,
+		FirstInningScore: 250,
+		Interruptions:    []Interruption{{InningNumber: 2, BallsBowled: 120, Wickets: 2, OversLost: 10}},
+	})
+
+	tests := []struct {
+		name        string
+		result      DLSResult
+		ballsBowled int
+		wicketsLost int
+		want        int
+	}{
+		{name: "before the first ball", result: result, want: 0},
+		{name: "at the interruption", result: result, ballsBowled: 120, wicketsLost: 2, want: 81},
+		{name: "all the overs bowled", result: result, ballsBowled: 240, want: result.RevisedTarget - 1},
+		{name: "no revised target", result: DLSResult{}, ballsBowled: 120, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := table.ParScore(tt.result, tt.ballsBowled, tt.wicketsLost)
+			if got != tt.want {
+				t.Errorf("ParScore() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResultByRevisedTarget(t *testing.T) {
+	tests := []struct {
+		name           string
+		chaseScore     int
+		chaseCompleted bool
+		want           int
+	}{
+		{name: "target reached", chaseScore: 213, chaseCompleted: true, want: ChaseWon},
+		{name: "one short of the target is a tie", chaseScore: 212, chaseCompleted: true, want: ChaseTied},
+		{name: "completed chase short of the target", chaseScore: 200, chaseCompleted: true, want: ChaseLost},
+		{name: "stopped chase ahead of par", chaseScore: 82, want: ChaseWon},
+		{name: "stopped chase level with par", chaseScore: 81, want: ChaseTied},
+		{name: "stopped chase behind par", chaseScore: 80, want: ChaseLost},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ResultByRevisedTarget(213, 81, tt.chaseScore, tt.chaseCompleted)
+			if got != tt.want {
+				t.Errorf("ResultByRevisedTarget() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package cricketutils
+
+import (
+	"fmt"
+	"khelogames/database/models"
+	"sort"
+)
+
+// InningSummary is the part of an inning score needed to sequence innings and decide a result
+type InningSummary struct {
+	TeamID       int32
+	InningNumber int
+	Score        int
+	Wickets      int
+	Completed    bool
+	FollowOn     bool
+}
+
+// MatchResult is the outcome of a match, Decided is false while it can still go either way
+type MatchResult struct {
+	Decided      bool
+	Draw         bool
+	Tie          bool
+	WinnerTeamID int32
+	Description  string
+}
+
+// InningSummaries converts the stored inning scores of a match
+func InningSummaries(scores []models.CricketScore) []InningSummary {
+	innings := make([]InningSummary, 0, len(scores))
+	for _, score := range scores {
+		innings = append(innings, InningSummary{
+			TeamID:       score.TeamID,
+			InningNumber: score.InningNumber,
+			Score:        score.Score,
+			Wickets:      score.Wickets,
+			Completed:    score.IsInningCompleted,
+			FollowOn:     score.FollowOn,
+		})
+	}
+	return innings
+}
+
+// IsMultiDayFormat reports whether the format is played over several days with two innings a side
+func IsMultiDayFormat(matchFormat *string) bool {
+	return matchFormat != nil && *matchFormat == "Test"
+}
+
+// MaxInnings returns the number of innings in a match of the format
+func MaxInnings(matchFormat *string) int {
+	if IsMultiDayFormat(matchFormat) {
+		return 4
+	}
+	return 2
+}
+
+// MaxOvers returns the overs per side of a limited overs format, 0 when the format has no limit
+func MaxOvers(matchFormat *string) int {
+	if matchFormat == nil {
+		return 0
+	}
+	switch *matchFormat {
+	case "T20":
+		return 20
+	case "ODI":
+		return 50
+	}
+	return 0
+}
+
+// MatchDays returns the scheduled days of play of the format
+func MatchDays(matchFormat *string) int {
+	if IsMultiDayFormat(matchFormat) {
+		return 5
+	}
+	return 1
+}
+
+// FollowOnLead returns the first innings lead needed to enforce the follow-on
+func FollowOnLead(days int) int {
+	switch {
+	case days >= 5:
+		return 200
+	case days >= 3:
+		return 150
+	case days == 2:
+		return 100
+	}
+	return 75
+}
+
+func sortInnings(innings []InningSummary) []InningSummary {
+	sorted := append([]InningSummary(nil), innings...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].InningNumber < sorted[j].InningNumber
+	})
+	return sorted
+}
+
+// CanEnforceFollowOn reports whether the side batting first may ask the other side to bat again
+func CanEnforceFollowOn(innings []InningSummary, days int) bool {
+	sorted := sortInnings(innings)
+	if len(sorted) != 2 || !sorted[1].Completed {
+		return false
+	}
+	return sorted[0].Score-sorted[1].Score >= FollowOnLead(days)
+}
+
+// NextInningNumber returns the inning to be played next, 0 when the match has no innings left
+func NextInningNumber(innings []InningSummary, maxInnings int) int {
+	sorted := sortInnings(innings)
+	if len(sorted) >= maxInnings {
+		return 0
+	}
+	if len(sorted) > 0 && !sorted[len(sorted)-1].Completed {
+		return 0
+	}
+	return len(sorted) + 1
+}
+
+// ValidateNextInning checks that the team may bat the given inning and reports whether
+// it would be following on. A non empty message describes why the inning is not allowed.
+func ValidateNextInning(innings []InningSummary, teamID int32, inningNumber int, maxInnings int, days int) (bool, string) {
+	sorted := sortInnings(innings)
+	if inningNumber != len(sorted)+1 {
+		return false, fmt.Sprintf("Next inning must be inning %d", len(sorted)+1)
+	}
+	if inningNumber > maxInnings {
+		return false, fmt.Sprintf("Match has only %d innings", maxInnings)
+	}
+	if len(sorted) > 0 && !sorted[len(sorted)-1].Completed {
+		return false, "Previous inning is still in progress"
+	}
+
+	switch inningNumber {
+	case 2:
+		if teamID == sorted[0].TeamID {
+			return false, "Team batted the previous inning"
+		}
+	case 3:
+		if teamID == sorted[1].TeamID {
+			if !CanEnforceFollowOn(sorted, days) {
+				return false, "Lead is not enough to enforce the follow-on"
+			}
+			return true, ""
+		}
+	case 4:
+		if teamID == sorted[2].TeamID {
+			return false, "Team batted the previous inning"
+		}
+	}
+	return false, ""
+}
+
+// DecideMultiDayMatch works out the result of a two innings a side match. When timeUp
+// is set an undecided match is drawn.
+func DecideMultiDayMatch(innings []InningSummary, timeUp bool) MatchResult {
+	sorted := sortInnings(innings)
+	if len(sorted) == 0 {
+		if timeUp {
+			return MatchResult{Decided: true, Draw: true, Description: "Match drawn"}
+		}
+		return MatchResult{}
+	}
+
+	totals := make(map[int32]int)
+	for _, inning := range sorted {
+		totals[inning.TeamID] += inning.Score
+	}
+	first := sorted[0].TeamID
+	var second int32
+	for teamID := range totals {
+		if teamID != first {
+			second = teamID
+		}
+	}
+
+	// a side which batted twice and is still behind loses by an innings
+	if len(sorted) == 3 && sorted[2].Completed {
+		twice := sorted[2].TeamID
+		once := first
+		if twice == first {
+			once = second
+		}
+		if totals[twice] < totals[once] {
+			return MatchResult{
+				Decided:      true,
+				WinnerTeamID: once,
+				Description:  fmt.Sprintf("won by an innings and %d runs", totals[once]-totals[twice]),
+			}
+		}
+	}
+
+	if len(sorted) == 4 {
+		chasing := sorted[3].TeamID
+		defending := first
+		if chasing == first {
+			defending = second
+		}
+		if totals[chasing] > totals[defending] {
+			wicketsLeft := 10 - sorted[3].Wickets
+			return MatchResult{
+				Decided:      true,
+				WinnerTeamID: chasing,
+				Description:  fmt.Sprintf("won by %d wickets", wicketsLeft),
+			}
+		}
+		if sorted[3].Completed {
+			if totals[chasing] == totals[defending] {
+				return MatchResult{Decided: true, Tie: true, Description: "Match tied"}
+			}
+			return MatchResult{
+				Decided:      true,
+				WinnerTeamID: defending,
+				Description:  fmt.Sprintf("won by %d runs", totals[defending]-totals[chasing]),
+			}
+		}
+	}
+
+	if timeUp {
+		return MatchResult{Decided: true, Draw: true, Description: "Match drawn"}
+	}
+	return MatchResult{}
+}
@@ -0,0 +1,120 @@
+package cricketutils
+
+import "khelogames/database/models"
+
+// Partnership is the stand between two batsmen until a wicket falls or one of them retires
+type Partnership struct {
+	InningNumber       int   `json:"inning_number"`
+	WicketNumber       int   `json:"wicket_number"`
+	FirstBatsmanID     int32 `json:"first_batsman_id"`
+	SecondBatsmanID    int32 `json:"second_batsman_id"`
+	FirstBatsmanRuns   int   `json:"first_batsman_runs"`
+	FirstBatsmanBalls  int   `json:"first_batsman_balls"`
+	SecondBatsmanRuns  int   `json:"second_batsman_runs"`
+	SecondBatsmanBalls int   `json:"second_batsman_balls"`
+	Extras             int   `json:"extras"`
+	Runs               int   `json:"runs"`
+	Balls              int   `json:"balls"`
+	Unbeaten           bool  `json:"unbeaten"`
+}
+
+// FallOfWicket is the inning score when a batsman was dismissed
+type FallOfWicket struct {
+	InningNumber int    `json:"inning_number"`
+	WicketNumber int    `json:"wicket_number"`
+	BatsmanID    int32  `json:"batsman_id"`
+	Score        int    `json:"score"`
+	Over         string `json:"over"`
+}
+
+// OverLabel formats the legal balls of an inning as overs, 75 balls is 12.3
+func OverLabel(balls int) string {
+	return BallLabel(balls/6, balls%6)
+}
+
+func (p *Partnership) add(delivery models.CricketDelivery) {
+	ballFaced := 1
+	if delivery.ExtrasType != nil && *delivery.ExtrasType == "wide" {
+		ballFaced = 0
+	}
+	if delivery.StrikerID == p.FirstBatsmanID {
+		p.FirstBatsmanRuns += delivery.Runs
+		p.FirstBatsmanBalls += ballFaced
+	} else {
+		p.SecondBatsmanRuns += delivery.Runs
+		p.SecondBatsmanBalls += ballFaced
+	}
+	p.Extras += delivery.ExtrasRuns
+	p.Runs += delivery.Runs + delivery.ExtrasRuns
+	if delivery.IsLegal {
+		p.Balls++
+	}
+}
+
+// Partnerships rebuilds the partnerships of an inning from its deliveries in the order they
+// were bowled. A new partnership starts after a wicket or when the batting pair changes
+// between deliveries, as after a retirement. The last one stays unbeaten.
+func Partnerships(deliveries []models.CricketDelivery) []Partnership {
+	var partnerships []Partnership
+	var current *Partnership
+	wickets := 0
+	for _, delivery := range deliveries {
+		if delivery.IsUndone {
+			continue
+		}
+		samePair := current != nil &&
+			((current.FirstBatsmanID == delivery.StrikerID && current.SecondBatsmanID == delivery.NonStrikerID) ||
+				(current.FirstBatsmanID == delivery.NonStrikerID && current.SecondBatsmanID == delivery.StrikerID))
+		if !samePair {
+			if current != nil {
+				partnerships = append(partnerships, *current)
+			}
+			current = &Partnership{
+				InningNumber:    delivery.InningNumber,
+				WicketNumber:    wickets + 1,
+				FirstBatsmanID:  delivery.StrikerID,
+				SecondBatsmanID: delivery.NonStrikerID,
+				Unbeaten:        true,
+			}
+		}
+
+		current.add(delivery)
+
+		if delivery.IsWicket {
+			wickets++
+			current.Unbeaten = false
+			partnerships = append(partnerships, *current)
+			current = nil
+		}
+	}
+	if current != nil {
+		partnerships = append(partnerships, *current)
+	}
+	// a partnership broken by a retirement is not unbeaten either
+	for i := 0; i < len(partnerships)-1; i++ {
+		partnerships[i].Unbeaten = false
+	}
+	return partnerships
+}
+
+// FallOfWickets lists the inning score at each dismissal, retired hurt batsmen are not out
+func FallOfWickets(wickets []models.Wicket) []FallOfWicket {
+	var fall []FallOfWicket
+	for _, wicket := range wickets {
+		if wicket.WicketType == "Retired Hurt" {
+			continue
+		}
+		score := 0
+		if wicket.Score != nil {
+			score = *wicket.Score
+		}
+		fall = append(fall, FallOfWicket{
+			InningNumber: wicket.InningNumber,
+			WicketNumber: wicket.WicketsNumber,
+			BatsmanID:    wicket.BatsmanID,
+			Score:        score,
+			Over:         OverLabel(wicket.BallNumber),
+		})
+	}
+	return fall
+}
@@ -0,0 +1,75 @@
+package cricketutils
+
+import "fmt"
+
+const (
+	// SuperOverOvers is the length of a super over inning
+	SuperOverOvers = 1
+	// SuperOverWickets ends a super over inning
+	SuperOverWickets = 2
+	// SuperOverSubStatus marks a tied knockout that is being decided by super overs
+	SuperOverSubStatus = "super_over"
+)
+
+// IsSuperOverInning reports whether the inning is a super over played after the regulation innings
+func IsSuperOverInning(matchFormat *string, inningNumber int) bool {
+	return !IsMultiDayFormat(matchFormat) && inningNumber > 2
+}
+
+// ValidateSuperOverInning checks the batting order of a super over: the side batting second
+// in the previous pair bats first in the next one, and another super over is only played when
+// the previous one was tied. A non empty message describes why the inning is not allowed.
+func ValidateSuperOverInning(innings []InningSummary, teamID int32, inningNumber int) string {
+	sorted := sortInnings(innings)
+	if inningNumber != len(sorted)+1 {
+		return fmt.Sprintf("Next inning must be inning %d", len(sorted)+1)
+	}
+	if len(sorted) < 2 || !sorted[len(sorted)-1].Completed {
+		return "Previous inning is still in progress"
+	}
+
+	if inningNumber%2 == 1 && len(sorted) >= 4 && !SuperOverTied(innings) {
+		return "Previous super over was not tied"
+	}
+
+	previous := sorted[len(sorted)-1].TeamID
+	if inningNumber%2 == 1 && teamID != previous {
+		return "Side batting second bats first in the super over"
+	}
+	if inningNumber%2 == 0 && teamID == previous {
+		return "Team batted the previous inning"
+	}
+	return ""
+}
+
+// DecideSuperOver works out the latest completed super over. The match stays undecided
+// while a super over is in progress or when the last one was tied, in which case another
+// super over is due.
+func DecideSuperOver(innings []InningSummary) MatchResult {
+	sorted := sortInnings(innings)
+	if len(sorted) < 4 || len(sorted)%2 != 0 {
+		return MatchResult{}
+	}
+
+	first, second := sorted[len(sorted)-2], sorted[len(sorted)-1]
+	if second.Score > first.Score {
+		return MatchResult{Decided: true, WinnerTeamID: second.TeamID, Description: "won the super over"}
+	}
+	if !second.Completed {
+		return MatchResult{}
+	}
+	if first.Score > second.Score {
+		return MatchResult{Decided: true, WinnerTeamID: first.TeamID, Description: "won the super over"}
+	}
+	return MatchResult{}
+}
+
+// SuperOverTied reports whether the latest super over ended level
+func SuperOverTied(innings []InningSummary) bool {
+	sorted := sortInnings(innings)
+	if len(sorted) < 4 || len(sorted)%2 != 0 {
+		return false
+	}
+	first, second := sorted[len(sorted)-2], sorted[len(sorted)-1]
+	return second.Completed && first.Score == second.Score
+}
@@ -0,0 +1,130 @@
+package cricketutils
+
+import (
+	"math"
+	"time"
+
+	"khelogames/database/models"
+)
+
+// OverSummary is an over of an inning with the inning totals at its end, used for the
+// Manhattan (per over) and worm (cumulative) charts
+type OverSummary struct {
+	OverNumber      int      `json:"over_number"`
+	Runs            int      `json:"runs"`
+	Extras          int      `json:"extras"`
+	Wickets         int      `json:"wickets"`
+	Balls           int      `json:"balls"`
+	TotalRuns       int      `json:"total_runs"`
+	TotalWickets    int      `json:"total_wickets"`
+	RunRate         float64  `json:"run_rate"`
+	RequiredRunRate *float64 `json:"required_run_rate,omitempty"`
+}
+
+func roundRate(rate float64) float64 {
+	return math.Round(rate*100) / 100
+}
+
+// OverSummaries groups the deliveries of an inning by over. Overs are numbered from 1
+// and an over still being bowled is included with the balls bowled so far. Penalty runs
+// of the inning count as extras of the over bowled when they were awarded, those awarded
+// before the first ball count in the first over.
+func OverSummaries(deliveries []models.CricketDelivery, penalties []models.CricketPenaltyRuns) []OverSummary {
+	var overs []OverSummary
+	totalRuns, totalWickets, totalBalls := 0, 0, 0
+	updateTotals := func(over *OverSummary) {
+		over.TotalRuns = totalRuns
+		over.TotalWickets = totalWickets
+		if totalBalls > 0 {
+			over.RunRate = roundRate(float64(totalRuns) * 6 / float64(totalBalls))
+		}
+	}
+	// addPenalties adds the penalty runs awarded before the given time to the current over
+	addPenalties := func(before *time.Time) {
+		for len(penalties) > 0 && len(overs) > 0 && (before == nil || penalties[0].CreatedAt.Before(*before)) {
+			over := &overs[len(overs)-1]
+			over.Runs += penalties[0].Runs
+			over.Extras += penalties[0].Runs
+			totalRuns += penalties[0].Runs
+			updateTotals(over)
+			penalties = penalties[1:]
+		}
+	}
+
+	for _, delivery := range deliveries {
+		if delivery.IsUndone {
+			continue
+		}
+		addPenalties(&delivery.CreatedAt)
+		if len(overs) == 0 || overs[len(overs)-1].OverNumber != delivery.OverNumber+1 {
+			overs = append(overs, OverSummary{OverNumber: delivery.OverNumber + 1})
+		}
+		over := &overs[len(overs)-1]
+		over.Runs += delivery.Runs + delivery.ExtrasRuns
+		over.Extras += delivery.ExtrasRuns
+		totalRuns += delivery.Runs + delivery.ExtrasRuns
+		if delivery.IsLegal {
+			over.Balls++
+			totalBalls++
+		}
+		if delivery.IsWicket {
+			over.Wickets++
+			totalWickets++
+		}
+		updateTotals(over)
+	}
+	addPenalties(nil)
+	return overs
+}
+
+// ChaseTarget returns the runs the side batting in the inning needs to win a limited
+// overs match, 0 when the inning is not a chase. Super overs are chased in pairs like
+// the main innings.
+func ChaseTarget(innings []InningSummary, inningNumber int) int {
+	if inningNumber%2 != 0 {
+		return 0
+	}
+	for _, inning := range innings {
+		if inning.InningNumber == inningNumber-1 {
+			return inning.Score + 1
+		}
+	}
+	return 0
+}
+
+// AllottedOvers returns the overs allotted to the inning, 0 when the format has no limit.
+// The overs of a shortened match come from its revised target.
+func AllottedOvers(matchFormat *string, revision *models.CricketRevisedTarget, inningNumber int) int {
+	maxOvers := MaxOvers(matchFormat)
+	if maxOvers > 0 && IsSuperOverInning(matchFormat, inningNumber) {
+		return SuperOverOvers
+	}
+	if maxOvers == 0 || revision == nil {
+		return maxOvers
+	}
+	if inningNumber == 1 {
+		return revision.FirstInningOvers
+	}
+	return revision.SecondInningOvers
+}
+
+// ApplyRequiredRunRate sets the rate the chasing side needs after each over to reach the
+// target in the overs allotted. It stays unset once the allotted overs are used up.
+func ApplyRequiredRunRate(overs []OverSummary, target int, inningOvers int) {
+	if target == 0 || inningOvers == 0 {
+		return
+	}
+	totalBalls := 0
+	for i := range overs {
+		totalBalls += overs[i].Balls
+		ballsLeft := inningOvers*6 - totalBalls
+		if ballsLeft <= 0 {
+			continue
+		}
+		required := 0.0
+		if runsNeeded := target - overs[i].TotalRuns; runsNeeded > 0 {
+			required = roundRate(float64(runsNeeded) * 6 / float64(ballsLeft))
+		}
+		overs[i].RequiredRunRate = &required
+	}
+}
@@ -0,0 +1,162 @@
+package football
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"khelogames/api/sports"
+	footballhelper "khelogames/api/sports/football_helper"
+	"khelogames/database/models"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+// footballClockPayload is the clock as sent to the clients, who count the seconds
+// themselves from elapsed_seconds while is_running is set
+func footballClockPayload(matchPublicID uuid.UUID, clock *models.FootballMatchClock, now int64) map[string]interface{} {
+	if clock == nil {
+		clock = &models.FootballMatchClock{State: footballhelper.ClockNotStarted}
+	}
+	return map[string]interface{}{
+		"match_public_id": matchPublicID,
+		"state":           clock.State,
+		"is_running":      clock.IsRunning,
+		"elapsed_seconds": footballhelper.ElapsedSeconds(*clock, now),
+		"added_minutes":   clock.AddedMinutes,
+		"minute":          footballhelper.ClockMinuteAt(*clock, now),
+		"server_time":     now,
+	}
+}
+
+type updateFootballClockRequest struct {
+	MatchPublicID string `json:"match_public_id" binding:"required"`
+	Action        string `json:"action" binding:"required,oneof=change_state pause resume added_time"`
+	State         string `json:"state"`
+	AddedMinutes  int    `json:"added_minutes"`
+}
+
+// UpdateFootballClockFunc changes the state of the match clock, pauses or resumes it and
+// announces the added time
+func (s *FootballServer) UpdateFootballClockFunc(ctx *gin.Context) {
+	var req updateFootballClockRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		s.logger.Error("Failed to bind request: ", err)
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format: ", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	clock, periodIncident, err := s.txStore.UpdateFootballClockTx(ctx, matchPublicID, req.Action, req.State, req.AddedMinutes)
+	if err != nil {
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
+		s.logger.Error("Failed to update football clock: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to update football clock",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	payload := footballClockPayload(matchPublicID, clock, time.Now().Unix())
+
+	if s.scoreBroadcaster != nil {
+		err := s.scoreBroadcaster.BroadcastMatchEvent(ctx, "UPDATE_FOOTBALL_CLOCK", payload)
+		if err != nil {
+			s.logger.Warn("Failed to broadcast football clock: ", err)
+		}
+		if periodIncident != nil {
+			err := s.scoreBroadcaster.BroadcastFootballEvent(ctx, "ADD_FOOTBALL_INCIDENT", map[string]interface{}{
+				"id":                      periodIncident.ID,
+				"public_id":               periodIncident.PublicID,
+				"match_id":                periodIncident.MatchID,
+				"team_id":                 periodIncident.TeamID,
+				"periods":                 periodIncident.Periods,
+				"incident_type":           periodIncident.IncidentType,
+				"incident_time":           periodIncident.IncidentTime,
+				"added_time":              periodIncident.AddedTime,
+				"description":             periodIncident.Description,
+				"penalty_shootout_scored": periodIncident.PenaltyShootoutScored,
+				"tournament_id":           periodIncident.TournamentID,
+				"created_at":              periodIncident.CreatedAt,
+			})
+			if err != nil {
+				s.logger.Warn("Failed to broadcast football period incident: ", err)
+			}
+		}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"type":    "UPDATE_FOOTBALL_CLOCK",
+			"payload": payload,
+		},
+	})
+}
+
+// footballMatchClock is the shared match clock run with the football clock states
+func (s *FootballServer) footballMatchClock() *sports.MatchClock {
+	return &sports.MatchClock{
+		Sport:       "football",
+		TickEvent:   "FOOTBALL_CLOCK_TICK",
+		Logger:      s.logger,
+		Broadcaster: s.GetScoreBroadcaster,
+		Running: func(ctx context.Context, now int64) ([]sports.RunningClock, error) {
+			clocks, err := s.store.GetRunningFootballMatchClocks(ctx)
+			if err != nil {
+				return nil, err
+			}
+			running := make([]sports.RunningClock, 0, len(clocks))
+			for _, clock := range clocks {
+				running = append(running, sports.RunningClock{
+					MatchID:       clock.Clock.MatchID,
+					MatchPublicID: clock.MatchPublicID,
+					State:         clock.Clock.State,
+					Minute:        footballhelper.ClockMinuteAt(clock.Clock, now).Display,
+					Payload:       footballClockPayload(clock.MatchPublicID, &clock.Clock, now),
+				})
+			}
+			return running, nil
+		},
+		Clock: func(ctx context.Context, matchPublicID uuid.UUID, now int64) (map[string]interface{}, error) {
+			match, err := s.store.GetMatchModelByPublicId(ctx, matchPublicID)
+			if err != nil {
+				return nil, err
+			}
+			clock, err := s.store.GetFootballMatchClock(ctx, int32(match.ID))
+			if err != nil {
+				return nil, err
+			}
+			return footballClockPayload(matchPublicID, clock, now), nil
+		},
+	}
+}
+
+// GetFootballClockFunc returns the current state and minute of the match clock
+func (s *FootballServer) GetFootballClockFunc(ctx *gin.Context) {
+	s.footballMatchClock().GetClockFunc(ctx)
+}
+
+// StartFootballClockTicker broadcasts a tick to the match subscribers whenever the minute of a
+// running clock changes, until ctx is done
+func (s *FootballServer) StartFootballClockTicker(ctx context.Context) {
+	s.footballMatchClock().StartTicker(ctx)
+}
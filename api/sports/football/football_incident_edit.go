@@ -0,0 +1,237 @@
+package football
+
+import (
+	"net/http"
+
+	"khelogames/api/transactions"
+	"khelogames/database/models"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+type updateFootballIncidentRequest struct {
+	MatchPublicID         string  `json:"match_public_id" binding:"required"`
+	IncidentPublicID      string  `json:"incident_public_id" binding:"required"`
+	TeamPublicID          *string `json:"team_public_id"`
+	PlayerPublicID        *string `json:"player_public_id"`
+	AssistPlayerPublicID  *string `json:"assist_player_public_id"`
+	PlayerInPublicID      *string `json:"player_in_public_id"`
+	PlayerOutPublicID     *string `json:"player_out_public_id"`
+	Periods               string  `json:"periods"`
+	IncidentType          string  `json:"incident_type" binding:"required"`
+	IncidentTime          int     `json:"incident_time"`
+	AddedTime             int     `json:"added_time"`
+	Description           string  `json:"description"`
+	PenaltyShootoutScored bool    `json:"penalty_shootout_scored"`
+}
+
+// parseOptionalUUID returns nil for a missing or empty id
+func parseOptionalUUID(value *string) (*uuid.UUID, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	parsed, err := uuid.Parse(*value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+func footballIncidentEventData(matchPublicID uuid.UUID, incident *models.FootballIncident) map[string]interface{} {
+	return map[string]interface{}{
+		"match_public_id":         matchPublicID,
+		"id":                      incident.ID,
+		"public_id":               incident.PublicID,
+		"match_id":                incident.MatchID,
+		"team_id":                 incident.TeamID,
+		"periods":                 incident.Periods,
+		"incident_type":           incident.IncidentType,
+		"incident_time":           incident.IncidentTime,
+		"added_time":              incident.AddedTime,
+		"description":             incident.Description,
+		"penalty_shootout_scored": incident.PenaltyShootoutScored,
+		"tournament_id":           incident.TournamentID,
+		"assist_player_id":        incident.AssistPlayerID,
+		"is_cancelled":            incident.IsCancelled,
+		"created_at":              incident.CreatedAt,
+	}
+}
+
+// broadcastFootballCorrection sends the corrected incident followed by the rebuilt score of each team
+func (s *FootballServer) broadcastFootballCorrection(ctx *gin.Context, eventType string, incidentData map[string]interface{}, scoreData []map[string]interface{}) {
+	if s.scoreBroadcaster == nil {
+		return
+	}
+	if err := s.scoreBroadcaster.BroadcastFootballEvent(ctx, eventType, incidentData); err != nil {
+		s.logger.Warn("Failed to broadcast football incident correction: ", err)
+	}
+	for _, score := range scoreData {
+		if err := s.scoreBroadcaster.BroadcastFootballEvent(ctx, "UPDATE_FOOTBALL_SCORE", score); err != nil {
+			s.logger.Warn("Failed to broadcast football score event: ", err)
+		}
+	}
+}
+
+// UpdateFootballIncidentFunc corrects an incident, the score and statistics of the match are
+// rebuilt from its incidents
+func (s *FootballServer) UpdateFootballIncidentFunc(ctx *gin.Context) {
+	var req updateFootballIncidentRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		s.logger.Error("Failed to bind request: ", err)
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format: ", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	incidentPublicID, err := uuid.Parse(req.IncidentPublicID)
+	if err != nil {
+		s.logger.Error("Invalid incident UUID format: ", err)
+		fieldErrors := map[string]string{"incident_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	arg := transactions.UpdateFootballIncidentTxParams{
+		MatchPublicID:         matchPublicID,
+		IncidentPublicID:      incidentPublicID,
+		Periods:               req.Periods,
+		IncidentType:          req.IncidentType,
+		IncidentTime:          req.IncidentTime,
+		AddedTime:             req.AddedTime,
+		Description:           req.Description,
+		PenaltyShootoutScored: req.PenaltyShootoutScored,
+	}
+
+	optionalIDs := []struct {
+		field string
+		value *string
+		dest  **uuid.UUID
+	}{
+		{"team_public_id", req.TeamPublicID, &arg.TeamPublicID},
+		{"player_public_id", req.PlayerPublicID, &arg.PlayerPublicID},
+		{"assist_player_public_id", req.AssistPlayerPublicID, &arg.AssistPlayerPublicID},
+		{"player_in_public_id", req.PlayerInPublicID, &arg.PlayerInPublicID},
+		{"player_out_public_id", req.PlayerOutPublicID, &arg.PlayerOutPublicID},
+	}
+	for _, id := range optionalIDs {
+		*id.dest, err = parseOptionalUUID(id.value)
+		if err != nil {
+			s.logger.Error("Invalid UUID format: ", err)
+			fieldErrors := map[string]string{id.field: "Invalid UUID format"}
+			errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+			return
+		}
+	}
+
+	if arg.AssistPlayerPublicID != nil {
+		if req.IncidentType != "goal" {
+			fieldErrors := map[string]string{"assist_player_public_id": "Only a goal can have an assist"}
+			errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+			return
+		}
+		if arg.PlayerPublicID != nil && *arg.PlayerPublicID == *arg.AssistPlayerPublicID {
+			fieldErrors := map[string]string{"assist_player_public_id": "Scorer cannot assist their own goal"}
+			errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+			return
+		}
+	}
+
+	incident, scoreData, err := s.txStore.UpdateFootballIncidentTx(ctx, arg)
+	if err != nil {
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
+		s.logger.Error("Failed to update football incident: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to update football incident",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	incidentData := footballIncidentEventData(matchPublicID, incident)
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"incident": incidentData,
+			"scores":   scoreData,
+		},
+	})
+
+	s.broadcastFootballCorrection(ctx, "INCIDENT_UPDATED", incidentData, scoreData)
+}
+
+// DeleteFootballIncidentFunc removes an incident, the score and statistics of the match are
+// rebuilt from the incidents left
+func (s *FootballServer) DeleteFootballIncidentFunc(ctx *gin.Context) {
+	var req struct {
+		MatchPublicID    string `uri:"match_public_id" binding:"required"`
+		IncidentPublicID string `uri:"incident_public_id" binding:"required"`
+	}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format: ", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	incidentPublicID, err := uuid.Parse(req.IncidentPublicID)
+	if err != nil {
+		s.logger.Error("Invalid incident UUID format: ", err)
+		fieldErrors := map[string]string{"incident_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	incident, scoreData, err := s.txStore.DeleteFootballIncidentTx(ctx, matchPublicID, incidentPublicID)
+	if err != nil {
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
+		s.logger.Error("Failed to delete football incident: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to delete football incident",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	incidentData := footballIncidentEventData(matchPublicID, incident)
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"incident": incidentData,
+			"scores":   scoreData,
+		},
+	})
+
+	s.broadcastFootballCorrection(ctx, "INCIDENT_DELETED", incidentData, scoreData)
+}
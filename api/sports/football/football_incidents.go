@@ -16,6 +16,7 @@ type addFootballIncidentsRequest struct {
 	TeamPublicID         *string `json:"team_public_id"`
 	TournamentPublicID   string  `json:"tournament_public_id"`
 	PlayerPublicID       string  `json:"player_public_id"`
+	AssistPlayerPublicID *string `json:"assist_player_public_id"`
 	Periods              string  `json:"periods"`
 	IncidentType         string  `json:"incident_type"`
 	IncidentTime         int     `json:"incident_time"`
@@ -33,6 +34,13 @@ func (s *FootballServer) AddFootballIncidentsFunc(ctx *gin.Context) {
 		return
 	}
 
+	// shootout kicks are validated in turn order by /addFootballShootoutKick
+	if req.IncidentType == "penalty_shootout" {
+		fieldErrors := map[string]string{"incident_type": "Use /addFootballShootoutKick for penalty shootout kicks"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
 	tournamentPublicID, err := uuid.Parse(req.TournamentPublicID)
 	if err != nil {
 		s.logger.Error("Invalid tournament UUID format: ", err)
@@ -74,6 +82,29 @@ func (s *FootballServer) AddFootballIncidentsFunc(ctx *gin.Context) {
 		}
 	}
 
+	var assistPlayerPublicID *uuid.UUID
+
+	if req.AssistPlayerPublicID != nil && *req.AssistPlayerPublicID != "" {
+		if req.IncidentType != "goal" {
+			fieldErrors := map[string]string{"assist_player_public_id": "Only a goal can have an assist"}
+			errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+			return
+		}
+		parsed, err := uuid.Parse(*req.AssistPlayerPublicID)
+		if err != nil {
+			s.logger.Error("Invalid assist player UUID format: ", err)
+			fieldErrors := map[string]string{"assist_player_public_id": "Invalid UUID format"}
+			errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+			return
+		}
+		if parsed == playerPublicID {
+			fieldErrors := map[string]string{"assist_player_public_id": "Scorer cannot assist their own goal"}
+			errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+			return
+		}
+		assistPlayerPublicID = &parsed
+	}
+
 	arg := db.CreateFootballIncidentsParams{
 		TournamentPublicID:    tournamentPublicID,
 		MatchPublicID:         matchPublicID,
@@ -83,6 +114,7 @@ func (s *FootballServer) AddFootballIncidentsFunc(ctx *gin.Context) {
 		IncidentTime:          req.IncidentTime,
 		Description:           req.Description,
 		PenaltyShootoutScored: req.PenaltShootoutScored,
+		AssistPlayerPublicID:  assistPlayerPublicID,
 	}
 	s.logger.Debugf("Creating incident with params: %+v", arg)
 
@@ -219,6 +251,7 @@ func (s *FootballServer) AddFootballIncidentsSubs(ctx *gin.Context) {
 		if err != nil {
 			s.logger.Warn("Failed to broadcast football event: ", err)
 		}
+		s.broadcastFootballFormation(ctx, matchPublicID, teamPublicID)
 	}
 }
 
@@ -311,6 +344,7 @@ func (s *FootballServer) GetFootballIncidentsFunc(ctx *gin.Context) {
 				"periods":       incident.Periods,
 				"incident_type": incident.IncidentType,
 				"incident_time": incident.IncidentTime,
+				"added_time":    incident.AddedTime,
 				"description":   incident.Description,
 				"player_in": map[string]interface{}{
 					"id":         playerInData["id"],
@@ -405,11 +439,27 @@ func (s *FootballServer) GetFootballIncidentsFunc(ctx *gin.Context) {
 				"periods":       incident.Periods,
 				"incident_type": incident.IncidentType,
 				"incident_time": incident.IncidentTime,
+				"added_time":    incident.AddedTime,
 				"description":   incident.Description,
 			}
 			incidents = append(incidents, incidentDataMap)
 
-		} else if incident.IncidentType == "goal" || incident.IncidentType == "penalty" {
+		} else if incident.IncidentType == "var_review" {
+			incidentDataMap := map[string]interface{}{
+				"id":                    incident.ID,
+				"public_id":             incident.PublicID,
+				"match_id":              incident.MatchID,
+				"team_id":               incident.TeamID,
+				"periods":               incident.Periods,
+				"incident_type":         incident.IncidentType,
+				"incident_time":         incident.IncidentTime,
+				"added_time":            incident.AddedTime,
+				"description":           incident.Description,
+				"cancelled_incident_id": incident.CancelledIncidentID,
+			}
+			incidents = append(incidents, incidentDataMap)
+
+		} else if incident.IncidentType == "goal" || incident.IncidentType == "penalty" || incident.IncidentType == "own_goal" {
 			var data map[string]interface{}
 			tt, ok := (incident.Players).([]byte)
 			if !ok || tt == nil {
@@ -422,11 +472,17 @@ func (s *FootballServer) GetFootballIncidentsFunc(ctx *gin.Context) {
 				continue
 			}
 
-			// Update score counters BEFORE creating the incident map
-			if incident.TeamID != nil {
-				if homeTeamID == *incident.TeamID {
+			// Update score counters BEFORE creating the incident map, an own goal counts for the
+			// other team and a goal overturned by VAR does not count
+			if incident.TeamID != nil && !incident.IsCancelled {
+				homeScored := homeTeamID == *incident.TeamID
+				awayScored := awayTeamID == *incident.TeamID
+				if incident.IncidentType == "own_goal" {
+					homeScored, awayScored = awayScored, homeScored
+				}
+				if homeScored {
 					homeGoals++
-				} else if awayTeamID == *incident.TeamID {
+				} else if awayScored {
 					awayGoals++
 				}
 			}
@@ -444,7 +500,9 @@ func (s *FootballServer) GetFootballIncidentsFunc(ctx *gin.Context) {
 				"periods":       incident.Periods,
 				"incident_type": incident.IncidentType,
 				"incident_time": incident.IncidentTime,
+				"added_time":    incident.AddedTime,
 				"description":   incident.Description,
+				"is_cancelled":  incident.IsCancelled,
 				"player": map[string]interface{}{
 					"id":         playerData["id"],
 					"public_id":  playerData["public_id"],
@@ -463,6 +521,19 @@ func (s *FootballServer) GetFootballIncidentsFunc(ctx *gin.Context) {
 					"goals": awayGoals,
 				},
 			}
+			if assistData, ok := data["assist_player"].(map[string]interface{}); ok {
+				incidentDataMap["assist_player"] = map[string]interface{}{
+					"id":         assistData["id"],
+					"public_id":  assistData["public_id"],
+					"user_id":    assistData["user_id"],
+					"name":       assistData["name"],
+					"slug":       assistData["slug"],
+					"short_name": assistData["short_name"],
+					"positions":  assistData["positions"],
+					"country":    assistData["country"],
+					"media_url":  assistData["media_url"],
+				}
+			}
 
 			incidents = append(incidents, incidentDataMap)
 
@@ -493,7 +564,9 @@ func (s *FootballServer) GetFootballIncidentsFunc(ctx *gin.Context) {
 				"periods":       incident.Periods,
 				"incident_type": incident.IncidentType,
 				"incident_time": incident.IncidentTime,
+				"added_time":    incident.AddedTime,
 				"description":   incident.Description,
+				"is_cancelled":  incident.IsCancelled,
 				"player": map[string]interface{}{
 					"id":         playerData["id"],
 					"public_id":  playerData["public_id"],
@@ -1,6 +1,7 @@
 package football
 
 import (
+	"fmt"
 	"khelogames/database/models"
 	errorhandler "khelogames/error_handler"
 	"net/http"
@@ -75,6 +76,22 @@ type MatchSquadRequest struct {
 	IsSubstituted []string `json:"is_substituted"`
 }
 
+// suspendedPlayerMessage returns a message naming the first picked player who is suspended
+func suspendedPlayerMessage(suspended []map[string]interface{}, picked []string) string {
+	names := make(map[string]interface{}, len(suspended))
+	for _, suspension := range suspended {
+		if player, ok := suspension["player"].(map[string]interface{}); ok {
+			names[fmt.Sprint(player["public_id"])] = player["name"]
+		}
+	}
+	for _, publicID := range picked {
+		if name, ok := names[publicID]; ok {
+			return fmt.Sprintf("%v is suspended for this match", name)
+		}
+	}
+	return ""
+}
+
 func (s *FootballServer) AddFootballSquadFunc(ctx *gin.Context) {
 	s.logger.Info("Received request to add football squad")
 	var req MatchSquadRequest
@@ -102,6 +119,25 @@ func (s *FootballServer) AddFootballSquadFunc(ctx *gin.Context) {
 		return
 	}
 
+	suspended, err := s.store.GetFootballSuspendedPlayers(ctx, matchPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get suspended players: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get suspended players",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+	if msg := suspendedPlayerMessage(suspended, req.Player); msg != "" {
+		fieldErrors := map[string]string{"player": msg}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
 	substitutedMap := make(map[string]bool)
 
 	for _, substitutedID := range req.IsSubstituted {
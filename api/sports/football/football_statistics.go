@@ -1,83 +1,81 @@
 package football
 
 import (
-	db "khelogames/database"
 	"net/http"
 
+	footballhelper "khelogames/api/sports/football_helper"
 	errorhandler "khelogames/error_handler"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/google/uuid"
 )
 
-type addFootballStatisticsRequest struct {
-	MatchID         int32 `json:"match_id"`
-	TeamID          int32 `json:"team_id"`
-	ShotsOnTarget   int32 `json:"shots_on_target"`
-	TotalShots      int32 `json:"total_shots"`
-	CornerKicks     int32 `json:"corner_kicks"`
-	Fouls           int32 `json:"fouls"`
-	GoalkeeperSaves int32 `json:"goalkeeper_saves"`
-	FreeKicks       int32 `json:"free_kicks"`
-	YellowCards     int32 `json:"yellow_cards"`
-	RedCards        int32 `json:"red_cards"`
+type updateFootballStatisticsRequest struct {
+	MatchPublicID  string `json:"match_public_id" binding:"required"`
+	Periods        string `json:"periods" binding:"required"`
+	HomePossession int    `json:"home_possession"`
 }
 
-func (s *FootballServer) AddFootballStatisticsFunc(ctx *gin.Context) {
-	s.logger.Info("Received request to add football statistics")
-	var req addFootballStatisticsRequest
-	err := ctx.ShouldBindJSON(&req)
-	if err != nil {
+// UpdateFootballStatisticsFunc records the possession of a period, the other statistics are
+// counted from the incidents of the match
+func (s *FootballServer) UpdateFootballStatisticsFunc(ctx *gin.Context) {
+	s.logger.Info("Received request to update football statistics")
+	var req updateFootballStatisticsRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
 		s.logger.Error("Failed to bind request: ", err)
 		fieldErrors := errorhandler.ExtractValidationErrors(err)
 		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
 		return
 	}
 
-	arg := db.CreateFootballStatisticsParams{
-		MatchID:         int32(req.MatchID),
-		TeamID:          int32(req.TeamID),
-		ShotsOnTarget:   req.ShotsOnTarget,
-		TotalShots:      req.TotalShots,
-		CornerKicks:     req.CornerKicks,
-		Fouls:           req.Fouls,
-		GoalkeeperSaves: req.GoalkeeperSaves,
-		FreeKicks:       req.FreeKicks,
-		YellowCards:     req.YellowCards,
-		RedCards:        req.RedCards,
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format: ", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
 	}
 
-	response, err := s.store.CreateFootballStatistics(ctx, arg)
+	statistics, err := s.txStore.UpdateFootballPossessionTx(ctx, matchPublicID, req.Periods, req.HomePossession)
 	if err != nil {
-		s.logger.Error("Failed to add the football statistics: ", err)
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
+		s.logger.Error("Failed to update football statistics: ", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
 			"error": gin.H{
 				"code":    "INTERNAL_ERROR",
-				"message": "Failed to add football statistics",
+				"message": "Failed to update football statistics",
 			},
 			"request_id": ctx.GetString("request_id"),
 		})
 		return
 	}
+	statistics["match_public_id"] = matchPublicID
+
+	if s.scoreBroadcaster != nil {
+		err := s.scoreBroadcaster.BroadcastMatchEvent(ctx, "UPDATE_FOOTBALL_STATISTICS", statistics)
+		if err != nil {
+			s.logger.Warn("Failed to broadcast football statistics: ", err)
+		}
+	}
 
-	s.logger.Info("Successfully added football statistics")
+	s.logger.Info("Successfully updated football statistics")
 	ctx.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    response,
+		"data":    statistics,
 	})
 }
 
-type getFootballStatisticsRequest struct {
-	MatchPublicID string `json:"match_public_id"`
-	TeamPublicID  string `json:"team_public_id"`
-}
-
+// GetFootballStatisticsFunc returns the statistics of both teams per period and over the match
 func (s *FootballServer) GetFootballStatisticsFunc(ctx *gin.Context) {
 	s.logger.Info("Received request to get football statistics")
-	var req getFootballStatisticsRequest
-	err := ctx.ShouldBindJSON(&req)
-	if err != nil {
+	var req struct {
+		MatchPublicID string `uri:"match_public_id" binding:"required"`
+	}
+	if err := ctx.ShouldBindUri(&req); err != nil {
 		s.logger.Error("Failed to bind request: ", err)
 		fieldErrors := errorhandler.ExtractValidationErrors(err)
 		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
@@ -92,17 +90,23 @@ func (s *FootballServer) GetFootballStatisticsFunc(ctx *gin.Context) {
 		return
 	}
 
-	teamPublicID, err := uuid.Parse(req.TeamPublicID)
+	match, err := s.store.GetMatchModelByPublicId(ctx, matchPublicID)
 	if err != nil {
-		s.logger.Error("Invalid team UUID format: ", err)
-		fieldErrors := map[string]string{"team_public_id": "Invalid UUID format"}
-		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		s.logger.Error("Failed to get match: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get match",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
 		return
 	}
 
-	response, err := s.store.GetFootballStatistics(ctx, matchPublicID, teamPublicID)
+	counts, err := s.store.GetFootballIncidentCounts(ctx, int32(match.ID))
 	if err != nil {
-		s.logger.Error("Failed to get the football statistics: ", err)
+		s.logger.Error("Failed to get football incident counts: ", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
 			"error": gin.H{
@@ -114,9 +118,26 @@ func (s *FootballServer) GetFootballStatisticsFunc(ctx *gin.Context) {
 		return
 	}
 
+	possession, err := s.store.GetFootballPossession(ctx, int32(match.ID))
+	if err != nil {
+		s.logger.Error("Failed to get football possession: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get football statistics",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	statistics := footballhelper.MatchStatistics(match, counts, possession)
+	statistics["match_public_id"] = matchPublicID
+
 	s.logger.Info("Successfully retrieved football statistics")
 	ctx.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    response,
+		"data":    statistics,
 	})
 }
@@ -0,0 +1,204 @@
+package football
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	footballhelper "khelogames/api/sports/football_helper"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+type addFootballLineupRequest struct {
+	MatchPublicID string                        `json:"match_public_id" binding:"required"`
+	TeamPublicID  string                        `json:"team_public_id" binding:"required"`
+	Formation     string                        `json:"formation" binding:"required"`
+	Starters      []footballhelper.LineupPlayer `json:"starters" binding:"required"`
+	Substitutes   []string                      `json:"substitutes"`
+}
+
+// footballFormationPayload lays the squad of the team out on the pitch: players holding a slot of
+// the formation get its coordinates, everyone else is on the bench
+func (s *FootballServer) footballFormationPayload(ctx context.Context, matchPublicID, teamPublicID uuid.UUID) (map[string]interface{}, error) {
+	formation, err := s.store.GetFootballFormation(ctx, matchPublicID, teamPublicID)
+	if err != nil {
+		return nil, err
+	}
+
+	squad, err := s.store.GetFootballMatchSquad(ctx, matchPublicID, teamPublicID)
+	if err != nil {
+		return nil, err
+	}
+
+	slots := make(map[string]footballhelper.FormationSlot)
+	var formationName interface{}
+	if formation != nil {
+		formationName = formation.Formation
+		formationSlots, err := footballhelper.FormationSlots(formation.Formation)
+		if err != nil {
+			return nil, err
+		}
+		for _, slot := range formationSlots {
+			slots[slot.Slot] = slot
+		}
+	}
+
+	pitch := []map[string]interface{}{}
+	bench := []map[string]interface{}{}
+	if squad != nil {
+		for _, member := range *squad {
+			slot, ok := slots[fmt.Sprint(member["formation_slot"])]
+			if !ok {
+				bench = append(bench, member)
+				continue
+			}
+			member["slot"] = slot
+			pitch = append(pitch, member)
+		}
+	}
+
+	return map[string]interface{}{
+		"match_public_id": matchPublicID,
+		"team_public_id":  teamPublicID,
+		"formation":       formationName,
+		"pitch":           pitch,
+		"bench":           bench,
+	}, nil
+}
+
+// broadcastFootballFormation sends the live formation of the team to the match subscribers
+func (s *FootballServer) broadcastFootballFormation(ctx context.Context, matchPublicID, teamPublicID uuid.UUID) {
+	if s.scoreBroadcaster == nil {
+		return
+	}
+	payload, err := s.footballFormationPayload(ctx, matchPublicID, teamPublicID)
+	if err != nil {
+		s.logger.Warn("Failed to build football formation: ", err)
+		return
+	}
+	if err := s.scoreBroadcaster.BroadcastMatchEvent(ctx, "UPDATE_FOOTBALL_FORMATION", payload); err != nil {
+		s.logger.Warn("Failed to broadcast football formation: ", err)
+	}
+}
+
+// AddFootballLineupFunc publishes the formation of the team with every starter put in a slot
+func (s *FootballServer) AddFootballLineupFunc(ctx *gin.Context) {
+	var req addFootballLineupRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		s.logger.Error("Failed to bind request: ", err)
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format: ", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	teamPublicID, err := uuid.Parse(req.TeamPublicID)
+	if err != nil {
+		s.logger.Error("Invalid team UUID format: ", err)
+		fieldErrors := map[string]string{"team_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	_, _, err = s.txStore.AddFootballLineupTx(ctx, matchPublicID, teamPublicID, req.Formation, req.Starters, req.Substitutes)
+	if err != nil {
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
+		s.logger.Error("Failed to add football lineup: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to add football lineup",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	payload, err := s.footballFormationPayload(ctx, matchPublicID, teamPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get football formation: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get football formation",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    payload,
+	})
+
+	if s.scoreBroadcaster != nil {
+		if err := s.scoreBroadcaster.BroadcastMatchEvent(ctx, "UPDATE_FOOTBALL_FORMATION", payload); err != nil {
+			s.logger.Warn("Failed to broadcast football formation: ", err)
+		}
+	}
+}
+
+// GetFootballFormationFunc returns the live formation of the team with the pitch coordinates of
+// every slot
+func (s *FootballServer) GetFootballFormationFunc(ctx *gin.Context) {
+	var req struct {
+		MatchPublicID string `uri:"match_public_id" binding:"required"`
+		TeamPublicID  string `uri:"team_public_id" binding:"required"`
+	}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format: ", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	teamPublicID, err := uuid.Parse(req.TeamPublicID)
+	if err != nil {
+		s.logger.Error("Invalid team UUID format: ", err)
+		fieldErrors := map[string]string{"team_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	payload, err := s.footballFormationPayload(ctx, matchPublicID, teamPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get football formation: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get football formation",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    payload,
+	})
+}
@@ -0,0 +1,63 @@
+package football
+
+import (
+	"net/http"
+
+	errorhandler "khelogames/error_handler"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetFootballMatchRatingsFunc returns the player ratings of a finished match with the man of the
+// match, who is nil until the match is finished
+func (s *FootballServer) GetFootballMatchRatingsFunc(ctx *gin.Context) {
+	var req struct {
+		MatchPublicID string `uri:"match_public_id" binding:"required"`
+	}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		s.logger.Error("Failed to bind request: ", err)
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format: ", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	ratings, err := s.store.GetFootballMatchRatings(ctx, matchPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get football match ratings: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get football match ratings",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	var manOfTheMatch map[string]interface{}
+	for _, rating := range ratings {
+		if rating["is_man_of_the_match"] == true {
+			manOfTheMatch = rating
+			break
+		}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"match_public_id":  matchPublicID,
+			"man_of_the_match": manOfTheMatch,
+			"ratings":          ratings,
+		},
+	})
+}
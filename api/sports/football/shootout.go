@@ -0,0 +1,186 @@
+package football
+
+import (
+	"net/http"
+
+	footballhelper "khelogames/api/sports/football_helper"
+	"khelogames/api/transactions"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+type addFootballShootoutKickRequest struct {
+	MatchPublicID  string  `json:"match_public_id" binding:"required"`
+	TeamPublicID   string  `json:"team_public_id" binding:"required"`
+	TakerPublicID  string  `json:"taker_public_id" binding:"required"`
+	KeeperPublicID *string `json:"keeper_public_id"`
+	Outcome        string  `json:"outcome" binding:"required,oneof=scored missed saved"`
+	IncidentTime   int     `json:"incident_time"`
+	Description    string  `json:"description"`
+}
+
+// AddFootballShootoutKickFunc records the next kick of the penalty shootout, the teams take turns
+// and the shootout is decided as soon as one team cannot catch up
+func (s *FootballServer) AddFootballShootoutKickFunc(ctx *gin.Context) {
+	var req addFootballShootoutKickRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		s.logger.Error("Failed to bind request: ", err)
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format: ", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	teamPublicID, err := uuid.Parse(req.TeamPublicID)
+	if err != nil {
+		s.logger.Error("Invalid team UUID format: ", err)
+		fieldErrors := map[string]string{"team_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	takerPublicID, err := uuid.Parse(req.TakerPublicID)
+	if err != nil {
+		s.logger.Error("Invalid taker UUID format: ", err)
+		fieldErrors := map[string]string{"taker_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	keeperPublicID, err := parseOptionalUUID(req.KeeperPublicID)
+	if err != nil {
+		s.logger.Error("Invalid keeper UUID format: ", err)
+		fieldErrors := map[string]string{"keeper_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	kick, incident, state, scoreData, err := s.txStore.AddFootballShootoutKickTx(ctx, transactions.AddFootballShootoutKickTxParams{
+		MatchPublicID:  matchPublicID,
+		TeamPublicID:   teamPublicID,
+		TakerPublicID:  takerPublicID,
+		KeeperPublicID: keeperPublicID,
+		Outcome:        req.Outcome,
+		IncidentTime:   req.IncidentTime,
+		Description:    req.Description,
+	})
+	if err != nil {
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
+		s.logger.Error("Failed to add shootout kick: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to add shootout kick",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	incidentData := footballIncidentEventData(matchPublicID, incident)
+	s.broadcastFootballCorrection(ctx, "ADD_FOOTBALL_INCIDENT", incidentData, scoreData)
+	if s.scoreBroadcaster != nil {
+		err := s.scoreBroadcaster.BroadcastMatchEvent(ctx, "UPDATE_FOOTBALL_SHOOTOUT", map[string]interface{}{
+			"match_public_id": matchPublicID,
+			"shootout":        state,
+		})
+		if err != nil {
+			s.logger.Warn("Failed to broadcast football shootout: ", err)
+		}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"kick":     kick,
+			"incident": incidentData,
+			"shootout": state,
+			"scores":   scoreData,
+		},
+	})
+}
+
+// GetFootballShootoutFunc returns the kicks of the penalty shootout in order with its state
+func (s *FootballServer) GetFootballShootoutFunc(ctx *gin.Context) {
+	var req struct {
+		MatchPublicID string `uri:"match_public_id" binding:"required"`
+	}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		s.logger.Error("Failed to bind request: ", err)
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format: ", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	match, err := s.store.GetMatchModelByPublicId(ctx, matchPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get match: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get match",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	kicks, err := s.store.GetFootballShootoutKicks(ctx, int32(match.ID))
+	if err != nil {
+		s.logger.Error("Failed to get shootout kicks: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get penalty shootout",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	kickDetails, err := s.store.GetFootballShootoutKickDetails(ctx, matchPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get shootout kick details: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get penalty shootout",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"match_public_id": matchPublicID,
+			"shootout":        footballhelper.MatchShootout(match, kicks),
+			"kicks":           kickDetails,
+		},
+	})
+}
@@ -0,0 +1,45 @@
+package football
+
+import (
+	"context"
+	"khelogames/api/sports"
+	db "khelogames/database"
+	"khelogames/database/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var _ sports.Sport = (*FootballServer)(nil)
+
+func (s *FootballServer) Name() string {
+	return "football"
+}
+
+func (s *FootballServer) ScoreSummary(matches []db.GetMatchByIDRow, tournamentPublicID uuid.UUID) []map[string]interface{} {
+	return s.GetFootballScore(matches, tournamentPublicID)
+}
+
+func (s *FootballServer) StartMatch(ctx context.Context, q *db.Queries, match *models.Match) error {
+	return s.txStore.StartFootballMatch(ctx, q, match)
+}
+
+func (s *FootballServer) MatchResult(ctx context.Context, q *db.Queries, match *models.Match) (*int32, error) {
+	return s.txStore.FootballMatchResult(ctx, q, match)
+}
+
+func (s *FootballServer) UpdateStandings(ctx context.Context, q *db.Queries, match *models.Match) error {
+	return s.txStore.UpdateFootballStandings(ctx, q, match)
+}
+
+func (s *FootballServer) FinishMatch(ctx context.Context, q *db.Queries, match *models.Match) error {
+	return s.txStore.FinishFootballMatch(ctx, q, match)
+}
+
+func (s *FootballServer) CreateStanding(ctx context.Context, tournamentPublicID uuid.UUID, groupID int32, teamPublicID uuid.UUID) (interface{}, error) {
+	return s.store.CreateFootballStanding(ctx, tournamentPublicID, groupID, teamPublicID)
+}
+
+func (s *FootballServer) GetTopPerformerFunc(ctx *gin.Context) {
+	s.GetFootballTopPerformerFunc(ctx)
+}
@@ -0,0 +1,100 @@
+package football
+
+import (
+	"net/http"
+
+	"khelogames/database/models"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+type addFootballVarReviewRequest struct {
+	MatchPublicID    string `json:"match_public_id" binding:"required"`
+	IncidentPublicID string `json:"incident_public_id" binding:"required"`
+	IncidentTime     int    `json:"incident_time"`
+	Description      string `json:"description"`
+}
+
+func footballVarIncidentData(incident *models.FootballIncident) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                    incident.ID,
+		"public_id":             incident.PublicID,
+		"match_id":              incident.MatchID,
+		"team_id":               incident.TeamID,
+		"periods":               incident.Periods,
+		"incident_type":         incident.IncidentType,
+		"incident_time":         incident.IncidentTime,
+		"added_time":            incident.AddedTime,
+		"description":           incident.Description,
+		"tournament_id":         incident.TournamentID,
+		"is_cancelled":          incident.IsCancelled,
+		"cancelled_incident_id": incident.CancelledIncidentID,
+		"created_at":            incident.CreatedAt,
+	}
+}
+
+// AddFootballVarReviewFunc records a VAR review overturning an earlier goal or card of the match
+func (s *FootballServer) AddFootballVarReviewFunc(ctx *gin.Context) {
+	var req addFootballVarReviewRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		s.logger.Error("Failed to bind request: ", err)
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format: ", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	incidentPublicID, err := uuid.Parse(req.IncidentPublicID)
+	if err != nil {
+		s.logger.Error("Invalid incident UUID format: ", err)
+		fieldErrors := map[string]string{"incident_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	review, cancelled, scoreData, err := s.txStore.AddFootballVarReviewTx(ctx, matchPublicID, incidentPublicID, req.IncidentTime, req.Description)
+	if err != nil {
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
+		s.logger.Error("Failed to add var review: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to add var review",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	incidentData := footballVarIncidentData(review)
+	incidentData["cancelled_incident"] = footballVarIncidentData(cancelled)
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    incidentData,
+	})
+
+	if s.scoreBroadcaster != nil {
+		if err := s.scoreBroadcaster.BroadcastFootballEvent(ctx, "ADD_FOOTBALL_INCIDENT", incidentData); err != nil {
+			s.logger.Warn("Failed to broadcast football var review: ", err)
+		}
+		if scoreData != nil {
+			if err := s.scoreBroadcaster.BroadcastFootballEvent(ctx, "UPDATE_FOOTBALL_SCORE", scoreData); err != nil {
+				s.logger.Warn("Failed to broadcast football score event: ", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package footballutils
+
+import (
+	"fmt"
+
+	"khelogames/database/models"
+)
+
+// States of the football match clock
+const (
+	ClockNotStarted          = "not_started"
+	ClockFirstHalf           = "first_half"
+	ClockHalfTime            = "half_time"
+	ClockSecondHalf          = "second_half"
+	ClockExtraTimeBreak      = "extra_time_break"
+	ClockExtraTimeFirstHalf  = "extra_time_first_half"
+	ClockExtraTimeHalfTime   = "extra_time_half_time"
+	ClockExtraTimeSecondHalf = "extra_time_second_half"
+	ClockPenaltyShootout     = "penalty_shootout"
+	ClockFullTime            = "full_time"
+)
+
+// ClockPeriod is a timed period of play, minutes are counted from StartMinute
+type ClockPeriod struct {
+	StartMinute int
+	Length      int
+}
+
+var clockPeriods = map[string]ClockPeriod{
+	ClockFirstHalf:           {StartMinute: 0, Length: 45},
+	ClockSecondHalf:          {StartMinute: 45, Length: 45},
+	ClockExtraTimeFirstHalf:  {StartMinute: 90, Length: 15},
+	ClockExtraTimeSecondHalf: {StartMinute: 105, Length: 15},
+}
+
+var clockTransitions = map[string][]string{
+	ClockNotStarted:          {ClockFirstHalf},
+	ClockFirstHalf:           {ClockHalfTime},
+	ClockHalfTime:            {ClockSecondHalf},
+	ClockSecondHalf:          {ClockFullTime, ClockExtraTimeBreak, ClockPenaltyShootout},
+	ClockExtraTimeBreak:      {ClockExtraTimeFirstHalf},
+	ClockExtraTimeFirstHalf:  {ClockExtraTimeHalfTime},
+	ClockExtraTimeHalfTime:   {ClockExtraTimeSecondHalf},
+	ClockExtraTimeSecondHalf: {ClockFullTime, ClockPenaltyShootout},
+	ClockPenaltyShootout:     {ClockFullTime},
+}
+
+// periodIncidents are the period markers recorded in the incidents when play stops
+var periodIncidents = map[string]string{
+	ClockHalfTime:       "half_time",
+	ClockExtraTimeBreak: "extra_time",
+	ClockFullTime:       "full_time",
+}
+
+// IsPlayingPeriod reports whether the clock runs in the state
+func IsPlayingPeriod(state string) bool {
+	_, ok := clockPeriods[state]
+	return ok
+}
+
+// ValidateClockTransition returns a message describing why the clock cannot move
+// from one state to the other, empty when the move is allowed
+func ValidateClockTransition(from string, to string) string {
+	for _, next := range clockTransitions[from] {
+		if next == to {
+			return ""
+		}
+	}
+	if len(clockTransitions[from]) == 0 {
+		return fmt.Sprintf("Clock cannot change state after %s", from)
+	}
+	return fmt.Sprintf("Clock cannot move from %s to %s", from, to)
+}
+
+// PeriodIncident returns the period marker recorded when the clock enters the state
+func PeriodIncident(state string) (string, bool) {
+	periods, ok := periodIncidents[state]
+	return periods, ok
+}
+
+// ClockMinute is a match minute as shown to the user, 45+2 is Minute 45 with AddedTime 2
+type ClockMinute struct {
+	Minute    int    `json:"minute"`
+	AddedTime int    `json:"added_time"`
+	Display   string `json:"display"`
+}
+
+// ElapsedSeconds returns the seconds played in the current period at the unix time now
+func ElapsedSeconds(clock models.FootballMatchClock, now int64) int {
+	elapsed := clock.ElapsedSeconds
+	if clock.IsRunning && clock.StartedAt != nil && now > *clock.StartedAt {
+		elapsed += int(now - *clock.StartedAt)
+	}
+	return elapsed
+}
+
+// MinuteAt converts the seconds played in a period to the match minute. The first
+// minute of the match is 1, minutes past the end of the period are added time.
+func MinuteAt(state string, elapsedSeconds int) ClockMinute {
+	period, ok := clockPeriods[state]
+	if !ok {
+		return ClockMinute{}
+	}
+	minute := elapsedSeconds/60 + 1
+	addedTime := 0
+	if minute > period.Length {
+		addedTime = minute - period.Length
+		minute = period.Length
+	}
+	minute += period.StartMinute
+	display := fmt.Sprintf("%d'", minute)
+	if addedTime > 0 {
+		display = fmt.Sprintf("%d+%d'", minute, addedTime)
+	}
+	return ClockMinute{Minute: minute, AddedTime: addedTime, Display: display}
+}
+
+// ClockMinuteAt returns the match minute of the clock at the unix time now
+func ClockMinuteAt(clock models.FootballMatchClock, now int64) ClockMinute {
+	return MinuteAt(clock.State, ElapsedSeconds(clock, now))
+}
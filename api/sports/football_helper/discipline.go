@@ -0,0 +1,53 @@
+package footballutils
+
+// Reasons a player is suspended for
+const (
+	SuspensionYellowCards = "yellow_card_accumulation"
+	SuspensionRedCard     = "red_card"
+)
+
+// DisciplinaryRules is the disciplinary ruleset of a tournament. When CarryOverToKnockout is off
+// the yellow cards and the bans left over from the group stage are wiped once the knockout starts.
+type DisciplinaryRules struct {
+	YellowCardThreshold int  `json:"yellow_card_threshold"`
+	YellowCardBan       int  `json:"yellow_card_ban"`
+	RedCardBan          int  `json:"red_card_ban"`
+	CarryOverToKnockout bool `json:"carry_over_to_knockout"`
+}
+
+// DefaultDisciplinaryRules apply to tournaments that have not set their own
+var DefaultDisciplinaryRules = DisciplinaryRules{
+	YellowCardThreshold: 3,
+	YellowCardBan:       1,
+	RedCardBan:          1,
+	CarryOverToKnockout: false,
+}
+
+// ValidateDisciplinaryRules returns an empty message when the rules can be applied
+func ValidateDisciplinaryRules(rules DisciplinaryRules) string {
+	switch {
+	case rules.YellowCardThreshold < 1:
+		return "yellow card threshold must be at least 1"
+	case rules.YellowCardBan < 0 || rules.RedCardBan < 0:
+		return "ban length cannot be negative"
+	}
+	return ""
+}
+
+// MatchSuspensions returns the bans a player picks up in a match: a red card bans the player on its
+// own, otherwise the yellow cards count towards the threshold. previousYellows is the count of
+// yellow cards carried into the match.
+func MatchSuspensions(rules DisciplinaryRules, previousYellows, yellowCards, redCards int) map[string]int {
+	bans := make(map[string]int)
+	if redCards > 0 {
+		if rules.RedCardBan > 0 {
+			bans[SuspensionRedCard] = rules.RedCardBan
+		}
+		return bans
+	}
+	total := previousYellows + yellowCards
+	if rules.YellowCardBan > 0 && total/rules.YellowCardThreshold > previousYellows/rules.YellowCardThreshold {
+		bans[SuspensionYellowCards] = rules.YellowCardBan
+	}
+	return bans
+}
@@ -0,0 +1,135 @@
+package footballutils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Roles of the formation slots
+const (
+	RoleGoalkeeper = "goalkeeper"
+	RoleDefender   = "defender"
+	RoleMidfielder = "midfielder"
+	RoleForward    = "forward"
+)
+
+// GoalkeeperSlot is the slot of the goalkeeper in every formation
+const GoalkeeperSlot = "GK"
+
+// StartingPlayers is the number of players that start a football match
+const StartingPlayers = 11
+
+// FormationSlot is a place in the formation. X runs across the pitch from the left touchline and
+// Y from the own goal line towards the opponents, both from 0 to 100.
+type FormationSlot struct {
+	Slot string  `json:"slot"`
+	Role string  `json:"role"`
+	X    float64 `json:"x"`
+	Y    float64 `json:"y"`
+}
+
+// LineupPlayer is a starting player put in a formation slot
+type LineupPlayer struct {
+	PlayerPublicID string `json:"player_public_id"`
+	Slot           string `json:"slot"`
+}
+
+var slotPrefixes = map[string]string{
+	RoleDefender:   "DF",
+	RoleMidfielder: "MF",
+	RoleForward:    "FW",
+}
+
+// FormationSlots lays out the slots of a formation such as 4-4-2 or 4-2-3-1: the goalkeeper, then
+// a line of players for every number, the first line being the defence and the last the attack
+func FormationSlots(formation string) ([]FormationSlot, error) {
+	parts := strings.Split(formation, "-")
+	if len(parts) < 3 || len(parts) > 5 {
+		return nil, fmt.Errorf("formation %q must have 3 to 5 lines", formation)
+	}
+
+	lines := make([]int, len(parts))
+	outfield := 0
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 1 || n > 6 {
+			return nil, fmt.Errorf("formation %q has an invalid line %q", formation, part)
+		}
+		lines[i] = n
+		outfield += n
+	}
+	if outfield != StartingPlayers-1 {
+		return nil, fmt.Errorf("formation %q must have %d outfield players", formation, StartingPlayers-1)
+	}
+
+	slots := []FormationSlot{{Slot: GoalkeeperSlot, Role: RoleGoalkeeper, X: 50, Y: 5}}
+	counts := make(map[string]int)
+	for i, n := range lines {
+		role := RoleMidfielder
+		if i == 0 {
+			role = RoleDefender
+		} else if i == len(lines)-1 {
+			role = RoleForward
+		}
+
+		// lines are spread evenly between the edge of the own box and the opponents' box
+		y := 25 + float64(i)*60/float64(len(lines)-1)
+		for j := 0; j < n; j++ {
+			counts[role]++
+			slots = append(slots, FormationSlot{
+				Slot: fmt.Sprintf("%s%d", slotPrefixes[role], counts[role]),
+				Role: role,
+				X:    float64(j+1) * 100 / float64(n+1),
+				Y:    y,
+			})
+		}
+	}
+	return slots, nil
+}
+
+// ValidateLineup checks the starters fill every slot of the formation once and that no player is
+// picked twice or comes from outside the team; an empty message means the lineup is valid
+func ValidateLineup(formation string, starters []LineupPlayer, substitutes []string, teamPlayers map[string]bool) string {
+	slots, err := FormationSlots(formation)
+	if err != nil {
+		return err.Error()
+	}
+	if len(starters) != StartingPlayers {
+		return fmt.Sprintf("lineup must have exactly %d starters", StartingPlayers)
+	}
+
+	open := make(map[string]bool, len(slots))
+	for _, slot := range slots {
+		open[slot.Slot] = true
+	}
+
+	picked := make(map[string]bool)
+	for _, starter := range starters {
+		if !open[starter.Slot] {
+			return fmt.Sprintf("slot %q is not in formation %s or is taken twice", starter.Slot, formation)
+		}
+		open[starter.Slot] = false
+		if picked[starter.PlayerPublicID] {
+			return fmt.Sprintf("player %s is picked twice", starter.PlayerPublicID)
+		}
+		picked[starter.PlayerPublicID] = true
+	}
+	if open[GoalkeeperSlot] {
+		return "lineup must have one goalkeeper"
+	}
+
+	for _, playerPublicID := range substitutes {
+		if picked[playerPublicID] {
+			return fmt.Sprintf("player %s is picked twice", playerPublicID)
+		}
+		picked[playerPublicID] = true
+	}
+
+	for playerPublicID := range picked {
+		if !teamPlayers[playerPublicID] {
+			return fmt.Sprintf("player %s is not in the team", playerPublicID)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,98 @@
+package footballutils
+
+import "khelogames/database/models"
+
+// statisticKeys are the team statistics returned for every period, a period without incidents
+// reports zero for each of them
+var statisticKeys = []string{
+	"shots_on_target",
+	"shots_off_target",
+	"total_shots",
+	"corner_kicks",
+	"fouls",
+	"offsides",
+	"goal_keeper_saves",
+	"free_kicks",
+	"yellow_cards",
+	"red_cards",
+}
+
+// MatchPossession returns the possession over the match, the possession of each period weighted
+// by its length. It is nil when no period has a possession.
+func MatchPossession(possession map[string]int) *float64 {
+	var weighted, minutes int
+	for periods, value := range possession {
+		period, ok := clockPeriods[periods]
+		if !ok {
+			continue
+		}
+		weighted += value * period.Length
+		minutes += period.Length
+	}
+	if minutes == 0 {
+		return nil
+	}
+	total := float64(weighted) / float64(minutes)
+	return &total
+}
+
+// TeamStatistics builds the statistics of one team from its incident counts and the possession
+// entered for it, per period of play and over the full match
+func TeamStatistics(teamID int32, counts []models.FootballIncidentCount, possession []models.FootballPossession) map[string]interface{} {
+	periods := make(map[string]map[string]interface{})
+	var total map[string]interface{}
+	for _, count := range counts {
+		if count.TeamID != teamID || !IsPlayingPeriod(count.Periods) {
+			continue
+		}
+		for i := 0; i < count.Count; i++ {
+			periods[count.Periods] = GetStatisticsUpdateFromIncident(periods[count.Periods], count.IncidentType)
+			total = GetStatisticsUpdateFromIncident(total, count.IncidentType)
+		}
+	}
+
+	teamPossession := make(map[string]int)
+	for _, p := range possession {
+		if p.TeamID != teamID {
+			continue
+		}
+		teamPossession[p.Periods] = p.Possession
+		if periods[p.Periods] == nil {
+			periods[p.Periods] = make(map[string]interface{})
+		}
+	}
+
+	for periodName, stats := range periods {
+		fillStatistics(stats)
+		if value, ok := teamPossession[periodName]; ok {
+			stats["possession"] = value
+		} else {
+			stats["possession"] = nil
+		}
+	}
+	if total == nil {
+		total = make(map[string]interface{})
+	}
+	fillStatistics(total)
+	total["possession"] = MatchPossession(teamPossession)
+
+	return map[string]interface{}{
+		"team_id": teamID,
+		"periods": periods,
+		"total":   total,
+	}
+}
+
+// MatchStatistics returns the statistics of the home and away team of the match
+func MatchStatistics(match *models.Match, counts []models.FootballIncidentCount, possession []models.FootballPossession) map[string]interface{} {
+	return map[string]interface{}{
+		"home": TeamStatistics(match.HomeTeamID, counts, possession),
+		"away": TeamStatistics(match.AwayTeamID, counts, possession),
+	}
+}
+
+func fillStatistics(stats map[string]interface{}) {
+	for _, key := range statisticKeys {
+		stats[key] = GetInt32(stats[key])
+	}
+}
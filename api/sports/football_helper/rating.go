@@ -0,0 +1,133 @@
+package footballutils
+
+import (
+	"math"
+	"strings"
+)
+
+// RatingWeights are what each part of a performance adds to the base rating of a player
+type RatingWeights struct {
+	Base            float64
+	FullMatch       float64
+	Goal            float64
+	Assist          float64
+	CleanSheet      float64
+	OwnGoal         float64
+	YellowCard      float64
+	RedCard         float64
+	CleanSheetAfter int
+	MinRating       float64
+	MaxRating       float64
+}
+
+// DefaultRatingWeights rate a player who played the full match without an incident at 6.5. The
+// clean sheet only counts for goalkeepers and defenders who played CleanSheetAfter minutes.
+var DefaultRatingWeights = RatingWeights{
+	Base:            6.0,
+	FullMatch:       0.5,
+	Goal:            1.0,
+	Assist:          0.6,
+	CleanSheet:      1.0,
+	OwnGoal:         -1.0,
+	YellowCard:      -0.5,
+	RedCard:         -1.5,
+	CleanSheetAfter: 60,
+	MinRating:       1.0,
+	MaxRating:       10.0,
+}
+
+// fullMatchMinutes is the length of a match without extra time
+const fullMatchMinutes = 90
+
+// PlayerPerformance is what a player did in a match
+type PlayerPerformance struct {
+	PlayerID      int32  `json:"player_id"`
+	TeamID        int32  `json:"team_id"`
+	Role          string `json:"role"`
+	MinutesPlayed int    `json:"minutes_played"`
+	Goals         int    `json:"goals"`
+	Assists       int    `json:"assists"`
+	OwnGoals      int    `json:"own_goals"`
+	YellowCards   int    `json:"yellow_cards"`
+	RedCards      int    `json:"red_cards"`
+	CleanSheet    bool   `json:"clean_sheet"`
+}
+
+// PlayerRole returns the role of a player from the formation slot held, falling back on the
+// position of the squad for players without a slot
+func PlayerRole(formationSlot, position string) string {
+	switch {
+	case formationSlot == GoalkeeperSlot:
+		return RoleGoalkeeper
+	case strings.HasPrefix(formationSlot, slotPrefixes[RoleDefender]):
+		return RoleDefender
+	case strings.HasPrefix(formationSlot, slotPrefixes[RoleMidfielder]):
+		return RoleMidfielder
+	case strings.HasPrefix(formationSlot, slotPrefixes[RoleForward]):
+		return RoleForward
+	}
+
+	position = strings.ToLower(position)
+	switch {
+	case position == "gk" || strings.Contains(position, "keeper"):
+		return RoleGoalkeeper
+	case position == "df" || strings.Contains(position, "def") || strings.Contains(position, "back"):
+		return RoleDefender
+	case position == "mf" || strings.Contains(position, "mid"):
+		return RoleMidfielder
+	case position == "fw" || strings.Contains(position, "forward") || strings.Contains(position, "striker"):
+		return RoleForward
+	}
+	return ""
+}
+
+// EarnsCleanSheet reports whether a player of a team that conceded no goal is credited with the
+// clean sheet
+func EarnsCleanSheet(weights RatingWeights, role string, minutesPlayed int) bool {
+	return (role == RoleGoalkeeper || role == RoleDefender) && minutesPlayed >= weights.CleanSheetAfter
+}
+
+// PlayerRating rates a performance between MinRating and MaxRating, rounded to one decimal
+func PlayerRating(weights RatingWeights, performance PlayerPerformance) float64 {
+	minutes := math.Min(float64(performance.MinutesPlayed), fullMatchMinutes)
+	rating := weights.Base + weights.FullMatch*minutes/fullMatchMinutes
+	rating += weights.Goal * float64(performance.Goals)
+	rating += weights.Assist * float64(performance.Assists)
+	rating += weights.OwnGoal * float64(performance.OwnGoals)
+	rating += weights.YellowCard * float64(performance.YellowCards)
+	rating += weights.RedCard * float64(performance.RedCards)
+	if performance.CleanSheet {
+		rating += weights.CleanSheet
+	}
+
+	rating = math.Max(weights.MinRating, math.Min(weights.MaxRating, rating))
+	return math.Round(rating*10) / 10
+}
+
+// ManOfTheMatch returns the index of the best rated performance, ties going to the player with
+// more goals, then more assists, then more minutes. It is -1 when nobody played.
+func ManOfTheMatch(performances []PlayerPerformance, ratings []float64) int {
+	best := -1
+	for i, performance := range performances {
+		if performance.MinutesPlayed == 0 {
+			continue
+		}
+		if best == -1 || betterPerformance(performance, ratings[i], performances[best], ratings[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+func betterPerformance(a PlayerPerformance, aRating float64, b PlayerPerformance, bRating float64) bool {
+	if aRating != bRating {
+		return aRating > bRating
+	}
+	if a.Goals != b.Goals {
+		return a.Goals > b.Goals
+	}
+	if a.Assists != b.Assists {
+		return a.Assists > b.Assists
+	}
+	return a.MinutesPlayed > b.MinutesPlayed
+}
@@ -0,0 +1,131 @@
+package footballutils
+
+import (
+	"fmt"
+
+	"khelogames/database/models"
+)
+
+// Outcomes of a penalty shootout kick
+const (
+	KickScored = "scored"
+	KickMissed = "missed"
+	KickSaved  = "saved"
+)
+
+// ShootoutRounds is the number of kicks each team takes before sudden death
+const ShootoutRounds = 5
+
+// ShootoutKick is a kick of the shootout in the order it was taken
+type ShootoutKick struct {
+	TeamID  int32  `json:"team_id"`
+	Outcome string `json:"outcome"`
+}
+
+// ShootoutState is the shootout after the kicks taken so far. NextTeamID is the team due to kick,
+// zero once the shootout is decided or before the first kick, when either team may start.
+type ShootoutState struct {
+	FirstTeamID  int32 `json:"first_team_id"`
+	HomeScore    int   `json:"home_score"`
+	AwayScore    int   `json:"away_score"`
+	HomeKicks    int   `json:"home_kicks"`
+	AwayKicks    int   `json:"away_kicks"`
+	SuddenDeath  bool  `json:"sudden_death"`
+	Finished     bool  `json:"finished"`
+	WinnerTeamID int32 `json:"winner_team_id"`
+	NextTeamID   int32 `json:"next_team_id"`
+}
+
+// Shootout replays the kicks of the shootout. The team of the first kick kicks first in every
+// round; within the first five rounds the shootout ends as soon as one team cannot catch up, in
+// sudden death after a round where one team scored and the other did not.
+func Shootout(homeTeamID, awayTeamID int32, kicks []ShootoutKick) ShootoutState {
+	var state ShootoutState
+	if len(kicks) == 0 {
+		return state
+	}
+	state.FirstTeamID = kicks[0].TeamID
+
+	for _, kick := range kicks {
+		scored := 0
+		if kick.Outcome == KickScored {
+			scored = 1
+		}
+		if kick.TeamID == homeTeamID {
+			state.HomeKicks++
+			state.HomeScore += scored
+		} else {
+			state.AwayKicks++
+			state.AwayScore += scored
+		}
+		decideShootout(&state, homeTeamID, awayTeamID)
+		if state.Finished {
+			break
+		}
+	}
+
+	if !state.Finished {
+		firstKicks, secondKicks, secondTeamID := state.HomeKicks, state.AwayKicks, awayTeamID
+		if state.FirstTeamID == awayTeamID {
+			firstKicks, secondKicks, secondTeamID = state.AwayKicks, state.HomeKicks, homeTeamID
+		}
+		state.NextTeamID = state.FirstTeamID
+		if firstKicks > secondKicks {
+			state.NextTeamID = secondTeamID
+		}
+	}
+	return state
+}
+
+// MatchShootout replays the recorded kicks of the shootout of the match
+func MatchShootout(match *models.Match, kicks []models.FootballShootoutKick) ShootoutState {
+	shootoutKicks := make([]ShootoutKick, 0, len(kicks))
+	for _, kick := range kicks {
+		shootoutKicks = append(shootoutKicks, ShootoutKick{TeamID: kick.TeamID, Outcome: kick.Outcome})
+	}
+	return Shootout(match.HomeTeamID, match.AwayTeamID, shootoutKicks)
+}
+
+func decideShootout(state *ShootoutState, homeTeamID, awayTeamID int32) {
+	if state.HomeKicks <= ShootoutRounds && state.AwayKicks <= ShootoutRounds {
+		homeLeft := ShootoutRounds - state.HomeKicks
+		awayLeft := ShootoutRounds - state.AwayKicks
+		switch {
+		case state.HomeScore > state.AwayScore+awayLeft:
+			state.Finished, state.WinnerTeamID = true, homeTeamID
+		case state.AwayScore > state.HomeScore+homeLeft:
+			state.Finished, state.WinnerTeamID = true, awayTeamID
+		}
+		if state.Finished || homeLeft > 0 || awayLeft > 0 {
+			return
+		}
+	}
+
+	state.SuddenDeath = state.HomeKicks >= ShootoutRounds && state.AwayKicks >= ShootoutRounds
+	if state.HomeKicks != state.AwayKicks {
+		return
+	}
+	switch {
+	case state.HomeScore > state.AwayScore:
+		state.Finished, state.WinnerTeamID = true, homeTeamID
+	case state.AwayScore > state.HomeScore:
+		state.Finished, state.WinnerTeamID = true, awayTeamID
+	}
+}
+
+// ValidateShootoutKick returns a message describing why the team cannot take the next kick, empty
+// when it can
+func ValidateShootoutKick(state ShootoutState, teamID int32, outcome string) string {
+	switch outcome {
+	case KickScored, KickMissed, KickSaved:
+	default:
+		return fmt.Sprintf("Unknown kick outcome %s", outcome)
+	}
+	if state.Finished {
+		return "Penalty shootout is already decided"
+	}
+	if state.NextTeamID != 0 && state.NextTeamID != teamID {
+		return "It is the other team's turn to kick"
+	}
+	return ""
+}
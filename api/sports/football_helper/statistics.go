@@ -53,6 +53,13 @@ func GetStatisticsUpdateFromIncident(currentStats map[string]interface{}, incide
 	case "total_shot":
 		currentStats["total_shots"] = GetInt32(currentStats["total_shots"]) + 1
 
+	case "shot_off_target":
+		currentStats["shots_off_target"] = GetInt32(currentStats["shots_off_target"]) + 1
+		currentStats["total_shots"] = GetInt32(currentStats["total_shots"]) + 1
+
+	case "offside":
+		currentStats["offsides"] = GetInt32(currentStats["offsides"]) + 1
+
 	case "penalty":
 		currentStats["shots_on_target"] = GetInt32(currentStats["shots_on_target"]) + 1
 		currentStats["total_shots"] = GetInt32(currentStats["total_shots"]) + 1
@@ -1,9 +1,6 @@
 package sports
 
 import (
-	"khelogames/api/sports/badminton"
-	"khelogames/api/sports/cricket"
-	"khelogames/api/sports/football"
 	"khelogames/core/token"
 	db "khelogames/database"
 	"khelogames/logger"
@@ -11,15 +8,12 @@ import (
 )
 
 type SportsServer struct {
-	store           *db.Store
-	logger          *logger.Logger
-	tokenMaker      token.Maker
-	config          util.Config
-	footballServer  *football.FootballServer
-	cricketServer   *cricket.CricketServer
-	badmintonServer *badminton.BadmintonServer
+	store      *db.Store
+	logger     *logger.Logger
+	tokenMaker token.Maker
+	config     util.Config
 }
 
-func NewSportsServer(store *db.Store, logger *logger.Logger, tokenMaker token.Maker, config util.Config, footballServer *football.FootballServer, cricketServer *cricket.CricketServer, badmintonServer *badminton.BadmintonServer) *SportsServer {
-	return &SportsServer{store: store, logger: logger, tokenMaker: tokenMaker, config: config, footballServer: footballServer, cricketServer: cricketServer, badmintonServer: badmintonServer}
+func NewSportsServer(store *db.Store, logger *logger.Logger, tokenMaker token.Maker, config util.Config) *SportsServer {
+	return &SportsServer{store: store, logger: logger, tokenMaker: tokenMaker, config: config}
 }
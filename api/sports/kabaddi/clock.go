@@ -0,0 +1,162 @@
+package kabaddi
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"khelogames/api/sports"
+	kabaddihelper "khelogames/api/sports/kabaddi_helper"
+	"khelogames/database/models"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+// kabaddiClockPayload is the clock as sent to the clients, who count the seconds
+// themselves from elapsed_seconds while is_running is set
+func kabaddiClockPayload(matchPublicID uuid.UUID, clock *models.KabaddiMatchClock, now int64) map[string]interface{} {
+	if clock == nil {
+		clock = &models.KabaddiMatchClock{State: kabaddihelper.ClockNotStarted}
+	}
+	return map[string]interface{}{
+		"match_public_id":   matchPublicID,
+		"state":             clock.State,
+		"is_running":        clock.IsRunning,
+		"elapsed_seconds":   kabaddihelper.ElapsedSeconds(*clock, now),
+		"remaining_seconds": kabaddihelper.RemainingSeconds(*clock, now),
+		"minute":            kabaddihelper.MatchMinute(*clock, now),
+		"server_time":       now,
+	}
+}
+
+type updateKabaddiClockRequest struct {
+	MatchPublicID string `json:"match_public_id" binding:"required"`
+	Action        string `json:"action" binding:"required,oneof=change_state pause resume"`
+	State         string `json:"state"`
+}
+
+// UpdateKabaddiClockFunc changes the state of the match clock, pauses or resumes it
+func (s *KabaddiServer) UpdateKabaddiClockFunc(ctx *gin.Context) {
+	var req updateKabaddiClockRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		s.logger.Error("Failed to bind request: ", err)
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format: ", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	clock, err := s.txStore.UpdateKabaddiClockTx(ctx, matchPublicID, req.Action, req.State)
+	if err != nil {
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
+		s.logger.Error("Failed to update kabaddi clock: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to update kabaddi clock",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	payload := kabaddiClockPayload(matchPublicID, clock, time.Now().Unix())
+
+	if s.scoreBroadcaster != nil {
+		err := s.scoreBroadcaster.BroadcastMatchEvent(ctx, "UPDATE_KABADDI_CLOCK", payload)
+		if err != nil {
+			s.logger.Warn("Failed to broadcast kabaddi clock: ", err)
+		}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"type":    "UPDATE_KABADDI_CLOCK",
+			"payload": payload,
+		},
+	})
+}
+
+// kabaddiMatchClock is the shared match clock run with the kabaddi clock states, ending each half
+// once it has been played for its full length
+func (s *KabaddiServer) kabaddiMatchClock() *sports.MatchClock {
+	return &sports.MatchClock{
+		Sport:       "kabaddi",
+		TickEvent:   "KABADDI_CLOCK_TICK",
+		Logger:      s.logger,
+		Broadcaster: s.GetScoreBroadcaster,
+		Running: func(ctx context.Context, now int64) ([]sports.RunningClock, error) {
+			clocks, err := s.store.GetRunningKabaddiMatchClocks(ctx)
+			if err != nil {
+				return nil, err
+			}
+			running := make([]sports.RunningClock, 0, len(clocks))
+			for _, clock := range clocks {
+				running = append(running, sports.RunningClock{
+					MatchID:       clock.Clock.MatchID,
+					MatchPublicID: clock.MatchPublicID,
+					State:         clock.Clock.State,
+					Minute:        strconv.Itoa(kabaddihelper.MatchMinute(clock.Clock, now)),
+					HalfOver:      kabaddihelper.IsHalfOver(clock.Clock, now),
+					Payload:       kabaddiClockPayload(clock.MatchPublicID, &clock.Clock, now),
+				})
+			}
+			return running, nil
+		},
+		Clock: func(ctx context.Context, matchPublicID uuid.UUID, now int64) (map[string]interface{}, error) {
+			match, err := s.store.GetMatchModelByPublicId(ctx, matchPublicID)
+			if err != nil {
+				return nil, err
+			}
+			clock, err := s.store.GetKabaddiMatchClock(ctx, int32(match.ID))
+			if err != nil {
+				return nil, err
+			}
+			return kabaddiClockPayload(matchPublicID, clock, now), nil
+		},
+		EndHalf: s.endKabaddiHalf,
+	}
+}
+
+// GetKabaddiClockFunc returns the current state and minute of the match clock
+func (s *KabaddiServer) GetKabaddiClockFunc(ctx *gin.Context) {
+	s.kabaddiMatchClock().GetClockFunc(ctx)
+}
+
+// StartKabaddiClockTicker broadcasts a tick to the match subscribers whenever the minute of a
+// running clock changes and ends the half once it has been played for its full length, until ctx
+// is done
+func (s *KabaddiServer) StartKabaddiClockTicker(ctx context.Context) {
+	s.kabaddiMatchClock().StartTicker(ctx)
+}
+
+// endKabaddiHalf moves a clock whose half has run out to half time or full time
+func (s *KabaddiServer) endKabaddiHalf(ctx context.Context, running sports.RunningClock, now int64) {
+	clock, err := s.txStore.UpdateKabaddiClockTx(ctx, running.MatchPublicID, "change_state", kabaddihelper.NextState(running.State))
+	if err != nil {
+		s.logger.Error("Failed to end kabaddi half: ", err)
+		return
+	}
+
+	if s.scoreBroadcaster != nil {
+		err := s.scoreBroadcaster.BroadcastMatchEvent(ctx, "UPDATE_KABADDI_CLOCK", kabaddiClockPayload(running.MatchPublicID, clock, now))
+		if err != nil {
+			s.logger.Warn("Failed to broadcast kabaddi clock: ", err)
+		}
+	}
+}
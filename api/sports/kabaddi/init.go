@@ -0,0 +1,37 @@
+package kabaddi
+
+import (
+	shared "khelogames/api/shared"
+	"khelogames/api/transactions"
+	db "khelogames/database"
+	"khelogames/logger"
+)
+
+type KabaddiServer struct {
+	store            *db.Store
+	logger           *logger.Logger
+	scoreBroadcaster shared.ScoreBroadcaster
+	txStore          *transactions.SQLStore
+}
+
+func NewKabaddiServer(store *db.Store, logger *logger.Logger, scoreBroadcaster shared.ScoreBroadcaster, txStore *transactions.SQLStore) *KabaddiServer {
+	server := &KabaddiServer{
+		store:            store,
+		logger:           logger,
+		scoreBroadcaster: scoreBroadcaster,
+		txStore:          txStore,
+	}
+
+	return server
+}
+
+func (s *KabaddiServer) SetScoreBroadcaster(broadcaster shared.ScoreBroadcaster) {
+	s.scoreBroadcaster = broadcaster
+}
+
+// GetScoreBroadcaster returns the assigned ScoreBroadcaster
+func (s *KabaddiServer) GetScoreBroadcaster() shared.ScoreBroadcaster {
+	return s.scoreBroadcaster
+}
+
+var _ shared.ScoreBroadcaster
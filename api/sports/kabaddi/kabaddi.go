@@ -0,0 +1,423 @@
+package kabaddi
+
+import (
+	"context"
+	"khelogames/api/transactions"
+	database "khelogames/database"
+	errorhandler "khelogames/error_handler"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+type addKabaddiRaidRequest struct {
+	MatchPublicID       string  `json:"match_public_id" binding:"required"`
+	RaidingTeamPublicID string  `json:"raiding_team_public_id" binding:"required"`
+	RaiderPublicID      string  `json:"raider_public_id" binding:"required"`
+	DefenderPublicID    *string `json:"defender_public_id"`
+	TouchPoints         int     `json:"touch_points"`
+	BonusPoint          bool    `json:"bonus_point"`
+	Tackled             bool    `json:"tackled"`
+}
+
+// AddKabaddiRaidFunc records a raid of the half being played and broadcasts the raid with the new
+// score and court
+func (s *KabaddiServer) AddKabaddiRaidFunc(ctx *gin.Context) {
+	var req addKabaddiRaidRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		s.logger.Error("Failed to bind request: ", err)
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format: ", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	raidingTeamPublicID, err := uuid.Parse(req.RaidingTeamPublicID)
+	if err != nil {
+		s.logger.Error("Invalid team UUID format: ", err)
+		fieldErrors := map[string]string{"raiding_team_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	raiderPublicID, err := uuid.Parse(req.RaiderPublicID)
+	if err != nil {
+		s.logger.Error("Invalid raider UUID format: ", err)
+		fieldErrors := map[string]string{"raider_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	var defenderPublicID *uuid.UUID
+	if req.DefenderPublicID != nil {
+		id, err := uuid.Parse(*req.DefenderPublicID)
+		if err != nil {
+			s.logger.Error("Invalid defender UUID format: ", err)
+			fieldErrors := map[string]string{"defender_public_id": "Invalid UUID format"}
+			errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+			return
+		}
+		defenderPublicID = &id
+	}
+
+	raid, score, court, err := s.txStore.AddKabaddiRaidTx(ctx, transactions.AddKabaddiRaidTxParams{
+		MatchPublicID:       matchPublicID,
+		RaidingTeamPublicID: raidingTeamPublicID,
+		RaiderPublicID:      raiderPublicID,
+		DefenderPublicID:    defenderPublicID,
+		TouchPoints:         req.TouchPoints,
+		BonusPoint:          req.BonusPoint,
+		Tackled:             req.Tackled,
+	})
+	if err != nil {
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
+		s.logger.Error("Failed to add kabaddi raid: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to add kabaddi raid",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	if s.scoreBroadcaster != nil {
+		err := s.scoreBroadcaster.BroadcastMatchEvent(ctx, "ADD_KABADDI_RAID", map[string]interface{}{
+			"match_public_id": matchPublicID,
+			"raid":            raid,
+			"score":           score,
+			"court":           court,
+		})
+		if err != nil {
+			s.logger.Warn("Broadcast failed: ", err)
+		}
+	}
+
+	ctx.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"data": gin.H{
+			"raid":  raid,
+			"score": score,
+			"court": court,
+		},
+	})
+}
+
+// GetKabaddiScoreFunc returns the score of both teams with the players on court and who raids next
+func (s *KabaddiServer) GetKabaddiScoreFunc(ctx *gin.Context) {
+	var req struct {
+		MatchPublicID string `uri:"match_public_id"`
+	}
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format: ", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	match, err := s.store.GetMatchModelByPublicId(ctx, matchPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get match: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get match",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	score, err := s.store.GetKabaddiMatchScore(ctx, matchPublicID, match.HomeTeamID, match.AwayTeamID)
+	if err != nil {
+		s.logger.Error("Failed to get kabaddi score: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to fetch kabaddi score",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	court, err := s.txStore.GetKabaddiCourtStateTx(ctx, matchPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get kabaddi court state: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to fetch kabaddi court",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": map[string]interface{}{
+			"match_public_id": matchPublicID,
+			"score":           score,
+			"court":           court,
+		},
+	})
+}
+
+// GetKabaddiRaidsFunc returns the raids of the match in the order they were played
+func (s *KabaddiServer) GetKabaddiRaidsFunc(ctx *gin.Context) {
+	var req struct {
+		MatchPublicID string `uri:"match_public_id"`
+	}
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format: ", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	match, err := s.store.GetMatchModelByPublicId(ctx, matchPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get match: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get match",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	raids, err := s.store.GetKabaddiRaids(ctx, int32(match.ID))
+	if err != nil {
+		s.logger.Error("Failed to get kabaddi raids: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to fetch kabaddi raids",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    raids,
+	})
+}
+
+func (s *KabaddiServer) GetKabaddiScore(matches []database.GetMatchByIDRow, tournamentPublicID uuid.UUID) []map[string]interface{} {
+	ctx := context.Background()
+
+	tournament, err := s.store.GetTournament(ctx, tournamentPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get tournament: ", err)
+	}
+
+	var matchDetail []map[string]interface{}
+	groupMatches := []map[string]interface{}{}
+	knockoutMatches := map[string][]map[string]interface{}{
+		"final":       {},
+		"semifinal":   {},
+		"quaterfinal": {},
+		"round_16":    {},
+		"round_32":    {},
+		"round_64":    {},
+		"round_128":   {},
+	}
+	leagueMatches := []map[string]interface{}{}
+
+	for _, match := range matches {
+
+		score, err := s.store.GetKabaddiMatchScore(ctx, match.PublicID, match.HomeTeamID, match.AwayTeamID)
+		if err != nil {
+			s.logger.Error("Failed to get kabaddi match score:", err)
+		}
+
+		var hScore int
+		var aScore int
+		if score != nil {
+			if score.Home != nil {
+				hScore = score.Home.Points
+			}
+			if score.Away != nil {
+				aScore = score.Away.Points
+			}
+		}
+
+		game, err := s.store.GetGame(ctx, match.HomeGameID)
+		if err != nil {
+			s.logger.Error("Failed to get the game: ", err)
+		}
+
+		matchMap := map[string]interface{}{
+			"id":                match.ID,
+			"public_id":         match.PublicID,
+			"homeTeam":          map[string]interface{}{"id": match.HomeTeamID, "public_id": match.HomeTeamPublicID, "name": match.HomeTeamName, "slug": match.HomeTeamSlug, "short_name": match.HomeTeamShortname, "gender": match.HomeTeamGender, "national": match.HomeTeamNational, "country": match.HomeTeamCountry, "type": match.HomeTeamType, "player_count": match.HomeTeamPlayerCount, "media_url": match.HomeTeamMediaUrl},
+			"homeScore":         hScore,
+			"awayTeam":          map[string]interface{}{"id": match.AwayTeamID, "public_id": match.AwayTeamPublicID, "name": match.AwayTeamName, "slug": match.AwayTeamSlug, "short_name": match.AwayTeamShortname, "gender": match.AwayTeamGender, "national": match.AwayTeamNational, "country": match.AwayTeamCountry, "type": match.AwayTeamType, "player_count": match.AwayTeamPlayerCount, "media_url": match.AwayTeamMediaUrl},
+			"awayScore":         aScore,
+			"start_timestamp":   match.StartTimestamp,
+			"end_timestamp":     match.EndTimestamp,
+			"type":              match.Type,
+			"status_code":       match.StatusCode,
+			"game":              game,
+			"result":            match.Result,
+			"stage":             match.Stage,
+			"knockout_level_id": match.KnockoutLevelID,
+		}
+
+		if match.Stage == nil {
+			// skip matches with no stage set
+		} else if strings.EqualFold(*match.Stage, "group") {
+			groupMatches = append(groupMatches, matchMap)
+		} else if strings.EqualFold(*match.Stage, "knockout") {
+			switch *match.KnockoutLevelID {
+			case 1:
+				knockoutMatches["final"] = append(knockoutMatches["final"], matchMap)
+			case 2:
+				knockoutMatches["semifinal"] = append(knockoutMatches["semifinal"], matchMap)
+			case 3:
+				knockoutMatches["quaterfinal"] = append(knockoutMatches["quaterfinal"], matchMap)
+			case 4:
+				knockoutMatches["round_16"] = append(knockoutMatches["round_16"], matchMap)
+			case 5:
+				knockoutMatches["round_32"] = append(knockoutMatches["round_32"], matchMap)
+			case 6:
+				knockoutMatches["round_64"] = append(knockoutMatches["round_64"], matchMap)
+			case 7:
+				knockoutMatches["round_128"] = append(knockoutMatches["round_128"], matchMap)
+			}
+		} else if strings.EqualFold(*match.Stage, "league") {
+			leagueMatches = append(leagueMatches, matchMap)
+		}
+	}
+	matchDetail = append(matchDetail, map[string]interface{}{
+		"tournament": map[string]interface{}{
+			"id":              tournament.ID,
+			"public_id":       tournament.PublicID,
+			"name":            tournament.Name,
+			"slug":            tournament.Slug,
+			"country":         tournament.Country,
+			"status_code":     tournament.Status,
+			"level":           tournament.Level,
+			"start_timestamp": tournament.StartTimestamp,
+			"game_id":         tournament.GameID,
+			"group_count":     tournament.GroupCount,
+			"max_group_team":  tournament.MaxGroupTeam,
+		},
+		"group_stage":    groupMatches,
+		"league_stage":   leagueMatches,
+		"knockout_stage": knockoutMatches,
+	})
+
+	return matchDetail
+}
+
+func (s *KabaddiServer) GetKabaddiPlayerStatsFunc(ctx *gin.Context) {
+	var req struct {
+		PlayerPublicID string `uri:"player_public_id"`
+	}
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	playerPublicID, err := uuid.Parse(req.PlayerPublicID)
+	if err != nil {
+		s.logger.Error("Invalid UUID format", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid UUID format",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	player, err := s.store.GetPlayer(ctx, playerPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get player: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to fetch player",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	if player == nil {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": "Player not found",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	stats, err := s.store.GetKabaddiPlayerStats(ctx, int32(player.ID))
+	if err != nil {
+		s.logger.Error("Failed to get kabaddi player stats: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to fetch player stats",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    stats,
+	})
+}
@@ -0,0 +1,59 @@
+package kabaddi
+
+import (
+	"context"
+	"khelogames/api/sports"
+	db "khelogames/database"
+	"khelogames/database/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var _ sports.Sport = (*KabaddiServer)(nil)
+var _ sports.MatchScorer = (*KabaddiServer)(nil)
+
+func (s *KabaddiServer) Name() string {
+	return "kabaddi"
+}
+
+func (s *KabaddiServer) ScoreSummary(matches []db.GetMatchByIDRow, tournamentPublicID uuid.UUID) []map[string]interface{} {
+	return s.GetKabaddiScore(matches, tournamentPublicID)
+}
+
+// MatchScore returns the points of each team, their kabaddi score not being part of the match queries
+func (s *KabaddiServer) MatchScore(ctx context.Context, matchPublicID uuid.UUID) (interface{}, interface{}, error) {
+	match, err := s.store.GetMatchModelByPublicId(ctx, matchPublicID)
+	if err != nil {
+		return nil, nil, err
+	}
+	score, err := s.store.GetKabaddiMatchScore(ctx, matchPublicID, match.HomeTeamID, match.AwayTeamID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return score.Home, score.Away, nil
+}
+
+func (s *KabaddiServer) StartMatch(ctx context.Context, q *db.Queries, match *models.Match) error {
+	return s.txStore.StartKabaddiMatch(ctx, q, match)
+}
+
+func (s *KabaddiServer) MatchResult(ctx context.Context, q *db.Queries, match *models.Match) (*int32, error) {
+	return s.txStore.KabaddiMatchResult(ctx, q, match)
+}
+
+func (s *KabaddiServer) UpdateStandings(ctx context.Context, q *db.Queries, match *models.Match) error {
+	return s.txStore.UpdateKabaddiStandings(ctx, q, match)
+}
+
+func (s *KabaddiServer) FinishMatch(ctx context.Context, q *db.Queries, match *models.Match) error {
+	return s.txStore.FinishKabaddiMatch(ctx, q, match)
+}
+
+func (s *KabaddiServer) CreateStanding(ctx context.Context, tournamentPublicID uuid.UUID, groupID int32, teamPublicID uuid.UUID) (interface{}, error) {
+	return s.store.CreateKabaddiStanding(ctx, tournamentPublicID, groupID, teamPublicID)
+}
+
+func (s *KabaddiServer) GetTopPerformerFunc(ctx *gin.Context) {
+	s.GetKabaddiTopPerformerFunc(ctx)
+}
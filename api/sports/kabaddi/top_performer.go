@@ -0,0 +1,29 @@
+package kabaddi
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (s *KabaddiServer) GetKabaddiTopPerformerFunc(ctx *gin.Context) {
+
+	topPerformer, err := s.store.GetKabaddiTopPerformer(ctx)
+	if err != nil {
+		s.logger.Error("Failed to get kabaddi top performer", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Unable to get kabaddi top performer",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"data":    topPerformer,
+	})
+}
@@ -0,0 +1,100 @@
+package kabaddiutils
+
+import (
+	"fmt"
+
+	"khelogames/database/models"
+)
+
+// States of the kabaddi match clock
+const (
+	ClockNotStarted = "not_started"
+	ClockFirstHalf  = "first_half"
+	ClockHalfTime   = "half_time"
+	ClockSecondHalf = "second_half"
+	ClockFullTime   = "full_time"
+)
+
+// HalfMinutes is the length of each half
+const HalfMinutes = 20
+
+var halfStartMinutes = map[string]int{
+	ClockFirstHalf:  0,
+	ClockSecondHalf: HalfMinutes,
+}
+
+var clockTransitions = map[string][]string{
+	ClockNotStarted: {ClockFirstHalf},
+	ClockFirstHalf:  {ClockHalfTime},
+	ClockHalfTime:   {ClockSecondHalf},
+	ClockSecondHalf: {ClockFullTime},
+}
+
+// IsPlayingHalf reports whether the clock runs in the state
+func IsPlayingHalf(state string) bool {
+	_, ok := halfStartMinutes[state]
+	return ok
+}
+
+// ValidateClockTransition returns a message describing why the clock cannot move from one state to
+// the other, empty when the move is allowed
+func ValidateClockTransition(from string, to string) string {
+	for _, next := range clockTransitions[from] {
+		if next == to {
+			return ""
+		}
+	}
+	if len(clockTransitions[from]) == 0 {
+		return fmt.Sprintf("Clock cannot change state after %s", from)
+	}
+	return fmt.Sprintf("Clock cannot move from %s to %s", from, to)
+}
+
+// NextState returns the state the clock moves to when the half being played runs out
+func NextState(state string) string {
+	next := clockTransitions[state]
+	if len(next) == 0 {
+		return ""
+	}
+	return next[0]
+}
+
+// ElapsedSeconds returns the seconds played in the current half at the unix time now, never more
+// than the length of the half
+func ElapsedSeconds(clock models.KabaddiMatchClock, now int64) int {
+	elapsed := clock.ElapsedSeconds
+	if clock.IsRunning && clock.StartedAt != nil && now > *clock.StartedAt {
+		elapsed += int(now - *clock.StartedAt)
+	}
+	if elapsed > HalfMinutes*60 {
+		elapsed = HalfMinutes * 60
+	}
+	return elapsed
+}
+
+// IsHalfOver reports whether the running half has been played for its full length at now
+func IsHalfOver(clock models.KabaddiMatchClock, now int64) bool {
+	return IsPlayingHalf(clock.State) && ElapsedSeconds(clock, now) >= HalfMinutes*60
+}
+
+// MatchMinute returns the match minute of the clock at the unix time now, the first minute of the
+// match being 1 and the first of the second half 21
+func MatchMinute(clock models.KabaddiMatchClock, now int64) int {
+	start, ok := halfStartMinutes[clock.State]
+	if !ok {
+		return 0
+	}
+	minute := ElapsedSeconds(clock, now)/60 + 1
+	if minute > HalfMinutes {
+		minute = HalfMinutes
+	}
+	return start + minute
+}
+
+// RemainingSeconds returns the seconds left in the current half at the unix time now
+func RemainingSeconds(clock models.KabaddiMatchClock, now int64) int {
+	if !IsPlayingHalf(clock.State) {
+		return 0
+	}
+	return HalfMinutes*60 - ElapsedSeconds(clock, now)
+}
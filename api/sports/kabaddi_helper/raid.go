@@ -0,0 +1,208 @@
+package kabaddiutils
+
+import "fmt"
+
+const (
+	// PlayersOnCourt is the number of players a side starts with and is revived to after an all-out
+	PlayersOnCourt = 7
+	// AllOutPoints are the extra points for putting out every player of the other side
+	AllOutPoints = 2
+	// SuperTackleDefenders is the most defenders on court for a tackle to be a super tackle
+	SuperTackleDefenders = 3
+	// BonusLineDefenders is the fewest defenders on court for the bonus line to count
+	BonusLineDefenders = 6
+	// DoOrDieEmptyRaids is the number of empty raids in a row after which a side's raid is do-or-die
+	DoOrDieEmptyRaids = 2
+)
+
+// Raid outcomes
+const (
+	RaidSuccessful = "successful"
+	RaidTackled    = "tackled"
+	RaidEmpty      = "empty"
+)
+
+// Raid is a raid as reported by the scorer. TouchPoints are the defenders the raider put out.
+type Raid struct {
+	Half          string
+	RaidingTeamID int32
+	TouchPoints   int
+	BonusPoint    bool
+	Tackled       bool
+}
+
+// SideState is a side's score and players on court after the raids played so far
+type SideState struct {
+	TeamID     int32 `json:"team_id"`
+	OnCourt    int   `json:"on_court"`
+	EmptyRaids int   `json:"empty_raids"`
+	Points     int   `json:"points"`
+	AllOuts    int   `json:"all_outs"`
+}
+
+// CourtState is the state of both sides after the raids of a match, with who raids next
+type CourtState struct {
+	Home              SideState `json:"home"`
+	Away              SideState `json:"away"`
+	Half              string    `json:"half"`
+	RaidNumber        int       `json:"raid_number"`
+	NextRaidingTeamID int32     `json:"next_raiding_team_id"`
+	DoOrDie           bool      `json:"do_or_die"`
+
+	firstRaidingTeamID int32
+}
+
+// RaidResult is what a raid changed: the points each side won, including all-out points, and the
+// super tackle, do-or-die and all-out that came with it. AllOutTeamID is the side put all out.
+type RaidResult struct {
+	Outcome         string `json:"outcome"`
+	RaidingPoints   int    `json:"raiding_points"`
+	DefendingPoints int    `json:"defending_points"`
+	SuperTackle     bool   `json:"super_tackle"`
+	DoOrDie         bool   `json:"do_or_die"`
+	AllOut          bool   `json:"all_out"`
+	AllOutTeamID    int32  `json:"all_out_team_id"`
+}
+
+// NewCourtState returns the state before the first raid of the match, either side may raid first
+func NewCourtState(homeTeamID, awayTeamID int32) CourtState {
+	return CourtState{
+		Home: SideState{TeamID: homeTeamID, OnCourt: PlayersOnCourt},
+		Away: SideState{TeamID: awayTeamID, OnCourt: PlayersOnCourt},
+		Half: ClockFirstHalf,
+	}
+}
+
+func (state *CourtState) sides(raidingTeamID int32) (*SideState, *SideState) {
+	if raidingTeamID == state.Home.TeamID {
+		return &state.Home, &state.Away
+	}
+	return &state.Away, &state.Home
+}
+
+func (state *CourtState) other(teamID int32) int32 {
+	if teamID == state.Home.TeamID {
+		return state.Away.TeamID
+	}
+	return state.Home.TeamID
+}
+
+// ValidateRaid returns a message describing why the raid cannot be played next, empty when it can
+func ValidateRaid(state CourtState, raid Raid) string {
+	if raid.RaidingTeamID != state.Home.TeamID && raid.RaidingTeamID != state.Away.TeamID {
+		return "Team is not playing this match"
+	}
+
+	next := state.NextRaidingTeamID
+	if raid.Half != state.Half && state.firstRaidingTeamID != 0 {
+		// the side that defended first raids first after half time
+		next = state.other(state.firstRaidingTeamID)
+	}
+	if next != 0 && raid.RaidingTeamID != next {
+		return "It is the other team's raid"
+	}
+
+	_, defence := state.sides(raid.RaidingTeamID)
+	if raid.TouchPoints < 0 || raid.TouchPoints > defence.OnCourt {
+		return fmt.Sprintf("Touch points must be between 0 and the %d defenders on court", defence.OnCourt)
+	}
+	if raid.Tackled && raid.TouchPoints > 0 {
+		return "A tackled raider scores no touch points"
+	}
+	if raid.BonusPoint && defence.OnCourt < BonusLineDefenders {
+		return fmt.Sprintf("Bonus point needs at least %d defenders on court", BonusLineDefenders)
+	}
+	return ""
+}
+
+// revive brings back players of a side, never more than a full court
+func revive(side *SideState, players int) {
+	side.OnCourt += players
+	if side.OnCourt > PlayersOnCourt {
+		side.OnCourt = PlayersOnCourt
+	}
+}
+
+// ApplyRaid plays a validated raid on the state. Every player put out revives one of the scoring
+// side, a raider tackled by three defenders or fewer is a super tackle worth an extra point, and an
+// empty do-or-die raid puts the raider out. A side left with nobody on court is all out: the other
+// side gets the all-out points and the whole side is revived.
+func ApplyRaid(state CourtState, raid Raid) (CourtState, RaidResult) {
+	if raid.Half != state.Half {
+		state.Half = raid.Half
+		if state.firstRaidingTeamID != 0 {
+			state.NextRaidingTeamID = state.other(state.firstRaidingTeamID)
+		}
+	}
+	if state.firstRaidingTeamID == 0 {
+		state.firstRaidingTeamID = raid.RaidingTeamID
+	}
+
+	attack, defence := state.sides(raid.RaidingTeamID)
+	result := RaidResult{DoOrDie: attack.EmptyRaids >= DoOrDieEmptyRaids}
+
+	raidPoints := raid.TouchPoints
+	if raid.BonusPoint {
+		raidPoints++
+	}
+
+	switch {
+	case raid.Tackled:
+		result.Outcome = RaidTackled
+		result.SuperTackle = defence.OnCourt <= SuperTackleDefenders
+		result.DefendingPoints = 1
+		if result.SuperTackle {
+			result.DefendingPoints++
+		}
+		attack.OnCourt--
+		revive(defence, 1)
+		attack.EmptyRaids = 0
+	case raidPoints > 0:
+		result.Outcome = RaidSuccessful
+		defence.OnCourt -= raid.TouchPoints
+		revive(attack, raid.TouchPoints)
+		attack.EmptyRaids = 0
+	case result.DoOrDie:
+		result.Outcome = RaidEmpty
+		result.DefendingPoints = 1
+		attack.OnCourt--
+		revive(defence, 1)
+		attack.EmptyRaids = 0
+	default:
+		result.Outcome = RaidEmpty
+		attack.EmptyRaids++
+	}
+	result.RaidingPoints = raidPoints
+
+	if defence.OnCourt <= 0 {
+		result.AllOut = true
+		result.AllOutTeamID = defence.TeamID
+		result.RaidingPoints += AllOutPoints
+		attack.AllOuts++
+		defence.OnCourt = PlayersOnCourt
+	}
+	if attack.OnCourt <= 0 {
+		result.AllOut = true
+		result.AllOutTeamID = attack.TeamID
+		result.DefendingPoints += AllOutPoints
+		defence.AllOuts++
+		attack.OnCourt = PlayersOnCourt
+	}
+
+	attack.Points += result.RaidingPoints
+	defence.Points += result.DefendingPoints
+
+	state.RaidNumber++
+	state.NextRaidingTeamID = defence.TeamID
+	state.DoOrDie = defence.EmptyRaids >= DoOrDieEmptyRaids
+	return state, result
+}
+
+// ReplayRaids returns the state after the raids of a match in order
+func ReplayRaids(homeTeamID, awayTeamID int32, raids []Raid) CourtState {
+	state := NewCourtState(homeTeamID, awayTeamID)
+	for _, raid := range raids {
+		state, _ = ApplyRaid(state, raid)
+	}
+	return state
+}
@@ -0,0 +1,212 @@
+package kabaddiutils
+
+import "testing"
+
+const (
+	homeTeam int32 = 1
+	awayTeam int32 = 2
+)
+
+func TestApplyRaid(t *testing.T) {
+	tests := []struct {
+		name   string
+		setup  func(state *CourtState)
+		raid   Raid
+		result RaidResult
+		home   SideState
+		away   SideState
+	}{
+		{
+			name:   "touch points and bonus revive the raiding side",
+			setup:  func(state *CourtState) { state.Home.OnCourt = 4 },
+			raid:   Raid{Half: ClockFirstHalf, RaidingTeamID: homeTeam, TouchPoints: 2, BonusPoint: true},
+			result: RaidResult{Outcome: RaidSuccessful, RaidingPoints: 3},
+			home:   SideState{TeamID: homeTeam, OnCourt: 6, Points: 3},
+			away:   SideState{TeamID: awayTeam, OnCourt: 5},
+		},
+		{
+			name:   "revival never goes past a full court",
+			raid:   Raid{Half: ClockFirstHalf, RaidingTeamID: homeTeam, TouchPoints: 1},
+			result: RaidResult{Outcome: RaidSuccessful, RaidingPoints: 1},
+			home:   SideState{TeamID: homeTeam, OnCourt: PlayersOnCourt, Points: 1},
+			away:   SideState{TeamID: awayTeam, OnCourt: 6},
+		},
+		{
+			name:   "tackle",
+			setup:  func(state *CourtState) { state.Away.OnCourt = 5 },
+			raid:   Raid{Half: ClockFirstHalf, RaidingTeamID: homeTeam, Tackled: true},
+			result: RaidResult{Outcome: RaidTackled, DefendingPoints: 1},
+			home:   SideState{TeamID: homeTeam, OnCourt: 6},
+			away:   SideState{TeamID: awayTeam, OnCourt: 6, Points: 1},
+		},
+		{
+			name:   "super tackle by three defenders",
+			setup:  func(state *CourtState) { state.Away.OnCourt = SuperTackleDefenders },
+			raid:   Raid{Half: ClockFirstHalf, RaidingTeamID: homeTeam, Tackled: true},
+			result: RaidResult{Outcome: RaidTackled, DefendingPoints: 2, SuperTackle: true},
+			home:   SideState{TeamID: homeTeam, OnCourt: 6},
+			away:   SideState{TeamID: awayTeam, OnCourt: 4, Points: 2},
+		},
+		{
+			name:   "empty raid",
+			setup:  func(state *CourtState) { state.Home.EmptyRaids = 1 },
+			raid:   Raid{Half: ClockFirstHalf, RaidingTeamID: homeTeam},
+			result: RaidResult{Outcome: RaidEmpty},
+			home:   SideState{TeamID: homeTeam, OnCourt: PlayersOnCourt, EmptyRaids: 2},
+			away:   SideState{TeamID: awayTeam, OnCourt: PlayersOnCourt},
+		},
+		{
+			name:   "empty do-or-die raid puts the raider out",
+			setup:  func(state *CourtState) { state.Home.EmptyRaids = DoOrDieEmptyRaids },
+			raid:   Raid{Half: ClockFirstHalf, RaidingTeamID: homeTeam},
+			result: RaidResult{Outcome: RaidEmpty, DefendingPoints: 1, DoOrDie: true},
+			home:   SideState{TeamID: homeTeam, OnCourt: 6},
+			away:   SideState{TeamID: awayTeam, OnCourt: PlayersOnCourt, Points: 1},
+		},
+		{
+			name:   "successful do-or-die raid",
+			setup:  func(state *CourtState) { state.Home.EmptyRaids = DoOrDieEmptyRaids },
+			raid:   Raid{Half: ClockFirstHalf, RaidingTeamID: homeTeam, TouchPoints: 1},
+			result: RaidResult{Outcome: RaidSuccessful, RaidingPoints: 1, DoOrDie: true},
+			home:   SideState{TeamID: homeTeam, OnCourt: PlayersOnCourt, Points: 1},
+			away:   SideState{TeamID: awayTeam, OnCourt: 6},
+		},
+		{
+			name:   "all out of the defence",
+			setup:  func(state *CourtState) { state.Away.OnCourt = 2 },
+			raid:   Raid{Half: ClockFirstHalf, RaidingTeamID: homeTeam, TouchPoints: 2},
+			result: RaidResult{Outcome: RaidSuccessful, RaidingPoints: 2 + AllOutPoints, AllOut: true, AllOutTeamID: awayTeam},
+			home:   SideState{TeamID: homeTeam, OnCourt: PlayersOnCourt, Points: 2 + AllOutPoints, AllOuts: 1},
+			away:   SideState{TeamID: awayTeam, OnCourt: PlayersOnCourt},
+		},
+		{
+			name:   "all out of the raiding side by a tackle",
+			setup:  func(state *CourtState) { state.Home.OnCourt = 1 },
+			raid:   Raid{Half: ClockFirstHalf, RaidingTeamID: homeTeam, Tackled: true},
+			result: RaidResult{Outcome: RaidTackled, DefendingPoints: 1 + AllOutPoints, AllOut: true, AllOutTeamID: homeTeam},
+			home:   SideState{TeamID: homeTeam, OnCourt: PlayersOnCourt},
+			away:   SideState{TeamID: awayTeam, OnCourt: PlayersOnCourt, Points: 1 + AllOutPoints, AllOuts: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := NewCourtState(homeTeam, awayTeam)
+			if tt.setup != nil {
+				tt.setup(&state)
+			}
+
+			got, result := ApplyRaid(state, tt.raid)
+			if result != tt.result {
+				t.Errorf("result = %+v, want %+v", result, tt.result)
+			}
+			if got.Home != tt.home {
+				t.Errorf("home = %+v, want %+v", got.Home, tt.home)
+			}
+			if got.Away != tt.away {
+				t.Errorf("away = %+v, want %+v", got.Away, tt.away)
+			}
+			if got.RaidNumber != 1 {
+				t.Errorf("raid number = %d, want 1", got.RaidNumber)
+			}
+			if got.NextRaidingTeamID != awayTeam {
+				t.Errorf("next raiding team = %d, want %d", got.NextRaidingTeamID, awayTeam)
+			}
+		})
+	}
+}
+
+func TestApplyRaidDoOrDieNext(t *testing.T) {
+	state := NewCourtState(homeTeam, awayTeam)
+	state.Away.EmptyRaids = DoOrDieEmptyRaids
+
+	got, _ := ApplyRaid(state, Raid{Half: ClockFirstHalf, RaidingTeamID: homeTeam, TouchPoints: 1})
+	if !got.DoOrDie {
+		t.Errorf("next raid of the away side should be do-or-die")
+	}
+}
+
+func TestValidateRaid(t *testing.T) {
+	firstHalfRaid := Raid{Half: ClockFirstHalf, RaidingTeamID: homeTeam}
+
+	tests := []struct {
+		name   string
+		raids  []Raid
+		setup  func(state *CourtState)
+		raid   Raid
+		wantOK bool
+	}{
+		{
+			name:   "either side raids first",
+			raid:   Raid{Half: ClockFirstHalf, RaidingTeamID: awayTeam},
+			wantOK: true,
+		},
+		{
+			name: "team not in the match",
+			raid: Raid{Half: ClockFirstHalf, RaidingTeamID: 3},
+		},
+		{
+			name:  "sides take turns",
+			raids: []Raid{firstHalfRaid},
+			raid:  firstHalfRaid,
+		},
+		{
+			name:   "other side raids next",
+			raids:  []Raid{firstHalfRaid},
+			raid:   Raid{Half: ClockFirstHalf, RaidingTeamID: awayTeam},
+			wantOK: true,
+		},
+		{
+			name:   "side that defended first raids first after half time",
+			raids:  []Raid{firstHalfRaid, {Half: ClockFirstHalf, RaidingTeamID: awayTeam}},
+			raid:   Raid{Half: ClockSecondHalf, RaidingTeamID: awayTeam},
+			wantOK: true,
+		},
+		{
+			name:  "side that raided first defends first after half time",
+			raids: []Raid{firstHalfRaid, {Half: ClockFirstHalf, RaidingTeamID: awayTeam}},
+			raid:  Raid{Half: ClockSecondHalf, RaidingTeamID: homeTeam},
+		},
+		{
+			name:  "more touch points than defenders",
+			setup: func(state *CourtState) { state.Away.OnCourt = 2 },
+			raid:  Raid{Half: ClockFirstHalf, RaidingTeamID: homeTeam, TouchPoints: 3},
+		},
+		{
+			name: "negative touch points",
+			raid: Raid{Half: ClockFirstHalf, RaidingTeamID: homeTeam, TouchPoints: -1},
+		},
+		{
+			name: "tackled raider with touch points",
+			raid: Raid{Half: ClockFirstHalf, RaidingTeamID: homeTeam, TouchPoints: 1, Tackled: true},
+		},
+		{
+			name:  "bonus point without enough defenders",
+			setup: func(state *CourtState) { state.Away.OnCourt = BonusLineDefenders - 1 },
+			raid:  Raid{Half: ClockFirstHalf, RaidingTeamID: homeTeam, BonusPoint: true},
+		},
+		{
+			name:   "bonus point with enough defenders",
+			setup:  func(state *CourtState) { state.Away.OnCourt = BonusLineDefenders },
+			raid:   Raid{Half: ClockFirstHalf, RaidingTeamID: homeTeam, BonusPoint: true},
+			wantOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := ReplayRaids(homeTeam, awayTeam, tt.raids)
+			if tt.setup != nil {
+				tt.setup(&state)
+			}
+
+			msg := ValidateRaid(state, tt.raid)
+			if tt.wantOK && msg != "" {
+				t.Errorf("ValidateRaid() = %q, want the raid allowed", msg)
+			}
+			if !tt.wantOK && msg == "" {
+				t.Errorf("ValidateRaid() allowed the raid, want a message")
+			}
+		})
+	}
+}
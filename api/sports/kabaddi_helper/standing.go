@@ -0,0 +1,23 @@
+package kabaddiutils
+
+// League points of a kabaddi match. A side losing by CloseLossMargin points or fewer still earns
+// CloseLossPoints.
+const (
+	WinPoints       = 5
+	DrawPoints      = 3
+	CloseLossPoints = 1
+	CloseLossMargin = 7
+)
+
+// LeaguePoints returns the league points a side earns for a match it scored pointsFor in
+func LeaguePoints(pointsFor, pointsAgainst int) int {
+	switch {
+	case pointsFor > pointsAgainst:
+		return WinPoints
+	case pointsFor == pointsAgainst:
+		return DrawPoints
+	case pointsAgainst-pointsFor <= CloseLossMargin:
+		return CloseLossPoints
+	}
+	return 0
+}
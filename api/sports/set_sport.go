@@ -0,0 +1,171 @@
+package sports
+
+import (
+	"context"
+	db "khelogames/database"
+	"khelogames/database/models"
+	"khelogames/logger"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// SetsWonFunc returns the sets won by each team in a match of a sport scored in sets
+type SetsWonFunc func(ctx context.Context, matchPublicID uuid.UUID) (*db.SetsWon, error)
+
+// SetSport is the part of Sport shared by the sports scored in sets. Their matches are finished and
+// decided by the point that wins the last set, not by a manual status update, they keep no
+// standings and the score of a match is the sets won by each team. A set sport server embeds it
+// with the query returning its sets won.
+type SetSport struct {
+	store   *db.Store
+	logger  *logger.Logger
+	setsWon SetsWonFunc
+}
+
+func NewSetSport(store *db.Store, logger *logger.Logger, setsWon SetsWonFunc) SetSport {
+	return SetSport{store: store, logger: logger, setsWon: setsWon}
+}
+
+// setsWonScore returns the sets won by the home and away team, zero before a set is finished
+func setsWonScore(score *db.SetsWon) (int, int) {
+	var hScore int
+	var aScore int
+	if score != nil {
+		if score.HomeSetsWon != nil {
+			hScore = *score.HomeSetsWon
+		}
+		if score.AwaySetsWon != nil {
+			aScore = *score.AwaySetsWon
+		}
+	}
+	return hScore, aScore
+}
+
+// ScoreSummary returns the matches of a tournament by stage, each scored by the sets won
+func (s SetSport) ScoreSummary(matches []db.GetMatchByIDRow, tournamentPublicID uuid.UUID) []map[string]interface{} {
+	ctx := context.Background()
+
+	tournament, err := s.store.GetTournament(ctx, tournamentPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get tournament: ", err)
+	}
+
+	var matchDetail []map[string]interface{}
+	groupMatches := []map[string]interface{}{}
+	knockoutMatches := map[string][]map[string]interface{}{
+		"final":       {},
+		"semifinal":   {},
+		"quaterfinal": {},
+		"round_16":    {},
+		"round_32":    {},
+		"round_64":    {},
+		"round_128":   {},
+	}
+	leagueMatches := []map[string]interface{}{}
+
+	for _, match := range matches {
+
+		score, err := s.setsWon(ctx, match.PublicID)
+		if err != nil {
+			s.logger.Error("Failed to get match sets won: ", err)
+		}
+		hScore, aScore := setsWonScore(score)
+
+		game, err := s.store.GetGame(ctx, match.HomeGameID)
+		if err != nil {
+			s.logger.Error("Failed to get the game: ", err)
+		}
+
+		matchMap := map[string]interface{}{
+			"id":                match.ID,
+			"public_id":         match.PublicID,
+			"homeTeam":          map[string]interface{}{"id": match.HomeTeamID, "public_id": match.HomeTeamPublicID, "name": match.HomeTeamName, "slug": match.HomeTeamSlug, "short_name": match.HomeTeamShortname, "gender": match.HomeTeamGender, "national": match.HomeTeamNational, "country": match.HomeTeamCountry, "type": match.HomeTeamType, "player_count": match.HomeTeamPlayerCount, "media_url": match.HomeTeamMediaUrl},
+			"homeScore":         hScore,
+			"awayTeam":          map[string]interface{}{"id": match.AwayTeamID, "public_id": match.AwayTeamPublicID, "name": match.AwayTeamName, "slug": match.AwayTeamSlug, "short_name": match.AwayTeamShortname, "gender": match.AwayTeamGender, "national": match.AwayTeamNational, "country": match.AwayTeamCountry, "type": match.AwayTeamType, "player_count": match.AwayTeamPlayerCount, "media_url": match.AwayTeamMediaUrl},
+			"awayScore":         aScore,
+			"start_timestamp":   match.StartTimestamp,
+			"end_timestamp":     match.EndTimestamp,
+			"type":              match.Type,
+			"status_code":       match.StatusCode,
+			"game":              game,
+			"result":            match.Result,
+			"stage":             match.Stage,
+			"knockout_level_id": match.KnockoutLevelID,
+		}
+
+		if match.Stage == nil {
+			// skip matches with no stage set
+		} else if strings.EqualFold(*match.Stage, "group") {
+			groupMatches = append(groupMatches, matchMap)
+		} else if strings.EqualFold(*match.Stage, "knockout") {
+			switch *match.KnockoutLevelID {
+			case 1:
+				knockoutMatches["final"] = append(knockoutMatches["final"], matchMap)
+			case 2:
+				knockoutMatches["semifinal"] = append(knockoutMatches["semifinal"], matchMap)
+			case 3:
+				knockoutMatches["quaterfinal"] = append(knockoutMatches["quaterfinal"], matchMap)
+			case 4:
+				knockoutMatches["round_16"] = append(knockoutMatches["round_16"], matchMap)
+			case 5:
+				knockoutMatches["round_32"] = append(knockoutMatches["round_32"], matchMap)
+			case 6:
+				knockoutMatches["round_64"] = append(knockoutMatches["round_64"], matchMap)
+			case 7:
+				knockoutMatches["round_128"] = append(knockoutMatches["round_128"], matchMap)
+			}
+		} else if strings.EqualFold(*match.Stage, "league") {
+			leagueMatches = append(leagueMatches, matchMap)
+		}
+	}
+	matchDetail = append(matchDetail, map[string]interface{}{
+		"tournament": map[string]interface{}{
+			"id":              tournament.ID,
+			"public_id":       tournament.PublicID,
+			"name":            tournament.Name,
+			"slug":            tournament.Slug,
+			"country":         tournament.Country,
+			"status_code":     tournament.Status,
+			"level":           tournament.Level,
+			"start_timestamp": tournament.StartTimestamp,
+			"game_id":         tournament.GameID,
+			"group_count":     tournament.GroupCount,
+			"max_group_team":  tournament.MaxGroupTeam,
+		},
+		"group_stage":    groupMatches,
+		"league_stage":   leagueMatches,
+		"knockout_stage": knockoutMatches,
+	})
+
+	return matchDetail
+}
+
+// MatchScore returns the sets won by each team, nil while no set has been played
+func (s SetSport) MatchScore(ctx context.Context, matchPublicID uuid.UUID) (interface{}, interface{}, error) {
+	score, err := s.setsWon(ctx, matchPublicID)
+	if err != nil || score == nil {
+		return nil, nil, err
+	}
+	hScore, aScore := setsWonScore(score)
+	return hScore, aScore, nil
+}
+
+// MatchResult leaves the result alone, the score update winning the last set finishes the match
+func (s SetSport) MatchResult(ctx context.Context, q *db.Queries, match *models.Match) (*int32, error) {
+	return nil, nil
+}
+
+// UpdateStandings has nothing to update, no standings are kept
+func (s SetSport) UpdateStandings(ctx context.Context, q *db.Queries, match *models.Match) error {
+	return nil
+}
+
+// FinishMatch has nothing more to record, the player stats are updated with the winning point
+func (s SetSport) FinishMatch(ctx context.Context, q *db.Queries, match *models.Match) error {
+	return nil
+}
+
+func (s SetSport) CreateStanding(ctx context.Context, tournamentPublicID uuid.UUID, groupID int32, teamPublicID uuid.UUID) (interface{}, error) {
+	return nil, ErrNoStandings
+}
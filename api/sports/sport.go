@@ -0,0 +1,72 @@
+package sports
+
+import (
+	"context"
+	"errors"
+	db "khelogames/database"
+	"khelogames/database/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ErrNoStandings is returned by sports which do not keep tournament standings
+var ErrNoStandings = errors.New("sport does not keep standings")
+
+// Sport is a game the platform can score. Each sport package implements it on its server, and the
+// code shared by every sport (match status, tournament matches, standings and top performers)
+// looks the sport up by name instead of switching on it.
+type Sport interface {
+	// Name is the game name the sport is registered under, as stored in games
+	Name() string
+
+	// ScoreSummary returns the matches of a tournament along with their score
+	ScoreSummary(matches []db.GetMatchByIDRow, tournamentPublicID uuid.UUID) []map[string]interface{}
+
+	// StartMatch sets up the score of a match going in progress, within the status transaction
+	StartMatch(ctx context.Context, q *db.Queries, match *models.Match) error
+
+	// MatchResult works out the winning team of a finished match, nil leaves the result as it is
+	MatchResult(ctx context.Context, q *db.Queries, match *models.Match) (*int32, error)
+
+	// UpdateStandings updates the tournament standing of both teams once the result is in
+	UpdateStandings(ctx context.Context, q *db.Queries, match *models.Match) error
+
+	// FinishMatch records whatever else the sport keeps for a finished match, such as player stats
+	FinishMatch(ctx context.Context, q *db.Queries, match *models.Match) error
+
+	// CreateStanding adds a team to the standings of a tournament, ErrNoStandings when the sport
+	// keeps none
+	CreateStanding(ctx context.Context, tournamentPublicID uuid.UUID, groupID int32, teamPublicID uuid.UUID) (interface{}, error)
+
+	// GetTopPerformerFunc responds with the top performers of the sport
+	GetTopPerformerFunc(ctx *gin.Context)
+}
+
+// MatchScorer is implemented by sports whose score is not part of the match list queries, such as
+// the sets won of a set sport. The shared match endpoints use it to fill in the score of a match.
+type MatchScorer interface {
+	// MatchScore returns the score of the home and away team in a match
+	MatchScore(ctx context.Context, matchPublicID uuid.UUID) (homeScore, awayScore interface{}, err error)
+}
+
+var registry = make(map[string]Sport)
+
+// Register makes a sport available by its name. Sports are registered while the servers are wired
+// up, before any request is served.
+func Register(sport Sport) {
+	registry[sport.Name()] = sport
+}
+
+// Lookup returns the sport registered under the game name
+func Lookup(name string) (Sport, bool) {
+	sport, ok := registry[name]
+	return sport, ok
+}
+
+// LookupMatchScorer returns the match scorer of the sport registered under the game name, false when
+// the sport has none
+func LookupMatchScorer(name string) (MatchScorer, bool) {
+	scorer, ok := registry[name].(MatchScorer)
+	return scorer, ok
+}
@@ -0,0 +1,40 @@
+package tabletennis
+
+import (
+	shared "khelogames/api/shared"
+	"khelogames/api/sports"
+	"khelogames/api/transactions"
+	db "khelogames/database"
+	"khelogames/logger"
+)
+
+type TableTennisServer struct {
+	sports.SetSport
+	store            *db.Store
+	logger           *logger.Logger
+	scoreBroadcaster shared.ScoreBroadcaster
+	txStore          *transactions.SQLStore
+}
+
+func NewTableTennisServer(store *db.Store, logger *logger.Logger, scoreBroadcaster shared.ScoreBroadcaster, txStore *transactions.SQLStore) *TableTennisServer {
+	server := &TableTennisServer{
+		SetSport:         sports.NewSetSport(store, logger, store.GetTableTennisMatchScore),
+		store:            store,
+		logger:           logger,
+		scoreBroadcaster: scoreBroadcaster,
+		txStore:          txStore,
+	}
+
+	return server
+}
+
+func (s *TableTennisServer) SetScoreBroadcaster(broadcaster shared.ScoreBroadcaster) {
+	s.scoreBroadcaster = broadcaster
+}
+
+// GetScoreBroadcaster returns the assigned ScoreBroadcaster
+func (s *TableTennisServer) GetScoreBroadcaster() shared.ScoreBroadcaster {
+	return s.scoreBroadcaster
+}
+
+var _ shared.ScoreBroadcaster
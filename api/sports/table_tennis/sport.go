@@ -0,0 +1,25 @@
+package tabletennis
+
+import (
+	"context"
+	"khelogames/api/sports"
+	db "khelogames/database"
+	"khelogames/database/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+var _ sports.Sport = (*TableTennisServer)(nil)
+var _ sports.MatchScorer = (*TableTennisServer)(nil)
+
+func (s *TableTennisServer) Name() string {
+	return "table_tennis"
+}
+
+func (s *TableTennisServer) StartMatch(ctx context.Context, q *db.Queries, match *models.Match) error {
+	return s.txStore.StartTableTennisMatch(ctx, q, match)
+}
+
+func (s *TableTennisServer) GetTopPerformerFunc(ctx *gin.Context) {
+	s.GetTableTennisTopPerformerFunc(ctx)
+}
@@ -0,0 +1,292 @@
+package tabletennis
+
+import (
+	errorhandler "khelogames/error_handler"
+	"net/http"
+
+	tabletennishelper "khelogames/api/sports/table_tennis_helper"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+func (s *TableTennisServer) UpdateTableTennisScoreFunc(ctx *gin.Context) {
+	var req struct {
+		MatchPublicID string `json:"match_public_id"`
+		TeamPublicID  string `json:"team_public_id"`
+		SetNumber     int    `json:"set_number"`
+	}
+
+	err := ctx.ShouldBindBodyWith(&req, binding.JSON)
+	if err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid UUID format", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid UUID format",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+	teamPublicID, err := uuid.Parse(req.TeamPublicID)
+	if err != nil {
+		s.logger.Error("Invalid UUID format", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid UUID format",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	matchResult, setScore, point, newSet, serve, err := s.txStore.UpdateTableTennisScoreTx(ctx, matchPublicID, teamPublicID, req.SetNumber)
+	if err != nil {
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
+		s.logger.Error("Failed to update table tennis score: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to update table tennis score",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	matchScore, err := s.store.GetTableTennisMatchScore(ctx, matchPublicID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get table tennis score",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	if s.scoreBroadcaster != nil {
+
+		payload := map[string]interface{}{
+			"match_public_id": matchPublicID,
+			"score":           setScore,
+			"match_result":    matchResult,
+			"point":           point,
+			"new_set":         newSet,
+			"serve":           serve,
+			"match_score": map[string]interface{}{
+				"match_public_id": matchPublicID,
+				"homeScore":       matchScore.HomeSetsWon,
+				"awayScore":       matchScore.AwaySetsWon,
+			},
+		}
+		err := s.scoreBroadcaster.BroadcastMatchEvent(ctx, "UPDATE_TABLE_TENNIS_SCORE", payload)
+		if err != nil {
+			s.logger.Warn("Broadcast failed: ", err)
+		}
+	}
+
+	ctx.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"data": gin.H{
+			"score":        setScore,
+			"match_result": matchResult,
+			"point":        point,
+			"new_set":      newSet,
+			"serve":        serve,
+			"match_score": map[string]interface{}{
+				"public_id": matchPublicID,
+				"homeScore": matchScore.HomeSetsWon,
+				"awayScore": matchScore.AwaySetsWon,
+			},
+		},
+	})
+}
+
+func (s *TableTennisServer) GetTableTennisScoreFunc(ctx *gin.Context) {
+	var req struct {
+		MatchPublicID string `uri:"match_public_id"`
+	}
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid UUID format", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid UUID format",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	match, err := s.store.GetMatchModelByPublicId(ctx, matchPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get match: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to fetch match",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	score, err := s.store.GetTableTennisMatchSetsScore(ctx, matchPublicID)
+	if err != nil {
+		s.logger.Error("Unable to get sets score: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to fetch table tennis score",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	var sets []map[string]interface{}
+	var serve *tabletennishelper.ServeState
+
+	for _, item := range score {
+		points, err := s.store.GetTableTennisPoints(ctx, item.MatchID, item.SetNumber)
+		if err != nil {
+			s.logger.Error("Unable to get sets points: ", err)
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error": gin.H{
+					"code":    "INTERNAL_ERROR",
+					"message": "Failed to fetch set points",
+				},
+				"request_id": ctx.GetString("request_id"),
+			})
+			return
+		}
+
+		set := map[string]interface{}{
+			"set_number": item.SetNumber,
+			"home_score": item.HomeScore,
+			"away_score": item.AwayScore,
+			"set_status": item.SetStatus,
+			"points":     points,
+		}
+
+		sets = append(sets, set)
+
+		if item.SetStatus != "finished" {
+			rules := tabletennishelper.DefaultRules
+			current := tabletennishelper.Serve(rules, item.SetNumber, match.HomeTeamID, match.AwayTeamID, item.HomeScore, item.AwayScore)
+			serve = &current
+		}
+	}
+
+	response := map[string]interface{}{
+		"match_public_id": matchPublicID,
+		"sets":            sets,
+		"serve":           serve,
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    response,
+	})
+}
+
+func (s *TableTennisServer) GetTableTennisPlayerStatsFunc(ctx *gin.Context) {
+	var req struct {
+		PlayerPublicID string `uri:"player_public_id"`
+	}
+
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	playerPublicID, err := uuid.Parse(req.PlayerPublicID)
+	if err != nil {
+		s.logger.Error("Invalid UUID format", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "VALIDATION_ERROR",
+				"message": "Invalid UUID format",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	player, err := s.store.GetPlayer(ctx, playerPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get player: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to fetch player",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	if player == nil {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "NOT_FOUND",
+				"message": "Player not found",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	stats, err := s.store.GetTableTennisPlayerStats(ctx, int32(player.ID))
+	if err != nil {
+		s.logger.Error("Failed to get table tennis player stats: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to fetch player stats",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    stats,
+	})
+}
@@ -0,0 +1,29 @@
+package tabletennis
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (s *TableTennisServer) GetTableTennisTopPerformerFunc(ctx *gin.Context) {
+
+	topPerformer, err := s.store.GetTableTennisTopPerformer(ctx)
+	if err != nil {
+		s.logger.Error("Failed to get table tennis top performer", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Unable to get table tennis top performer",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"data":    topPerformer,
+	})
+}
@@ -0,0 +1,91 @@
+package tabletennisutils
+
+// Rules are the scoring rules of a table tennis match. A game is won at PointsToWin with a lead of
+// WinBy. The serve passes to the other side every ServesPerTurn points, and after every point once
+// both sides reach one point short of the game; the players change ends in the deciding game when a
+// side first reaches EndChangeAt.
+type Rules struct {
+	PointsToWin   int `json:"points_to_win"`
+	WinBy         int `json:"win_by"`
+	SetsToWin     int `json:"sets_to_win"`
+	ServesPerTurn int `json:"serves_per_turn"`
+	EndChangeAt   int `json:"end_change_at"`
+}
+
+// DefaultRules are the laws of table tennis: best of five games of 11 points
+var DefaultRules = Rules{
+	PointsToWin:   11,
+	WinBy:         2,
+	SetsToWin:     3,
+	ServesPerTurn: 2,
+	EndChangeAt:   5,
+}
+
+// ServeState is who serves the next point of a game
+type ServeState struct {
+	SetNumber      int   `json:"set_number"`
+	ServerTeamID   int32 `json:"server_team_id"`
+	ReceiverTeamID int32 `json:"receiver_team_id"`
+	ServesLeft     int   `json:"serves_left"`
+	EndChange      bool  `json:"end_change"`
+}
+
+// IsSetFinished reports whether a game is over at the given score
+func IsSetFinished(rules Rules, homeScore, awayScore int) bool {
+	leader, trailer := homeScore, awayScore
+	if awayScore > homeScore {
+		leader, trailer = awayScore, homeScore
+	}
+	return leader >= rules.PointsToWin && leader-trailer >= rules.WinBy
+}
+
+// IsDecidingSet reports whether the game is the last one the match can go to
+func IsDecidingSet(rules Rules, setNumber int) bool {
+	return setNumber == 2*rules.SetsToWin-1
+}
+
+// FirstServer returns the side serving the first point of a game: the home side serves the first
+// game and the first serve alternates from game to game
+func FirstServer(setNumber int, homeTeamID, awayTeamID int32) int32 {
+	if setNumber%2 == 1 {
+		return homeTeamID
+	}
+	return awayTeamID
+}
+
+// Serve returns who serves the next point of a game at the given score
+func Serve(rules Rules, setNumber int, homeTeamID, awayTeamID int32, homeScore, awayScore int) ServeState {
+	played := homeScore + awayScore
+	deuce := rules.PointsToWin - 1
+
+	var turn, servesLeft int
+	if homeScore >= deuce && awayScore >= deuce {
+		// from deuce the serve changes after every point
+		turn = 2*deuce/rules.ServesPerTurn + played - 2*deuce
+		servesLeft = 1
+	} else {
+		turn = played / rules.ServesPerTurn
+		servesLeft = rules.ServesPerTurn - played%rules.ServesPerTurn
+	}
+
+	server, receiver := homeTeamID, awayTeamID
+	if FirstServer(setNumber, homeTeamID, awayTeamID) == awayTeamID {
+		server, receiver = awayTeamID, homeTeamID
+	}
+	if turn%2 == 1 {
+		server, receiver = receiver, server
+	}
+
+	return ServeState{
+		SetNumber:      setNumber,
+		ServerTeamID:   server,
+		ReceiverTeamID: receiver,
+		ServesLeft:     servesLeft,
+	}
+}
+
+// IsEndChange reports whether the point just won takes the scoring side to the end change score of
+// the deciding game first
+func IsEndChange(rules Rules, setNumber, scorerScore, otherScore int) bool {
+	return IsDecidingSet(rules, setNumber) && scorerScore == rules.EndChangeAt && otherScore < rules.EndChangeAt
+}
@@ -3,17 +3,12 @@ package sports
 import "github.com/gin-gonic/gin"
 
 func (s *SportsServer) GetTopPerformerHandler(c *gin.Context) {
-	sport := c.Param("sport")
-	switch sport {
-	case "football":
-		s.footballServer.GetFootballTopPerformerFunc(c)
-	case "cricket":
-		s.cricketServer.GetCricketTopPerformerFunc(c)
-	case "badminton":
-		s.badmintonServer.GetBadmintonTopPerformerFunc(c)
-	default:
+	sport, ok := Lookup(c.Param("sport"))
+	if !ok {
 		c.JSON(400, gin.H{
 			"error": "invalid sport",
 		})
+		return
 	}
+	sport.GetTopPerformerFunc(c)
 }
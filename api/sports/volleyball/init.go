@@ -0,0 +1,40 @@
+package volleyball
+
+import (
+	shared "khelogames/api/shared"
+	"khelogames/api/sports"
+	"khelogames/api/transactions"
+	db "khelogames/database"
+	"khelogames/logger"
+)
+
+type VolleyballServer struct {
+	sports.SetSport
+	store            *db.Store
+	logger           *logger.Logger
+	scoreBroadcaster shared.ScoreBroadcaster
+	txStore          *transactions.SQLStore
+}
+
+func NewVolleyballServer(store *db.Store, logger *logger.Logger, scoreBroadcaster shared.ScoreBroadcaster, txStore *transactions.SQLStore) *VolleyballServer {
+	server := &VolleyballServer{
+		SetSport:         sports.NewSetSport(store, logger, store.GetVolleyballMatchScore),
+		store:            store,
+		logger:           logger,
+		scoreBroadcaster: scoreBroadcaster,
+		txStore:          txStore,
+	}
+
+	return server
+}
+
+func (s *VolleyballServer) SetScoreBroadcaster(broadcaster shared.ScoreBroadcaster) {
+	s.scoreBroadcaster = broadcaster
+}
+
+// GetScoreBroadcaster returns the assigned ScoreBroadcaster
+func (s *VolleyballServer) GetScoreBroadcaster() shared.ScoreBroadcaster {
+	return s.scoreBroadcaster
+}
+
+var _ shared.ScoreBroadcaster
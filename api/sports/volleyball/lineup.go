@@ -0,0 +1,129 @@
+package volleyball
+
+import (
+	errorhandler "khelogames/error_handler"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+type updateVolleyballLineupRequest struct {
+	MatchPublicID   string   `json:"match_public_id" binding:"required"`
+	TeamPublicID    string   `json:"team_public_id" binding:"required"`
+	SetNumber       int      `json:"set_number" binding:"required"`
+	PlayerPublicIDs []string `json:"player_public_ids" binding:"required,len=6"`
+}
+
+// UpdateVolleyballLineupFunc sets the starting rotation of a team for a set, position 1 first
+func (s *VolleyballServer) UpdateVolleyballLineupFunc(ctx *gin.Context) {
+	s.logger.Info("Received request to update volleyball lineup")
+	var req updateVolleyballLineupRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		s.logger.Error("Failed to bind request: ", err)
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format: ", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+	teamPublicID, err := uuid.Parse(req.TeamPublicID)
+	if err != nil {
+		s.logger.Error("Invalid team UUID format: ", err)
+		fieldErrors := map[string]string{"team_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	var playerPublicIDs []uuid.UUID
+	for _, id := range req.PlayerPublicIDs {
+		playerPublicID, err := uuid.Parse(id)
+		if err != nil {
+			s.logger.Error("Invalid player UUID format: ", err)
+			fieldErrors := map[string]string{"player_public_ids": "Invalid UUID format"}
+			errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+			return
+		}
+		playerPublicIDs = append(playerPublicIDs, playerPublicID)
+	}
+
+	rotation, err := s.txStore.UpdateVolleyballLineupTx(ctx, matchPublicID, teamPublicID, req.SetNumber, playerPublicIDs)
+	if err != nil {
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
+		s.logger.Error("Failed to update volleyball lineup: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to update volleyball lineup",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	if s.scoreBroadcaster != nil {
+		payload := map[string]interface{}{
+			"match_public_id": matchPublicID,
+			"rotation":        rotation,
+		}
+		err := s.scoreBroadcaster.BroadcastMatchEvent(ctx, "UPDATE_VOLLEYBALL_LINEUP", payload)
+		if err != nil {
+			s.logger.Warn("Broadcast failed: ", err)
+		}
+	}
+
+	s.logger.Info("Successfully updated volleyball lineup")
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    rotation,
+	})
+}
+
+// GetVolleyballRotationFunc returns the positions of both teams and the server of the current set
+func (s *VolleyballServer) GetVolleyballRotationFunc(ctx *gin.Context) {
+	var req struct {
+		MatchPublicID string `uri:"match_public_id" binding:"required"`
+	}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format: ", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	rotation, err := s.txStore.GetVolleyballRotationTx(ctx, matchPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get volleyball rotation: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get volleyball rotation",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    rotation,
+	})
+}
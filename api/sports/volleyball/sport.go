@@ -0,0 +1,25 @@
+package volleyball
+
+import (
+	"context"
+	"khelogames/api/sports"
+	db "khelogames/database"
+	"khelogames/database/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+var _ sports.Sport = (*VolleyballServer)(nil)
+var _ sports.MatchScorer = (*VolleyballServer)(nil)
+
+func (s *VolleyballServer) Name() string {
+	return "volleyball"
+}
+
+func (s *VolleyballServer) StartMatch(ctx context.Context, q *db.Queries, match *models.Match) error {
+	return s.txStore.StartVolleyballMatch(ctx, q, match)
+}
+
+func (s *VolleyballServer) GetTopPerformerFunc(ctx *gin.Context) {
+	s.GetVolleyballTopPerformerFunc(ctx)
+}
@@ -0,0 +1,29 @@
+package volleyball
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (s *VolleyballServer) GetVolleyballTopPerformerFunc(ctx *gin.Context) {
+
+	topPerformer, err := s.store.GetVolleyballTopPerformer(ctx)
+	if err != nil {
+		s.logger.Error("Failed to get volleyball top performer", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Unable to get volleyball top performer",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"data":    topPerformer,
+	})
+}
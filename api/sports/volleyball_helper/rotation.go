@@ -0,0 +1,102 @@
+package volleyballutils
+
+// CourtPositions is the number of players a side has on court
+const CourtPositions = 6
+
+// Lineup is the starting order of a side in a set: Players[0] starts in position 1 and serves first,
+// Players[1] in position 2 and so on
+type Lineup struct {
+	TeamID  int32
+	Players []int32
+}
+
+// SideRotation is where the players of a side stand after it has rotated Rotations times in the
+// set. Positions[0] is position 1, the server's.
+type SideRotation struct {
+	TeamID    int32   `json:"team_id"`
+	Rotations int     `json:"rotations"`
+	Positions []int32 `json:"positions"`
+}
+
+// RotationState is the score and rotation of both sides after the rallies of a set, with who serves
+// the next rally
+type RotationState struct {
+	SetNumber      int          `json:"set_number"`
+	HomeScore      int          `json:"home_score"`
+	AwayScore      int          `json:"away_score"`
+	ServingTeamID  int32        `json:"serving_team_id"`
+	ServerPlayerID int32        `json:"server_player_id"`
+	Home           SideRotation `json:"home"`
+	Away           SideRotation `json:"away"`
+	SideOut        bool         `json:"side_out"`
+	SetFinished    bool         `json:"set_finished"`
+	WinnerTeamID   int32        `json:"winner_team_id"`
+}
+
+// Positions returns the players of a lineup on court after the side has rotated the given number
+// of times, each rotation moving every player one position clockwise
+func Positions(lineup Lineup, rotations int) []int32 {
+	players := lineup.Players
+	if len(players) > CourtPositions {
+		players = players[:CourtPositions]
+	}
+	if len(players) == 0 {
+		return nil
+	}
+
+	positions := make([]int32, len(players))
+	for i := range players {
+		positions[i] = players[(i+rotations)%len(players)]
+	}
+	return positions
+}
+
+// SetRotation replays the rallies of a set from the starting lineups, scoringTeamIDs being the side
+// winning each rally in order. A side winning a rally on the other side's serve rotates and serves
+// the next rally from its new position 1.
+func SetRotation(rules Rules, setNumber int, home, away Lineup, scoringTeamIDs []int32) RotationState {
+	state := RotationState{
+		SetNumber:     setNumber,
+		ServingTeamID: FirstServer(rules, setNumber, home.TeamID, away.TeamID),
+		Home:          SideRotation{TeamID: home.TeamID},
+		Away:          SideRotation{TeamID: away.TeamID},
+	}
+
+	for _, teamID := range scoringTeamIDs {
+		if teamID == home.TeamID {
+			state.HomeScore++
+		} else {
+			state.AwayScore++
+		}
+
+		state.SideOut = teamID != state.ServingTeamID
+		if state.SideOut {
+			if teamID == home.TeamID {
+				state.Home.Rotations++
+			} else {
+				state.Away.Rotations++
+			}
+			state.ServingTeamID = teamID
+		}
+	}
+
+	state.Home.Positions = Positions(home, state.Home.Rotations)
+	state.Away.Positions = Positions(away, state.Away.Rotations)
+
+	server := state.Home
+	if state.ServingTeamID == away.TeamID {
+		server = state.Away
+	}
+	if len(server.Positions) > 0 {
+		state.ServerPlayerID = server.Positions[0]
+	}
+
+	if IsSetFinished(rules, setNumber, state.HomeScore, state.AwayScore) {
+		state.SetFinished = true
+		state.WinnerTeamID = home.TeamID
+		if state.AwayScore > state.HomeScore {
+			state.WinnerTeamID = away.TeamID
+		}
+	}
+	return state
+}
@@ -0,0 +1,204 @@
+package volleyballutils
+
+import (
+	"reflect"
+	"testing"
+)
+
+var (
+	homeLineup = Lineup{TeamID: 1, Players: []int32{11, 12, 13, 14, 15, 16}}
+	awayLineup = Lineup{TeamID: 2, Players: []int32{21, 22, 23, 24, 25, 26}}
+)
+
+// rallies returns the side winning each of n rallies in turn, starting with first
+func rallies(first, second int32, n int) []int32 {
+	teams := make([]int32, n)
+	for i := range teams {
+		teams[i] = first
+		if i%2 == 1 {
+			teams[i] = second
+		}
+	}
+	return teams
+}
+
+// repeat returns n rallies all won by the same side
+func repeat(teamID int32, n int) []int32 {
+	teams := make([]int32, n)
+	for i := range teams {
+		teams[i] = teamID
+	}
+	return teams
+}
+
+func TestPositions(t *testing.T) {
+	tests := []struct {
+		name      string
+		lineup    Lineup
+		rotations int
+		want      []int32
+	}{
+		{
+			name:   "starting order",
+			lineup: homeLineup,
+			want:   []int32{11, 12, 13, 14, 15, 16},
+		},
+		{
+			name:      "one rotation",
+			lineup:    homeLineup,
+			rotations: 1,
+			want:      []int32{12, 13, 14, 15, 16, 11},
+		},
+		{
+			name:      "rotations wrap around the court",
+			lineup:    homeLineup,
+			rotations: CourtPositions + 2,
+			want:      []int32{13, 14, 15, 16, 11, 12},
+		},
+		{
+			name:   "only six players are on court",
+			lineup: Lineup{TeamID: 1, Players: []int32{11, 12, 13, 14, 15, 16, 17}},
+			want:   []int32{11, 12, 13, 14, 15, 16},
+		},
+		{
+			name:   "no lineup",
+			lineup: Lineup{TeamID: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Positions(tt.lineup, tt.rotations)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Positions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetRotation(t *testing.T) {
+	tests := []struct {
+		name          string
+		setNumber     int
+		rallies       []int32
+		homeScore     int
+		awayScore     int
+		serving       int32
+		server        int32
+		homeRotations int
+		awayRotations int
+		sideOut       bool
+		winner        int32
+	}{
+		{
+			name:      "home serves the first set",
+			setNumber: 1,
+			serving:   1,
+			server:    11,
+		},
+		{
+			name:      "away serves the second set",
+			setNumber: 2,
+			serving:   2,
+			server:    21,
+		},
+		{
+			name:      "home serves the deciding set",
+			setNumber: 5,
+			serving:   1,
+			server:    11,
+		},
+		{
+			name:      "serving side keeps the serve",
+			setNumber: 1,
+			rallies:   []int32{1, 1},
+			homeScore: 2,
+			serving:   1,
+			server:    11,
+		},
+		{
+			name:          "side out rotates the receiving side",
+			setNumber:     1,
+			rallies:       []int32{2},
+			awayScore:     1,
+			serving:       2,
+			server:        22,
+			awayRotations: 1,
+			sideOut:       true,
+		},
+		{
+			name:          "sides rotate on each side out",
+			setNumber:     1,
+			rallies:       []int32{2, 1, 2},
+			homeScore:     1,
+			awayScore:     2,
+			serving:       2,
+			server:        23,
+			homeRotations: 1,
+			awayRotations: 2,
+			sideOut:       true,
+		},
+		{
+			name:          "rotation wraps around the court",
+			setNumber:     1,
+			rallies:       rallies(2, 1, 2*CourtPositions),
+			homeScore:     CourtPositions,
+			awayScore:     CourtPositions,
+			serving:       1,
+			server:        11,
+			homeRotations: CourtPositions,
+			awayRotations: CourtPositions,
+			sideOut:       true,
+		},
+		{
+			name:      "set is played to 25",
+			setNumber: 1,
+			rallies:   repeat(1, 25),
+			homeScore: 25,
+			serving:   1,
+			server:    11,
+			winner:    1,
+		},
+		{
+			name:          "deciding set is played to 15",
+			setNumber:     5,
+			rallies:       append([]int32{2}, repeat(2, 14)...),
+			awayScore:     15,
+			serving:       2,
+			server:        22,
+			awayRotations: 1,
+			winner:        2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SetRotation(DefaultRules, tt.setNumber, homeLineup, awayLineup, tt.rallies)
+
+			if got.HomeScore != tt.homeScore || got.AwayScore != tt.awayScore {
+				t.Errorf("score = %d-%d, want %d-%d", got.HomeScore, got.AwayScore, tt.homeScore, tt.awayScore)
+			}
+			if got.ServingTeamID != tt.serving {
+				t.Errorf("serving team = %d, want %d", got.ServingTeamID, tt.serving)
+			}
+			if got.ServerPlayerID != tt.server {
+				t.Errorf("server = %d, want %d", got.ServerPlayerID, tt.server)
+			}
+			if got.Home.Rotations != tt.homeRotations || got.Away.Rotations != tt.awayRotations {
+				t.Errorf("rotations = %d/%d, want %d/%d", got.Home.Rotations, got.Away.Rotations, tt.homeRotations, tt.awayRotations)
+			}
+			if got.SideOut != tt.sideOut {
+				t.Errorf("side out = %v, want %v", got.SideOut, tt.sideOut)
+			}
+			if got.SetFinished != (tt.winner != 0) || got.WinnerTeamID != tt.winner {
+				t.Errorf("set finished = %v won by %d, want won by %d", got.SetFinished, got.WinnerTeamID, tt.winner)
+			}
+			if !reflect.DeepEqual(got.Home.Positions, Positions(homeLineup, tt.homeRotations)) {
+				t.Errorf("home positions = %v", got.Home.Positions)
+			}
+			if !reflect.DeepEqual(got.Away.Positions, Positions(awayLineup, tt.awayRotations)) {
+				t.Errorf("away positions = %v", got.Away.Positions)
+			}
+		})
+	}
+}
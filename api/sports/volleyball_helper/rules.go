@@ -0,0 +1,49 @@
+package volleyballutils
+
+// Rules are the scoring rules of a volleyball match. A set is won at PointsToWin with a lead of
+// WinBy, the deciding set at DecidingSetPoints.
+type Rules struct {
+	PointsToWin       int `json:"points_to_win"`
+	DecidingSetPoints int `json:"deciding_set_points"`
+	WinBy             int `json:"win_by"`
+	SetsToWin         int `json:"sets_to_win"`
+}
+
+// DefaultRules are the rules of indoor volleyball: best of five sets of 25 points, the fifth to 15
+var DefaultRules = Rules{
+	PointsToWin:       25,
+	DecidingSetPoints: 15,
+	WinBy:             2,
+	SetsToWin:         3,
+}
+
+// IsDecidingSet reports whether the set is the last one the match can go to
+func IsDecidingSet(rules Rules, setNumber int) bool {
+	return setNumber == 2*rules.SetsToWin-1
+}
+
+// SetPoints returns the points needed to win the set
+func SetPoints(rules Rules, setNumber int) int {
+	if IsDecidingSet(rules, setNumber) {
+		return rules.DecidingSetPoints
+	}
+	return rules.PointsToWin
+}
+
+// IsSetFinished reports whether a set is over at the given score
+func IsSetFinished(rules Rules, setNumber, homeScore, awayScore int) bool {
+	leader, trailer := homeScore, awayScore
+	if awayScore > homeScore {
+		leader, trailer = awayScore, homeScore
+	}
+	return leader >= SetPoints(rules, setNumber) && leader-trailer >= rules.WinBy
+}
+
+// FirstServer returns the side serving the first rally of a set: the home side serves the first set
+// and the deciding set, and the first serve alternates in between
+func FirstServer(rules Rules, setNumber int, homeTeamID, awayTeamID int32) int32 {
+	if IsDecidingSet(rules, setNumber) || setNumber%2 == 1 {
+		return homeTeamID
+	}
+	return awayTeamID
+}
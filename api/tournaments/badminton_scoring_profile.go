@@ -0,0 +1,133 @@
+package tournaments
+
+import (
+	"net/http"
+
+	badmintonhelper "khelogames/api/sports/badminton_helper"
+	db "khelogames/database"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+// UpdateBadmintonScoringProfileFunc sets the scoring format played by the badminton matches of the
+// tournament, a named format with any of its values overridden
+func (s *TournamentServer) UpdateBadmintonScoringProfileFunc(ctx *gin.Context) {
+	var uri struct {
+		TournamentPublicID string `uri:"tournament_public_id" binding:"required"`
+	}
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	var req badmintonhelper.ScoringFormat
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		s.logger.Error("Failed to bind request: ", err)
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	tournamentPublicID, err := uuid.Parse(uri.TournamentPublicID)
+	if err != nil {
+		s.logger.Error("Failed to parse tournament public id: ", err)
+		fieldErrors := map[string]string{"tournament_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	if req.HomeHandicap != nil || req.AwayHandicap != nil {
+		fieldErrors := map[string]string{"rules": "Handicaps can only be set for a single match"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+	rules, msg := req.Rules()
+	if msg != "" {
+		fieldErrors := map[string]string{"rules": msg}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	profile, err := s.store.UpsertBadmintonTournamentProfile(ctx, tournamentPublicID, db.BadmintonScoringProfileParams{
+		PointsPerGame: rules.PointsToWin,
+		WinBy:         rules.WinBy,
+		MaxPoints:     rules.MaxPoints,
+		GamesToWin:    rules.SetsToWin,
+		IntervalAt:    rules.IntervalAt,
+	})
+	if err != nil {
+		s.logger.Error("Failed to update badminton scoring profile: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to update badminton scoring profile",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    profile,
+	})
+}
+
+// GetBadmintonScoringProfileFunc returns the scoring rules of the tournament, the default rules
+// when it has not set its own
+func (s *TournamentServer) GetBadmintonScoringProfileFunc(ctx *gin.Context) {
+	var req struct {
+		TournamentPublicID string `uri:"tournament_public_id" binding:"required"`
+	}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	tournamentPublicID, err := uuid.Parse(req.TournamentPublicID)
+	if err != nil {
+		s.logger.Error("Failed to parse tournament public id: ", err)
+		fieldErrors := map[string]string{"tournament_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	tournament, err := s.store.GetTournament(ctx, tournamentPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get tournament: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get tournament",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	profile, err := s.store.GetBadmintonTournamentProfile(ctx, int32(tournament.ID))
+	if err != nil {
+		s.logger.Error("Failed to get badminton scoring profile: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get badminton scoring profile",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    badmintonhelper.ProfileRules(profile),
+	})
+}
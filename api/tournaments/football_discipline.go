@@ -0,0 +1,226 @@
+package tournaments
+
+import (
+	"net/http"
+
+	footballhelper "khelogames/api/sports/football_helper"
+	db "khelogames/database"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+)
+
+type updateFootballDisciplinaryRulesRequest struct {
+	YellowCardThreshold int  `json:"yellow_card_threshold" binding:"required"`
+	YellowCardBan       int  `json:"yellow_card_ban"`
+	RedCardBan          int  `json:"red_card_ban"`
+	CarryOverToKnockout bool `json:"carry_over_to_knockout"`
+}
+
+// UpdateFootballDisciplinaryRulesFunc sets the yellow card threshold, the ban lengths and whether
+// bans carry over to the knockout stage for the tournament
+func (s *TournamentServer) UpdateFootballDisciplinaryRulesFunc(ctx *gin.Context) {
+	var uri struct {
+		TournamentPublicID string `uri:"tournament_public_id" binding:"required"`
+	}
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	var req updateFootballDisciplinaryRulesRequest
+	if err := ctx.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		s.logger.Error("Failed to bind request: ", err)
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	tournamentPublicID, err := uuid.Parse(uri.TournamentPublicID)
+	if err != nil {
+		s.logger.Error("Failed to parse tournament public id: ", err)
+		fieldErrors := map[string]string{"tournament_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	rules := footballhelper.DisciplinaryRules{
+		YellowCardThreshold: req.YellowCardThreshold,
+		YellowCardBan:       req.YellowCardBan,
+		RedCardBan:          req.RedCardBan,
+		CarryOverToKnockout: req.CarryOverToKnockout,
+	}
+	if msg := footballhelper.ValidateDisciplinaryRules(rules); msg != "" {
+		fieldErrors := map[string]string{"rules": msg}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	updated, err := s.store.UpsertFootballDisciplinaryRules(ctx, db.UpsertFootballDisciplinaryRulesParams{
+		TournamentPublicID:  tournamentPublicID,
+		YellowCardThreshold: rules.YellowCardThreshold,
+		YellowCardBan:       rules.YellowCardBan,
+		RedCardBan:          rules.RedCardBan,
+		CarryOverToKnockout: rules.CarryOverToKnockout,
+	})
+	if err != nil {
+		s.logger.Error("Failed to update disciplinary rules: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to update disciplinary rules",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    updated,
+	})
+}
+
+// GetFootballDisciplinaryRulesFunc returns the disciplinary rules of the tournament, the default
+// rules when it has not set its own
+func (s *TournamentServer) GetFootballDisciplinaryRulesFunc(ctx *gin.Context) {
+	var req struct {
+		TournamentPublicID string `uri:"tournament_public_id" binding:"required"`
+	}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	tournamentPublicID, err := uuid.Parse(req.TournamentPublicID)
+	if err != nil {
+		s.logger.Error("Failed to parse tournament public id: ", err)
+		fieldErrors := map[string]string{"tournament_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	tournament, err := s.store.GetTournament(ctx, tournamentPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get tournament: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get tournament",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	rules, err := s.store.GetFootballDisciplinaryRules(ctx, int32(tournament.ID))
+	if err != nil {
+		s.logger.Error("Failed to get disciplinary rules: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get disciplinary rules",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	if rules == nil {
+		ctx.JSON(http.StatusOK, gin.H{
+			"success": true,
+			"data":    footballhelper.DefaultDisciplinaryRules,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    rules,
+	})
+}
+
+// GetFootballTournamentSuspensionsFunc returns the suspension ledger of the tournament
+func (s *TournamentServer) GetFootballTournamentSuspensionsFunc(ctx *gin.Context) {
+	var req struct {
+		TournamentPublicID string `uri:"tournament_public_id" binding:"required"`
+	}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	tournamentPublicID, err := uuid.Parse(req.TournamentPublicID)
+	if err != nil {
+		s.logger.Error("Failed to parse tournament public id: ", err)
+		fieldErrors := map[string]string{"tournament_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	suspensions, err := s.store.GetFootballTournamentSuspensions(ctx, tournamentPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get suspensions: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get suspensions",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    suspensions,
+	})
+}
+
+// GetFootballSuspendedPlayersFunc returns the players of both teams who are banned from the match
+func (s *TournamentServer) GetFootballSuspendedPlayersFunc(ctx *gin.Context) {
+	var req struct {
+		MatchPublicID string `uri:"match_public_id" binding:"required"`
+	}
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	matchPublicID, err := uuid.Parse(req.MatchPublicID)
+	if err != nil {
+		s.logger.Error("Invalid match UUID format: ", err)
+		fieldErrors := map[string]string{"match_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	suspended, err := s.store.GetFootballSuspendedPlayers(ctx, matchPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get suspended players: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get suspended players",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    suspended,
+	})
+}
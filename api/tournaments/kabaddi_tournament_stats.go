@@ -0,0 +1,132 @@
+package tournaments
+
+import (
+	errorhandler "khelogames/error_handler"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func (s *TournamentServer) GetKabaddiTournamentRaidPointsFunc(ctx *gin.Context) {
+	var req struct {
+		TournamentPublicID string `uri:"tournament_public_id"`
+	}
+
+	err := ctx.ShouldBindUri(&req)
+	if err != nil {
+		s.logger.Error("Failed to bind: ", err)
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	tournamentPublicID, err := uuid.Parse(req.TournamentPublicID)
+	if err != nil {
+		s.logger.Error("Failed to parse tournament public ID: ", err)
+		fieldErrors := map[string]string{"tournament_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	stats, err := s.store.GetKabaddiTournamentRaidPoints(ctx, tournamentPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get kabaddi tournament raid points: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get kabaddi tournament raid points",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"data":    stats,
+	})
+}
+
+func (s *TournamentServer) GetKabaddiTournamentTacklePointsFunc(ctx *gin.Context) {
+	var req struct {
+		TournamentPublicID string `uri:"tournament_public_id"`
+	}
+
+	err := ctx.ShouldBindUri(&req)
+	if err != nil {
+		s.logger.Error("Failed to bind: ", err)
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	tournamentPublicID, err := uuid.Parse(req.TournamentPublicID)
+	if err != nil {
+		s.logger.Error("Failed to parse tournament public ID: ", err)
+		fieldErrors := map[string]string{"tournament_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	stats, err := s.store.GetKabaddiTournamentTacklePoints(ctx, tournamentPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get kabaddi tournament tackle points: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get kabaddi tournament tackle points",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"data":    stats,
+	})
+}
+
+func (s *TournamentServer) GetKabaddiTournamentSuperTacklesFunc(ctx *gin.Context) {
+	var req struct {
+		TournamentPublicID string `uri:"tournament_public_id"`
+	}
+
+	err := ctx.ShouldBindUri(&req)
+	if err != nil {
+		s.logger.Error("Failed to bind: ", err)
+		fieldErrors := errorhandler.ExtractValidationErrors(err)
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	tournamentPublicID, err := uuid.Parse(req.TournamentPublicID)
+	if err != nil {
+		s.logger.Error("Failed to parse tournament public ID: ", err)
+		fieldErrors := map[string]string{"tournament_public_id": "Invalid UUID format"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	stats, err := s.store.GetKabaddiTournamentSuperTackles(ctx, tournamentPublicID)
+	if err != nil {
+		s.logger.Error("Failed to get kabaddi tournament super tackles: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to get kabaddi tournament super tackles",
+			},
+			"request_id": ctx.GetString("request_id"),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"data":    stats,
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"khelogames/api/orchestrator"
 	"khelogames/api/shared"
+	"khelogames/api/sports"
 	"khelogames/core/token"
 	errorhandler "khelogames/error_handler"
 	"khelogames/pkg"
@@ -427,8 +428,18 @@ func (s *TournamentServer) UpdateMatchStatusFunc(ctx *gin.Context) {
 		return
 	}
 
-	updatedMatchData, err := s.txStore.UpdateMatchStatusTx(ctx, matchPublicID, req.StatusCode, *gameID)
+	sport, ok := sports.Lookup(gameID.Name)
+	if !ok {
+		fieldErrors := map[string]string{"sport": "Unsupported sport"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
+
+	updatedMatchData, err := s.txStore.UpdateMatchStatusTx(ctx, matchPublicID, req.StatusCode, sport)
 	if err != nil {
+		if errorhandler.FieldErrorResponse(ctx, err) {
+			return
+		}
 		s.logger.Error("Failed to update match status: ", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
@@ -1,6 +1,8 @@
 package tournaments
 
 import (
+	"errors"
+	"khelogames/api/sports"
 	errorhandler "khelogames/error_handler"
 	"net/http"
 
@@ -41,43 +43,33 @@ func (s *TournamentServer) CreateTournamentStandingFunc(ctx *gin.Context) {
 		return
 	}
 
-	game := ctx.Param("sport")
+	sport, ok := sports.Lookup(ctx.Param("sport"))
+	if !ok {
+		fieldErrors := map[string]string{"sport": "Unsupported sport"}
+		errorhandler.ValidationErrorResponse(ctx, fieldErrors)
+		return
+	}
 
-	if game == "football" {
-		footballStanding, err := s.store.CreateFootballStanding(ctx, tournamentPublicID, req.GroupID, teamPublicID)
-		if err != nil {
-			s.logger.Error("Failed to create football standing: ", err)
-			ctx.JSON(http.StatusInternalServerError, gin.H{
-				"success": false,
-				"error": gin.H{
-					"code":    "INTERNAL_ERROR",
-					"message": "Failed to create football standing",
-				},
-				"request_id": ctx.GetString("request_id"),
-			})
-			return
-		}
-		ctx.JSON(http.StatusAccepted, gin.H{
-			"success": true,
-			"data":    footballStanding,
-		})
-	} else if game == "cricket" {
-		cricketStanding, err := s.store.CreateCricketStanding(ctx, tournamentPublicID, req.GroupID, teamPublicID)
-		if err != nil {
-			s.logger.Error("Failed to create cricket standing: ", err)
-			ctx.JSON(http.StatusInternalServerError, gin.H{
-				"success": false,
-				"error": gin.H{
-					"code":    "INTERNAL_ERROR",
-					"message": "Failed to create cricket standing",
-				},
-				"request_id": ctx.GetString("request_id"),
-			})
+	standing, err := sport.CreateStanding(ctx, tournamentPublicID, req.GroupID, teamPublicID)
+	if err != nil {
+		if errors.Is(err, sports.ErrNoStandings) {
+			fieldErrors := map[string]string{"sport": "Standings are not kept for " + sport.Name()}
+			errorhandler.ValidationErrorResponse(ctx, fieldErrors)
 			return
 		}
-		ctx.JSON(http.StatusAccepted, gin.H{
-			"success": true,
-			"data":    cricketStanding,
+		s.logger.Error("Failed to create standing: ", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INTERNAL_ERROR",
+				"message": "Failed to create " + sport.Name() + " standing",
+			},
+			"request_id": ctx.GetString("request_id"),
 		})
+		return
 	}
+	ctx.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"data":    standing,
+	})
 }
@@ -0,0 +1,54 @@
+package transactions
+
+import (
+	"context"
+	"khelogames/database"
+	"khelogames/database/models"
+
+	badmintonhelper "khelogames/api/sports/badminton_helper"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/google/uuid"
+)
+
+// badmintonMatchRules returns the scoring rules of the match, taken from its own profile, then the
+// profile of its tournament, then the default rules
+func badmintonMatchRules(ctx context.Context, q *database.Queries, match *models.Match) (badmintonhelper.Rules, error) {
+	profile, err := q.GetBadmintonScoringProfile(ctx, int32(match.ID), match.TournamentID)
+	if err != nil {
+		return badmintonhelper.DefaultRules, err
+	}
+	return badmintonhelper.ProfileRules(profile), nil
+}
+
+// UpdateBadmintonMatchProfileTx sets the scoring format and handicaps of a match. The format
+// cannot change once the match has started, as the sets already played were scored by the old one.
+func (store *SQLStore) UpdateBadmintonMatchProfileTx(ctx context.Context, matchPublicID uuid.UUID, rules badmintonhelper.Rules) (*models.BadmintonScoringProfile, error) {
+	var profile *models.BadmintonScoringProfile
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		match, err := q.GetMatchModelByPublicId(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get match: ", err)
+			return err
+		}
+		if match.StatusCode == "in_progress" || match.StatusCode == "finished" {
+			return errorhandler.NewFieldError("match_public_id", "Scoring format cannot change once the match has started")
+		}
+
+		profile, err = q.UpsertBadmintonMatchProfile(ctx, int32(match.ID), database.BadmintonScoringProfileParams{
+			PointsPerGame: rules.PointsToWin,
+			WinBy:         rules.WinBy,
+			MaxPoints:     rules.MaxPoints,
+			GamesToWin:    rules.SetsToWin,
+			IntervalAt:    rules.IntervalAt,
+			HomeHandicap:  rules.HomeHandicap,
+			AwayHandicap:  rules.AwayHandicap,
+		})
+		if err != nil {
+			store.logger.Error("Failed to update badminton scoring profile: ", err)
+			return err
+		}
+		return nil
+	})
+	return profile, err
+}
@@ -0,0 +1,230 @@
+package transactions
+
+import (
+	"context"
+	"khelogames/database"
+	"khelogames/database/models"
+	"sort"
+
+	badmintonhelper "khelogames/api/sports/badminton_helper"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/google/uuid"
+)
+
+// badmintonSides returns the home and away sides of the match with their players in a stable order
+func badmintonSides(ctx context.Context, q *database.Queries, match *models.Match) (badmintonhelper.Side, badmintonhelper.Side, error) {
+	home := badmintonhelper.Side{TeamID: match.HomeTeamID}
+	away := badmintonhelper.Side{TeamID: match.AwayTeamID}
+
+	homePlayers, err := q.GetPlayerIDsByTeamID(ctx, match.HomeTeamID)
+	if err != nil {
+		return home, away, err
+	}
+	awayPlayers, err := q.GetPlayerIDsByTeamID(ctx, match.AwayTeamID)
+	if err != nil {
+		return home, away, err
+	}
+	sort.Slice(homePlayers, func(i, j int) bool { return homePlayers[i] < homePlayers[j] })
+	sort.Slice(awayPlayers, func(i, j int) bool { return awayPlayers[i] < awayPlayers[j] })
+
+	home.Players = homePlayers
+	away.Players = awayPlayers
+	return home, away, nil
+}
+
+// badmintonSetOpening returns the sides of a game, its opening service and its points in order.
+// The opening service is the one recorded by the umpire, or the default service without one.
+func badmintonSetOpening(ctx context.Context, q *database.Queries, match *models.Match, setNumber int) (badmintonhelper.Side, badmintonhelper.Side, badmintonhelper.Service, []models.BadmintonSetsPoints, error) {
+	var first badmintonhelper.Service
+	home, away, err := badmintonSides(ctx, q, match)
+	if err != nil {
+		return home, away, first, nil, err
+	}
+
+	service, err := q.GetBadmintonService(ctx, int32(match.ID), setNumber)
+	if err != nil {
+		return home, away, first, nil, err
+	}
+
+	if service != nil {
+		first = badmintonhelper.Service{
+			ServerTeamID:     service.ServerTeamID,
+			ServerPlayerID:   service.ServerPlayerID,
+			ReceiverTeamID:   service.ReceiverTeamID,
+			ReceiverPlayerID: service.ReceiverPlayerID,
+		}
+	} else {
+		sets, err := q.GetBadmintonMatchSetsScore(ctx, match.PublicID)
+		if err != nil {
+			return home, away, first, nil, err
+		}
+		var previousWinnerTeamID int32
+		for _, set := range sets {
+			if set.SetNumber != setNumber-1 {
+				continue
+			}
+			previousWinnerTeamID = match.AwayTeamID
+			if set.HomeScore > set.AwayScore {
+				previousWinnerTeamID = match.HomeTeamID
+			}
+		}
+		first = badmintonhelper.DefaultService(setNumber, home, away, previousWinnerTeamID)
+	}
+
+	points, err := q.GetBadmintonSetsPointsByTeam(ctx, int32(match.ID), setNumber)
+	if err != nil {
+		return home, away, first, nil, err
+	}
+	return home, away, first, points, nil
+}
+
+// badmintonRallyState replays the rallies of a game from its opening service
+func badmintonRallyState(ctx context.Context, q *database.Queries, match *models.Match, rules badmintonhelper.Rules, setNumber int) (*badmintonhelper.RallyState, error) {
+	home, away, first, points, err := badmintonSetOpening(ctx, q, match, setNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	scoringTeamIDs := make([]int32, 0, len(points))
+	for _, point := range points {
+		scoringTeamIDs = append(scoringTeamIDs, point.ScoringTeamID)
+	}
+
+	state := badmintonhelper.SetState(rules, setNumber, home, away, first, scoringTeamIDs)
+	return &state, nil
+}
+
+// UpdateBadmintonServiceTx records who serves and who receives the opening rally of a game, as
+// decided by the toss or the umpire. It can only be changed before the first rally of the game.
+func (store *SQLStore) UpdateBadmintonServiceTx(ctx context.Context, matchPublicID uuid.UUID, setNumber int, serverPublicID, receiverPublicID uuid.UUID) (*badmintonhelper.RallyState, error) {
+	var state *badmintonhelper.RallyState
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		match, err := q.GetMatchModelByPublicId(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get match: ", err)
+			return err
+		}
+		if match.StatusCode == "finished" {
+			return errorhandler.NewFieldError("match_public_id", "Match is already finished")
+		}
+
+		rules, err := badmintonMatchRules(ctx, q, match)
+		if err != nil {
+			store.logger.Error("Failed to get badminton scoring rules: ", err)
+			return err
+		}
+
+		set, err := q.GetBadmintonMatchSetScore(ctx, matchPublicID, setNumber)
+		if err != nil {
+			store.logger.Error("Failed to get badminton set: ", err)
+			return err
+		}
+		if set == nil {
+			return errorhandler.NewFieldError("set_number", "Set not found")
+		}
+		if set.SetStatus == "finished" || set.HomeScore+set.AwayScore > rules.HomeHandicap+rules.AwayHandicap {
+			return errorhandler.NewFieldError("set_number", "Service can only be chosen before the first rally of the set")
+		}
+
+		server, err := q.GetPlayerByPublicID(ctx, serverPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get server: ", err)
+			return err
+		}
+		if server == nil {
+			return errorhandler.NewFieldError("server_public_id", "Player not found")
+		}
+		receiver, err := q.GetPlayerByPublicID(ctx, receiverPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get receiver: ", err)
+			return err
+		}
+		if receiver == nil {
+			return errorhandler.NewFieldError("receiver_public_id", "Player not found")
+		}
+
+		home, away, err := badmintonSides(ctx, q, match)
+		if err != nil {
+			store.logger.Error("Failed to get badminton players: ", err)
+			return err
+		}
+
+		serverSide, receiverSide := home, away
+		switch {
+		case containsPlayer(home.Players, int32(server.ID)):
+		case containsPlayer(away.Players, int32(server.ID)):
+			serverSide, receiverSide = away, home
+		default:
+			return errorhandler.NewFieldError("server_public_id", "Server does not play for either team")
+		}
+		if !containsPlayer(receiverSide.Players, int32(receiver.ID)) {
+			return errorhandler.NewFieldError("receiver_public_id", "Receiver must play for the other team")
+		}
+
+		_, err = q.UpsertBadmintonService(ctx, database.UpsertBadmintonServiceParams{
+			MatchID:          int32(match.ID),
+			SetNumber:        setNumber,
+			ServerTeamID:     serverSide.TeamID,
+			ServerPlayerID:   int32(server.ID),
+			ReceiverTeamID:   receiverSide.TeamID,
+			ReceiverPlayerID: int32(receiver.ID),
+		})
+		if err != nil {
+			store.logger.Error("Failed to update badminton service: ", err)
+			return err
+		}
+
+		state, err = badmintonRallyState(ctx, q, match, rules, setNumber)
+		if err != nil {
+			store.logger.Error("Failed to get badminton rally state: ", err)
+			return err
+		}
+		return nil
+	})
+	return state, err
+}
+
+// GetBadmintonRallyStateTx returns the rally state of the latest game of the match
+func (store *SQLStore) GetBadmintonRallyStateTx(ctx context.Context, matchPublicID uuid.UUID) (*badmintonhelper.RallyState, error) {
+	var state *badmintonhelper.RallyState
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		match, err := q.GetMatchModelByPublicId(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get match: ", err)
+			return err
+		}
+
+		sets, err := q.GetBadmintonMatchSetsScore(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get badminton sets: ", err)
+			return err
+		}
+		if len(sets) == 0 {
+			return errorhandler.NewFieldError("match_public_id", "Badminton match has not started")
+		}
+
+		rules, err := badmintonMatchRules(ctx, q, match)
+		if err != nil {
+			store.logger.Error("Failed to get badminton scoring rules: ", err)
+			return err
+		}
+
+		state, err = badmintonRallyState(ctx, q, match, rules, sets[len(sets)-1].SetNumber)
+		if err != nil {
+			store.logger.Error("Failed to get badminton rally state: ", err)
+			return err
+		}
+		return nil
+	})
+	return state, err
+}
+
+func containsPlayer(players []int32, playerID int32) bool {
+	for _, id := range players {
+		if id == playerID {
+			return true
+		}
+	}
+	return false
+}
@@ -6,19 +6,20 @@ import (
 	"khelogames/database"
 	"khelogames/database/models"
 
+	badmintonhelper "khelogames/api/sports/badminton_helper"
+
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
-const badmintonSetsToWin = 2
-const badmintonMinScore = 21
-const badmintonMaxScore = 30
-
-func (store *SQLStore) UpdateBadmintonScoreTx(ctx *gin.Context, matchPublicID, teamPublicID uuid.UUID, setNumber int) (*models.Match, map[string]interface{}, *models.BadmintonSetsPoints, *models.BadmintonScore, error) {
+// UpdateBadmintonScoreTx adds a rally to the scoring team and returns the rally state of the game
+// after it, with the service of the next rally
+func (store *SQLStore) UpdateBadmintonScoreTx(ctx *gin.Context, matchPublicID, teamPublicID uuid.UUID, setNumber int) (*models.Match, map[string]interface{}, *models.BadmintonSetsPoints, *models.BadmintonScore, *badmintonhelper.RallyState, error) {
 	var badmintonSetScore map[string]interface{}
 	var matchResult *models.Match
 	var points *models.BadmintonSetsPoints
 	var newSet *models.BadmintonScore
+	var rallyState *badmintonhelper.RallyState
 
 	err := store.execTx(ctx, func(q *database.Queries) error {
 
@@ -31,6 +32,12 @@ func (store *SQLStore) UpdateBadmintonScoreTx(ctx *gin.Context, matchPublicID, t
 			return err
 		}
 
+		rules, err := badmintonMatchRules(ctx, q, match)
+		if err != nil {
+			store.logger.Error("failed to get badminton scoring rules: ", err)
+			return err
+		}
+
 		team, err := q.GetTeamByPublicID(ctx, teamPublicID)
 		if err != nil {
 			store.logger.Error("failed to get team: ", err)
@@ -59,8 +66,14 @@ func (store *SQLStore) UpdateBadmintonScoreTx(ctx *gin.Context, matchPublicID, t
 			}
 		}
 
+		rallyState, err = badmintonRallyState(ctx, q, match, rules, setNumber)
+		if err != nil {
+			store.logger.Error("failed to get badminton rally state: ", err)
+			return err
+		}
+
 		// Check if the current set is finished
-		setFinished := isBadmintonSetFinished(updatedScore.HomeScore, updatedScore.AwayScore)
+		setFinished := badmintonhelper.IsSetFinished(rules, updatedScore.HomeScore, updatedScore.AwayScore)
 		if !setFinished {
 			// Set still in progress — build response and return
 			badmintonSetScore = buildBadmintonSetScore(updatedScore, matchPublicID)
@@ -83,8 +96,8 @@ func (store *SQLStore) UpdateBadmintonScoreTx(ctx *gin.Context, matchPublicID, t
 			homeSetsWon := derefInt(matchScore.HomeSetsWon)
 			awaySetsWon := derefInt(matchScore.AwaySetsWon)
 
-			// Determine if match is decided (best of 3)
-			if homeSetsWon >= badmintonSetsToWin || awaySetsWon >= badmintonSetsToWin {
+			// Determine if match is decided
+			if homeSetsWon >= rules.SetsToWin || awaySetsWon >= rules.SetsToWin {
 				// Match is over — determine winner
 				var winnerTeamID int32
 				if homeSetsWon > awaySetsWon {
@@ -93,6 +106,9 @@ func (store *SQLStore) UpdateBadmintonScoreTx(ctx *gin.Context, matchPublicID, t
 					winnerTeamID = match.AwayTeamID
 				}
 
+				// No change of ends once the match is over
+				rallyState.EndChange = false
+
 				matchResult, err = q.UpdateMatchResult(ctx, int32(match.ID), winnerTeamID)
 				if err != nil {
 					store.logger.Error("failed to update match result: ", err)
@@ -100,18 +116,25 @@ func (store *SQLStore) UpdateBadmintonScoreTx(ctx *gin.Context, matchPublicID, t
 				}
 
 				// Update badminton player stats for both teams/player
-				if err := updateBadmintonStatsOnFinish(ctx, q, store, match, matchPublicID, winnerTeamID, homeSetsWon, awaySetsWon); err != nil {
+				if err := updateBadmintonStatsOnFinish(ctx, q, store, match, matchPublicID, rules, winnerTeamID, homeSetsWon, awaySetsWon); err != nil {
 					store.logger.Error("failed to update badminton player stats: ", err)
 					return err
 				}
 			} else {
 				// Match continues — create next set
 				nextSetNumber := setNumber + 1
-				newSet, err = q.AddBadmintonScore(ctx, int32(match.ID), nextSetNumber)
+				newSet, err = q.AddBadmintonScore(ctx, int32(match.ID), nextSetNumber, rules.HomeHandicap, rules.AwayHandicap)
 				if err != nil {
 					store.logger.Error("failed to add next set: ", err)
 					return err
 				}
+
+				nextState, err := badmintonRallyState(ctx, q, match, rules, nextSetNumber)
+				if err != nil {
+					store.logger.Error("failed to get next set rally state: ", err)
+					return err
+				}
+				rallyState.NextService = &nextState.Service
 			}
 		}
 
@@ -120,26 +143,7 @@ func (store *SQLStore) UpdateBadmintonScoreTx(ctx *gin.Context, matchPublicID, t
 		return nil
 	})
 
-	return matchResult, badmintonSetScore, points, newSet, err
-}
-
-// isBadmintonSetFinished checks if a badminton set is complete.
-// Standard rules: first to 21, win by 2, hard cap at 30.
-func isBadmintonSetFinished(homeScore, awayScore int) bool {
-	if homeScore == badmintonMaxScore || awayScore == badmintonMaxScore {
-		return true
-	}
-
-	diff := homeScore - awayScore
-	if diff < 0 {
-		diff = -diff
-	}
-
-	if (homeScore >= badmintonMinScore || awayScore >= badmintonMinScore) && diff >= 2 {
-		return true
-	}
-
-	return false
+	return matchResult, badmintonSetScore, points, newSet, rallyState, err
 }
 
 func buildBadmintonSetScore(score *models.BadmintonScore, matchPublicID uuid.UUID) map[string]interface{} {
@@ -162,6 +166,7 @@ func derefInt(p *int) int {
 }
 
 // updateBadmintonStatsOnFinish calculates and upserts player stats for each player in both teams when a match finishes.
+// Handicap start scores are not counted as points scored.
 // For singles: each team has 1 player → 1 stats upsert per side
 // For doubles: each team has 2 players → 2 stats upserts per side (each player gets individual stats)
 func updateBadmintonStatsOnFinish(
@@ -170,6 +175,7 @@ func updateBadmintonStatsOnFinish(
 	store *SQLStore,
 	match *models.Match,
 	matchPublicID uuid.UUID,
+	rules badmintonhelper.Rules,
 	winnerTeamID int32,
 	homeSetsWon, awaySetsWon int,
 ) error {
@@ -181,12 +187,12 @@ func updateBadmintonStatsOnFinish(
 
 	// Calculate total points from all sets
 	homePointsScored := 0
-	homePointsConceded := 0
+	awayPointsScored := 0
 	for _, set := range sets {
-		homePointsScored += set.HomeScore
-		homePointsConceded += set.AwayScore
+		homePointsScored += set.HomeScore - rules.HomeHandicap
+		awayPointsScored += set.AwayScore - rules.AwayHandicap
 	}
-	awayPointsScored := homePointsConceded
+	homePointsConceded := awayPointsScored
 	awayPointsConceded := homePointsScored
 
 	// Determine play_type from match type
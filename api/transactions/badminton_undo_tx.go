@@ -0,0 +1,210 @@
+package transactions
+
+import (
+	"context"
+	"khelogames/database"
+	"khelogames/database/models"
+
+	badmintonhelper "khelogames/api/sports/badminton_helper"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/google/uuid"
+)
+
+// rebuildBadmintonPlayerStats works the stats of the players of both teams out again from their
+// finished matches, as the stats written on finish only ever add a match
+func (store *SQLStore) rebuildBadmintonPlayerStats(ctx context.Context, q *database.Queries, match *models.Match) error {
+	doubles := match.Type == "double"
+	playType := "singles"
+	if doubles {
+		playType = "doubles"
+	}
+
+	for _, teamID := range []int32{match.HomeTeamID, match.AwayTeamID} {
+		playerIDs, err := q.GetPlayerIDsByTeamID(ctx, teamID)
+		if err != nil {
+			store.logger.Error("Failed to get team players: ", err)
+			return err
+		}
+
+		for _, playerID := range playerIDs {
+			results, err := q.GetBadmintonPlayerMatchResults(ctx, playerID, doubles)
+			if err != nil {
+				store.logger.Error("Failed to get badminton match results: ", err)
+				return err
+			}
+
+			stats := badmintonhelper.PlayerCareerStats(results)
+			err = q.SetBadmintonPlayerStats(ctx, database.SetBadmintonPlayerStatsParams{
+				PlayerID:       playerID,
+				PlayType:       playType,
+				Matches:        stats.Matches,
+				Wins:           stats.Wins,
+				Losses:         stats.Losses,
+				SetsWon:        stats.SetsWon,
+				SetsLost:       stats.SetsLost,
+				PointsScored:   stats.PointsScored,
+				PointsConceded: stats.PointsConceded,
+				WinPercentage:  stats.WinPercentage,
+				CurrentStreak:  stats.CurrentStreak,
+				BestStreak:     stats.BestStreak,
+			})
+			if err != nil {
+				store.logger.Error("Failed to set badminton player stats: ", err)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// UndoBadmintonPointTx removes the latest point of the match. When that point won the set the set
+// is reopened and the set it opened is removed; when it won the match the match goes back in
+// progress and the player stats are worked out again without it.
+func (store *SQLStore) UndoBadmintonPointTx(ctx context.Context, matchPublicID uuid.UUID) (*models.Match, map[string]interface{}, *models.BadmintonSetsPoints, *models.BadmintonScore, *badmintonhelper.RallyState, error) {
+	var matchResult *models.Match
+	var setScore map[string]interface{}
+	var point *models.BadmintonSetsPoints
+	var removedSet *models.BadmintonScore
+	var rallyState *badmintonhelper.RallyState
+
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		match, err := q.GetMatchModelByPublicId(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get match: ", err)
+			return err
+		}
+		if match.StatusCode != "in_progress" && match.StatusCode != "finished" {
+			return errorhandler.NewFieldError("match_public_id", "Match has not started")
+		}
+
+		rules, err := badmintonMatchRules(ctx, q, match)
+		if err != nil {
+			store.logger.Error("Failed to get badminton scoring rules: ", err)
+			return err
+		}
+
+		point, err = q.GetBadmintonLastPoint(ctx, int32(match.ID))
+		if err != nil {
+			store.logger.Error("Failed to get last badminton point: ", err)
+			return err
+		}
+		if point == nil {
+			return errorhandler.NewFieldError("match_public_id", "No point to undo")
+		}
+
+		sets, err := q.GetBadmintonMatchSetsScore(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get badminton sets: ", err)
+			return err
+		}
+		for _, set := range sets {
+			if set.SetNumber <= point.SetNumber {
+				continue
+			}
+			removedSet, err = q.DeleteBadmintonSet(ctx, int32(match.ID), set.SetNumber)
+			if err != nil {
+				store.logger.Error("Failed to remove badminton set: ", err)
+				return err
+			}
+		}
+
+		if match.StatusCode == "finished" {
+			matchResult, err = q.ReopenMatch(ctx, int32(match.ID))
+			if err != nil {
+				store.logger.Error("Failed to reopen match: ", err)
+				return err
+			}
+		}
+
+		err = q.DeleteBadmintonSetsPoint(ctx, point.ID)
+		if err != nil {
+			store.logger.Error("Failed to delete badminton point: ", err)
+			return err
+		}
+
+		updatedScore, err := q.RevertBadmintonSetScore(ctx, int32(match.ID), point.SetNumber, point.ScoringTeamID)
+		if err != nil {
+			store.logger.Error("Failed to revert badminton set score: ", err)
+			return err
+		}
+
+		if matchResult != nil {
+			if err := store.rebuildBadmintonPlayerStats(ctx, q, match); err != nil {
+				return err
+			}
+		}
+
+		rallyState, err = badmintonRallyState(ctx, q, match, rules, point.SetNumber)
+		if err != nil {
+			store.logger.Error("Failed to get badminton rally state: ", err)
+			return err
+		}
+
+		setScore = buildBadmintonSetScore(updatedScore, matchPublicID)
+		return nil
+	})
+
+	return matchResult, setScore, point, removedSet, rallyState, err
+}
+
+// GetBadmintonRalliesTx returns every rally of the match set by set, with who served it
+func (store *SQLStore) GetBadmintonRalliesTx(ctx context.Context, matchPublicID uuid.UUID) ([]map[string]interface{}, error) {
+	var sets []map[string]interface{}
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		match, err := q.GetMatchModelByPublicId(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get match: ", err)
+			return err
+		}
+
+		rules, err := badmintonMatchRules(ctx, q, match)
+		if err != nil {
+			store.logger.Error("Failed to get badminton scoring rules: ", err)
+			return err
+		}
+
+		scores, err := q.GetBadmintonMatchSetsScore(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get badminton sets: ", err)
+			return err
+		}
+
+		for _, score := range scores {
+			home, away, first, points, err := badmintonSetOpening(ctx, q, match, score.SetNumber)
+			if err != nil {
+				store.logger.Error("Failed to get badminton set: ", err)
+				return err
+			}
+
+			scoringTeamIDs := make([]int32, 0, len(points))
+			for _, point := range points {
+				scoringTeamIDs = append(scoringTeamIDs, point.ScoringTeamID)
+			}
+
+			rallies := make([]map[string]interface{}, 0, len(points))
+			for i, rally := range badmintonhelper.SetRallies(rules, score.SetNumber, home, away, first, scoringTeamIDs) {
+				rallies = append(rallies, map[string]interface{}{
+					"public_id":       points[i].PublicID,
+					"point_number":    points[i].PointNumber,
+					"scoring_team_id": rally.ScoringTeamID,
+					"home_score":      rally.HomeScore,
+					"away_score":      rally.AwayScore,
+					"service":         rally.Service,
+					"service_court":   rally.ServiceCourt,
+					"created_at":      points[i].CreatedAt,
+				})
+			}
+
+			sets = append(sets, map[string]interface{}{
+				"set_number": score.SetNumber,
+				"home_score": score.HomeScore,
+				"away_score": score.AwayScore,
+				"set_status": score.SetStatus,
+				"rallies":    rallies,
+			})
+		}
+		return nil
+	})
+	return sets, err
+}
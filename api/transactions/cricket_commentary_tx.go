@@ -0,0 +1,96 @@
+package transactions
+
+import (
+	"context"
+	"khelogames/database"
+	"khelogames/database/models"
+
+	crickethelper "khelogames/api/sports/cricket_helper"
+)
+
+// cricketPlayerName returns the display name of a player, empty when the player is not set
+func (store *SQLStore) cricketPlayerName(ctx context.Context, q *database.Queries, playerID *int32) (string, error) {
+	if playerID == nil || *playerID == 0 {
+		return "", nil
+	}
+	player, err := q.GetPlayerByID(ctx, int64(*playerID))
+	if err != nil {
+		store.logger.Error("Failed to get player: ", err)
+		return "", err
+	}
+	return player.Name, nil
+}
+
+// addCricketCommentary stores the commentary lines of a delivery: the ball itself, the end of
+// the over, a batsman reaching a milestone and a bowler's five-wicket haul
+func (store *SQLStore) addCricketCommentary(ctx context.Context, q *database.Queries, delivery *models.CricketDelivery, bowlerName string, batsmanScore *models.BatsmanScore, bowlerScore *models.BowlerScore, inningScore *models.CricketScore) error {
+	striker, err := store.cricketPlayerName(ctx, q, &delivery.StrikerID)
+	if err != nil {
+		return err
+	}
+	dismissed, err := store.cricketPlayerName(ctx, q, delivery.DismissedBatsmanID)
+	if err != nil {
+		return err
+	}
+	fielder, err := store.cricketPlayerName(ctx, q, delivery.FielderID)
+	if err != nil {
+		return err
+	}
+
+	lines := []crickethelper.CommentaryLine{
+		crickethelper.DeliveryLine(crickethelper.DeliveryCommentary{
+			OverNumber:       delivery.OverNumber,
+			BallNumber:       delivery.BallNumber,
+			Bowler:           bowlerName,
+			Striker:          striker,
+			Runs:             delivery.Runs,
+			ExtrasType:       delivery.ExtrasType,
+			ExtrasRuns:       delivery.ExtrasRuns,
+			IsWicket:         delivery.IsWicket,
+			WicketType:       delivery.WicketType,
+			DismissedBatsman: dismissed,
+			Fielder:          fielder,
+		}),
+	}
+
+	if batsmanScore != nil {
+		milestone := crickethelper.MilestoneLine(striker, batsmanScore.RunsScored-delivery.Runs, batsmanScore.RunsScored, batsmanScore.BallsFaced)
+		if milestone != nil {
+			lines = append(lines, *milestone)
+		}
+	}
+
+	if bowlerScore != nil {
+		bowlerWicket := delivery.IsWicket && delivery.WicketType != nil && database.IsBowlerCreditedWicket(*delivery.WicketType)
+		fiveWickets := crickethelper.FiveWicketLine(bowlerName, int(bowlerScore.Wickets), int(bowlerScore.Runs), bowlerWicket)
+		if fiveWickets != nil {
+			lines = append(lines, *fiveWickets)
+		}
+	}
+
+	if isOverCompleted(delivery, inningScore) {
+		overRuns, err := q.GetCricketOverRuns(ctx, delivery.MatchID, delivery.InningNumber, delivery.OverNumber)
+		if err != nil {
+			store.logger.Error("Failed to get over runs: ", err)
+			return err
+		}
+		lines = append(lines, crickethelper.OverEndLine(delivery.OverNumber, bowlerName, overRuns, inningScore.Score, inningScore.Wickets))
+	}
+
+	for _, line := range lines {
+		_, err := q.AddCricketCommentary(ctx, database.AddCricketCommentaryParams{
+			MatchID:      delivery.MatchID,
+			DeliveryID:   delivery.ID,
+			InningNumber: delivery.InningNumber,
+			OverNumber:   delivery.OverNumber,
+			BallNumber:   delivery.BallNumber,
+			EventType:    line.EventType,
+			Commentary:   line.Text,
+		})
+		if err != nil {
+			store.logger.Error("Failed to add commentary: ", err)
+			return err
+		}
+	}
+	return nil
+}
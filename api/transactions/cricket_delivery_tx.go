@@ -0,0 +1,483 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"khelogames/database"
+	"khelogames/database/models"
+
+	crickethelper "khelogames/api/sports/cricket_helper"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/google/uuid"
+)
+
+// addCricketDelivery stores a delivery against the current batting pair and applies it to the scorecard
+func (store *SQLStore) addCricketDelivery(ctx context.Context, q *database.Queries, matchPublicID, teamPublicID, bowlerPublicID uuid.UUID, arg database.AddCricketDeliveryParams) (*models.CricketDelivery, *models.BatsmanScore, *models.BowlerScore, *models.CricketScore, error) {
+	currentBatsman, err := q.GetCurrentBattingBatsman(ctx, matchPublicID, teamPublicID, arg.InningNumber)
+	if err != nil {
+		store.logger.Error("Failed to get current batsman: ", err)
+		return nil, nil, nil, nil, err
+	}
+
+	for _, batsman := range currentBatsman {
+		if batsman.IsStriker {
+			arg.StrikerID = batsman.BatsmanID
+		} else {
+			arg.NonStrikerID = batsman.BatsmanID
+		}
+		arg.MatchID = batsman.MatchID
+		arg.TeamID = batsman.TeamID
+	}
+	if arg.StrikerID == 0 || arg.NonStrikerID == 0 {
+		return nil, nil, nil, nil, fmt.Errorf("striker and non striker are not set for inning %d", arg.InningNumber)
+	}
+
+	bowler, err := q.GetPlayerByPublicID(ctx, bowlerPublicID)
+	if err != nil {
+		store.logger.Error("Failed to get bowler: ", err)
+		return nil, nil, nil, nil, err
+	}
+	arg.BowlerID = int32(bowler.ID)
+
+	match, err := q.GetMatchModelByPublicId(ctx, matchPublicID)
+	if err != nil {
+		store.logger.Error("Failed to get match: ", err)
+		return nil, nil, nil, nil, err
+	}
+
+	err = store.validateCricketBowler(ctx, q, match, arg.InningNumber, arg.BowlerID)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	inningScore, err := q.GetCricketScoreByInning(ctx, matchPublicID, teamPublicID, arg.InningNumber)
+	if err != nil {
+		store.logger.Error("Failed to get inning score: ", err)
+		return nil, nil, nil, nil, err
+	}
+	if inningScore == nil {
+		return nil, nil, nil, nil, fmt.Errorf("inning %d has not started", arg.InningNumber)
+	}
+	arg.OverNumber = inningScore.Overs / 6
+	arg.BallNumber = inningScore.Overs%6 + 1
+
+	delivery, err := q.AddCricketDelivery(ctx, arg)
+	if err != nil {
+		store.logger.Error("Failed to add delivery: ", err)
+		return nil, nil, nil, nil, err
+	}
+
+	batsmanScore, bowlerScore, updatedInningScore, err := q.ApplyCricketDelivery(ctx, *delivery, 1)
+	if err != nil {
+		store.logger.Error("Failed to apply delivery: ", err)
+		return nil, nil, nil, nil, err
+	}
+
+	err = store.addCricketCommentary(ctx, q, delivery, bowler.Name, batsmanScore, bowlerScore, updatedInningScore)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	return delivery, batsmanScore, bowlerScore, updatedInningScore, nil
+}
+
+// runsRan returns the runs the batsmen completed between the wickets on a delivery
+func runsRan(delivery *models.CricketDelivery) int {
+	if delivery.ExtrasType != nil {
+		switch *delivery.ExtrasType {
+		case "wide", "no_ball":
+			return delivery.Runs + delivery.ExtrasRuns - 1
+		}
+	}
+	return delivery.Runs + delivery.ExtrasRuns
+}
+
+// cricketInningOvers returns the overs allotted to the inning, 0 when the format has no limit.
+func cricketInningOvers(match *models.Match, revision *models.CricketRevisedTarget, inningNumber int) int {
+	return crickethelper.AllottedOvers(match.MatchFormat, revision, inningNumber)
+}
+
+// isCricketInningOver reports whether the inning is all out or its overs are bowled
+func isCricketInningOver(match *models.Match, inningScore *models.CricketScore, revision *models.CricketRevisedTarget) bool {
+	if inningScore.Wickets >= 10 {
+		return true
+	}
+	if cricketMaxOvers(match) > 0 && crickethelper.IsSuperOverInning(match.MatchFormat, inningScore.InningNumber) && inningScore.Wickets >= crickethelper.SuperOverWickets {
+		return true
+	}
+	maxOvers := cricketInningOvers(match, revision, inningScore.InningNumber)
+	if maxOvers == 0 {
+		return false
+	}
+	return inningScore.Overs >= maxOvers*6
+}
+
+// isOverCompleted reports whether the delivery was the sixth legal ball of its over
+func isOverCompleted(delivery *models.CricketDelivery, inningScore *models.CricketScore) bool {
+	return delivery.IsLegal && inningScore.Overs > 0 && inningScore.Overs%6 == 0
+}
+
+// shouldRotateStrike swaps the batsmen on odd runs and again at the end of the over
+func shouldRotateStrike(overCompleted bool, runs int) bool {
+	return (runs%2 != 0) != overCompleted
+}
+
+// validateCricketBowler rejects a bowler who bowled the over just completed or who
+// has used up their quota of overs for the inning
+func (store *SQLStore) validateCricketBowler(ctx context.Context, q *database.Queries, match *models.Match, inningNumber int, bowlerID int32) error {
+	inningBalls, bowlerBalls, err := q.CountCricketLegalBalls(ctx, int32(match.ID), inningNumber, bowlerID)
+	if err != nil {
+		store.logger.Error("Failed to count legal balls: ", err)
+		return err
+	}
+
+	if inningBalls > 0 && inningBalls%6 == 0 {
+		lastDelivery, err := q.GetLastLegalCricketDelivery(ctx, int32(match.ID), inningNumber)
+		if err != nil {
+			store.logger.Error("Failed to get last legal delivery: ", err)
+			return err
+		}
+		if lastDelivery != nil && lastDelivery.BowlerID == bowlerID {
+			return errorhandler.NewFieldError("bowler_public_id", "Bowler cannot bowl consecutive overs")
+		}
+	}
+
+	revision, err := q.GetCricketRevisedTarget(ctx, int32(match.ID))
+	if err != nil {
+		store.logger.Error("Failed to get revised target: ", err)
+		return err
+	}
+	inningOvers := cricketInningOvers(match, revision, inningNumber)
+	if inningOvers == 0 {
+		return nil
+	}
+
+	// a bowler may bowl a fifth of the overs of the inning, 4 in T20 and 10 in ODI
+	quota := (inningOvers + 4) / 5
+	if bowlerBalls >= quota*6 {
+		return errorhandler.NewFieldError("bowler_public_id", fmt.Sprintf("Bowler has completed the quota of %d overs", quota))
+	}
+	return nil
+}
+
+// checkNoCricketRetirementAfter rejects undoing or redoing a delivery when one of its batsmen has
+// retired since. Retirements are not deliveries, restoring the pair of the ball would put the
+// retired batsman back at the crease with the wicket still counted.
+func (store *SQLStore) checkNoCricketRetirementAfter(ctx context.Context, q *database.Queries, delivery *models.CricketDelivery) error {
+	retired, err := q.CountRetiredCricketBatsmen(ctx, delivery.MatchID, delivery.TeamID, delivery.InningNumber, delivery.StrikerID, delivery.NonStrikerID)
+	if err != nil {
+		store.logger.Error("Failed to count retired batsmen: ", err)
+		return err
+	}
+	if retired > 0 {
+		return errorhandler.NewFieldError("inning_number", "A batsman has retired since this delivery, it cannot be undone or redone")
+	}
+	return nil
+}
+
+// Undo the last delivery of the inning and reverse all of its effects
+func (store *SQLStore) UndoCricketDeliveryTx(ctx context.Context, matchPublicID, teamPublicID uuid.UUID, inningNumber int) (*models.CricketDelivery, []models.BatsmanScore, *models.BowlerScore, *models.CricketScore, error) {
+	var delivery *models.CricketDelivery
+	var currentBatsman []models.BatsmanScore
+	var currentBowler *models.BowlerScore
+	var inningScore *models.CricketScore
+
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		var err error
+
+		match, err := q.GetMatchModelByPublicId(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get match: ", err)
+			return err
+		}
+
+		delivery, err = q.GetLastCricketDelivery(ctx, int32(match.ID), inningNumber)
+		if err != nil {
+			store.logger.Error("Failed to get last delivery: ", err)
+			return err
+		}
+		if delivery == nil {
+			return nil
+		}
+		err = store.checkNoCricketRetirementAfter(ctx, q, delivery)
+		if err != nil {
+			return err
+		}
+
+		delivery, err = q.SetCricketDeliveryUndone(ctx, delivery.ID, true)
+		if err != nil {
+			store.logger.Error("Failed to mark delivery as undone: ", err)
+			return err
+		}
+
+		_, _, _, err = q.ApplyCricketDelivery(ctx, *delivery, -1)
+		if err != nil {
+			store.logger.Error("Failed to reverse delivery: ", err)
+			return err
+		}
+
+		if delivery.IsWicket {
+			err = q.DeleteCricketDeliveryWicket(ctx, *delivery)
+			if err != nil {
+				store.logger.Error("Failed to delete wicket: ", err)
+				return err
+			}
+
+			err = q.DeleteIncomingCricketBatsman(ctx, delivery.MatchID, delivery.TeamID, inningNumber, delivery.StrikerID, delivery.NonStrikerID)
+			if err != nil {
+				store.logger.Error("Failed to remove incoming batsman: ", err)
+				return err
+			}
+		}
+
+		err = q.ReopenCricketInning(ctx, delivery.MatchID, delivery.TeamID, inningNumber)
+		if err != nil {
+			store.logger.Error("Failed to reopen inning: ", err)
+			return err
+		}
+
+		currentBatsman, err = q.RestoreCricketBattingPair(ctx, delivery.MatchID, delivery.TeamID, inningNumber, delivery.StrikerID, delivery.NonStrikerID)
+		if err != nil {
+			store.logger.Error("Failed to restore batting pair: ", err)
+			return err
+		}
+
+		currentBowler, err = q.SetCricketCurrentBowler(ctx, delivery.MatchID, inningNumber, delivery.BowlerID)
+		if err != nil {
+			store.logger.Error("Failed to restore current bowler: ", err)
+			return err
+		}
+
+		inningScore, err = q.GetCricketScoreByInning(ctx, matchPublicID, teamPublicID, inningNumber)
+		if err != nil {
+			store.logger.Error("Failed to get inning score: ", err)
+			return err
+		}
+		return nil
+	})
+	return delivery, currentBatsman, currentBowler, inningScore, err
+}
+
+// Redo the earliest undone delivery of the inning
+func (store *SQLStore) RedoCricketDeliveryTx(ctx context.Context, matchPublicID, teamPublicID uuid.UUID, inningNumber int) (*models.CricketDelivery, []models.BatsmanScore, *models.BowlerScore, *models.CricketScore, error) {
+	var delivery *models.CricketDelivery
+	var currentBatsman []models.BatsmanScore
+	var currentBowler *models.BowlerScore
+	var inningScore *models.CricketScore
+
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		var err error
+
+		match, err := q.GetMatchModelByPublicId(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get match: ", err)
+			return err
+		}
+
+		delivery, err = q.GetNextUndoneCricketDelivery(ctx, int32(match.ID), inningNumber)
+		if err != nil {
+			store.logger.Error("Failed to get undone delivery: ", err)
+			return err
+		}
+		if delivery == nil {
+			return nil
+		}
+		err = store.checkNoCricketRetirementAfter(ctx, q, delivery)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.RestoreCricketBattingPair(ctx, delivery.MatchID, delivery.TeamID, inningNumber, delivery.StrikerID, delivery.NonStrikerID)
+		if err != nil {
+			store.logger.Error("Failed to restore batting pair: ", err)
+			return err
+		}
+
+		delivery, err = q.SetCricketDeliveryUndone(ctx, delivery.ID, false)
+		if err != nil {
+			store.logger.Error("Failed to mark delivery as redone: ", err)
+			return err
+		}
+
+		_, _, updatedInningScore, err := q.ApplyCricketDelivery(ctx, *delivery, 1)
+		if err != nil {
+			store.logger.Error("Failed to apply delivery: ", err)
+			return err
+		}
+		inningScore = updatedInningScore
+
+		currentBowler, err = q.SetCricketCurrentBowler(ctx, delivery.MatchID, inningNumber, delivery.BowlerID)
+		if err != nil {
+			store.logger.Error("Failed to restore current bowler: ", err)
+			return err
+		}
+
+		if delivery.IsWicket {
+			_, err = q.AddCricketDeliveryWicket(ctx, *delivery, *inningScore)
+			if err != nil {
+				store.logger.Error("Failed to add wicket: ", err)
+				return err
+			}
+
+			_, err = q.DismissCricketBatsman(ctx, delivery.MatchID, delivery.TeamID, inningNumber, *delivery.DismissedBatsmanID)
+			if err != nil {
+				store.logger.Error("Failed to dismiss batsman: ", err)
+				return err
+			}
+
+			// only the over change is replayed, the new batsman takes strike as set by the scorer
+			if isOverCompleted(delivery, inningScore) {
+				_, err = q.ToggleCricketStricker(ctx, matchPublicID, inningNumber)
+				if err != nil {
+					store.logger.Error("Failed to update stricker: ", err)
+					return err
+				}
+			}
+		} else if shouldRotateStrike(isOverCompleted(delivery, inningScore), runsRan(delivery)) {
+			_, err = q.ToggleCricketStricker(ctx, matchPublicID, inningNumber)
+			if err != nil {
+				store.logger.Error("Failed to update stricker: ", err)
+				return err
+			}
+		}
+
+		revision, err := q.GetCricketRevisedTarget(ctx, int32(match.ID))
+		if err != nil {
+			store.logger.Error("Failed to get revised target: ", err)
+			return err
+		}
+		if isCricketInningOver(match, inningScore, revision) {
+			inningScore, _, currentBowler, err = q.UpdateInningEndStatus(ctx, delivery.MatchID, delivery.TeamID, inningNumber)
+			if err != nil {
+				store.logger.Error("Failed to update inning end status: ", err)
+				return err
+			}
+		}
+
+		currentBatsman, err = q.GetCricketCurrentBatsmen(ctx, delivery.MatchID, delivery.TeamID, inningNumber)
+		if err != nil {
+			store.logger.Error("Failed to get current batsman: ", err)
+			return err
+		}
+		return nil
+	})
+	return delivery, currentBatsman, currentBowler, inningScore, err
+}
+
+// Add byes or leg byes to the batting team
+func (store *SQLStore) AddCricketExtrasTx(ctx context.Context, matchPublicID, battingTeamPublicID, bowlerPublicID uuid.UUID, extrasType string, runsScored int32, inningNumber int) (*models.BatsmanScore, []models.BatsmanScore, *models.BowlerScore, *models.CricketScore, error) {
+	var batsmanScore *models.BatsmanScore
+	var currentBatsman []models.BatsmanScore
+	var bowlerScore *models.BowlerScore
+	var inningScore *models.CricketScore
+
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		var err error
+
+		arg := database.AddCricketDeliveryParams{
+			InningNumber: inningNumber,
+			Runs:         0,
+			ExtrasType:   &extrasType,
+			ExtrasRuns:   int(runsScored),
+			IsLegal:      true,
+		}
+		var delivery *models.CricketDelivery
+		delivery, batsmanScore, bowlerScore, inningScore, err = store.addCricketDelivery(ctx, q, matchPublicID, battingTeamPublicID, bowlerPublicID, arg)
+		if err != nil {
+			store.logger.Error("Failed to add extras: ", err)
+			return err
+		}
+
+		if shouldRotateStrike(isOverCompleted(delivery, inningScore), runsRan(delivery)) {
+			currentBatsman, err = q.ToggleCricketStricker(ctx, matchPublicID, inningNumber)
+			if err != nil {
+				store.logger.Error("Failed to update stricker: ", err)
+				return err
+			}
+		} else {
+			currentBatsman, err = q.GetCricketCurrentBatsmen(ctx, delivery.MatchID, delivery.TeamID, inningNumber)
+			if err != nil {
+				store.logger.Error("Failed to get current batsman: ", err)
+				return err
+			}
+		}
+
+		scores := []models.CricketScore{*inningScore}
+		err = q.AttachCricketExtras(ctx, delivery.MatchID, scores)
+		if err != nil {
+			store.logger.Error("Failed to get inning extras: ", err)
+			return err
+		}
+		inningScore = &scores[0]
+		return nil
+	})
+	return batsmanScore, currentBatsman, bowlerScore, inningScore, err
+}
+
+// cricketPenaltyRuns are the runs of a penalty awarded by the umpires
+const cricketPenaltyRuns = 5
+
+// AddCricketPenaltyRunsTx awards five penalty runs to either team of the match. They are added to the
+// latest inning of the team, or to its next inning when it has not batted yet, without a delivery.
+// Returns the penalty and the inning score it was added to, nil when it waits for the next inning.
+func (store *SQLStore) AddCricketPenaltyRunsTx(ctx context.Context, matchPublicID, awardedToTeamPublicID uuid.UUID) (*models.CricketPenaltyRuns, *models.CricketScore, error) {
+	var penalty *models.CricketPenaltyRuns
+	var inningScore *models.CricketScore
+
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		match, err := q.GetMatchModelByPublicId(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get match: ", err)
+			return err
+		}
+
+		team, err := q.GetTeamByPublicID(ctx, awardedToTeamPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get team: ", err)
+			return err
+		}
+		teamID := int32(team.ID)
+		if teamID != match.HomeTeamID && teamID != match.AwayTeamID {
+			return errorhandler.NewFieldError("awarded_to_team_public_id", "Team is not playing this match")
+		}
+
+		scores, err := q.GetCricketScores(ctx, int32(match.ID))
+		if err != nil {
+			store.logger.Error("Failed to get inning scores: ", err)
+			return err
+		}
+
+		var inningNumber *int
+		for i := range scores {
+			if scores[i].TeamID == teamID && (inningNumber == nil || scores[i].InningNumber > *inningNumber) {
+				inningNumber = &scores[i].InningNumber
+			}
+		}
+
+		penalty, err = q.AddCricketPenaltyRuns(ctx, int32(match.ID), teamID, inningNumber, cricketPenaltyRuns)
+		if err != nil {
+			store.logger.Error("Failed to add penalty runs: ", err)
+			return err
+		}
+		if inningNumber == nil {
+			return nil
+		}
+
+		inningScore, err = q.AddCricketInningPenaltyRuns(ctx, int32(match.ID), teamID, *inningNumber, cricketPenaltyRuns)
+		if err != nil {
+			store.logger.Error("Failed to add penalty runs to the inning: ", err)
+			return err
+		}
+
+		scores = []models.CricketScore{*inningScore}
+		err = q.AttachCricketExtras(ctx, int32(match.ID), scores)
+		if err != nil {
+			store.logger.Error("Failed to get inning extras: ", err)
+			return err
+		}
+		inningScore = &scores[0]
+		return nil
+	})
+	return penalty, inningScore, err
+}
@@ -0,0 +1,134 @@
+package transactions
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"khelogames/database"
+	"khelogames/database/models"
+
+	crickethelper "khelogames/api/sports/cricket_helper"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/google/uuid"
+)
+
+// Declare the running inning of a multi day match closed
+func (store *SQLStore) DeclareCricketInningTx(ctx context.Context, matchPublicID, teamPublicID uuid.UUID, inningNumber int) (*models.CricketScore, *models.BatsmanScore, *models.BowlerScore, error) {
+	var inningScore *models.CricketScore
+	var batsmanScore *models.BatsmanScore
+	var bowlerScore *models.BowlerScore
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		match, err := q.GetMatchModelByPublicId(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get match: ", err)
+			return err
+		}
+		if !crickethelper.IsMultiDayFormat(match.MatchFormat) {
+			return errorhandler.NewFieldError("match_public_id", "Innings can only be declared in a multi day match")
+		}
+
+		team, err := q.GetTeamByPublicID(ctx, teamPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get team: ", err)
+			return err
+		}
+
+		err = q.DeclareCricketInning(ctx, int32(match.ID), int32(team.ID), inningNumber)
+		if errors.Is(err, sql.ErrNoRows) {
+			return errorhandler.NewFieldError("inning_number", "Inning is not in progress")
+		}
+		if err != nil {
+			store.logger.Error("Failed to declare inning: ", err)
+			return err
+		}
+
+		inningScore, batsmanScore, bowlerScore, err = q.UpdateInningEndStatus(ctx, int32(match.ID), int32(team.ID), inningNumber)
+		if err != nil {
+			store.logger.Error("Failed to update inning end status: ", err)
+			return err
+		}
+		if inningScore == nil {
+			// no batsman at the crease, the inning score is read on its own
+			inningScore, err = q.GetCricketScoreByInning(ctx, matchPublicID, teamPublicID, inningNumber)
+			if err != nil {
+				store.logger.Error("Failed to get inning score: ", err)
+				return err
+			}
+		}
+		return nil
+	})
+	return inningScore, batsmanScore, bowlerScore, err
+}
+
+// Record a session break or the resumption of play. Stumps on the last scheduled day
+// ends the match, drawn unless a side has already won.
+func (store *SQLStore) UpdateCricketSessionTx(ctx context.Context, matchPublicID uuid.UUID, session string) (*models.Match, *crickethelper.MatchResult, error) {
+	var match *models.Match
+	var result *crickethelper.MatchResult
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		var err error
+		match, err = q.GetMatchModelByPublicId(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get match: ", err)
+			return err
+		}
+		if match.StatusCode != "in_progress" {
+			return errorhandler.NewFieldError("match_public_id", "Match is not in progress")
+		}
+
+		dayNumber := 1
+		if match.DayNumber != nil && *match.DayNumber > 0 {
+			dayNumber = *match.DayNumber
+		}
+		multiDay := crickethelper.IsMultiDayFormat(match.MatchFormat)
+
+		var subStatus *string
+		switch session {
+		case "play":
+			if multiDay && match.SubStatus != nil && *match.SubStatus == "stumps" {
+				dayNumber++
+			}
+		case "stumps":
+			if !multiDay {
+				return errorhandler.NewFieldError("session", "Stumps only applies to a multi day match")
+			}
+			if dayNumber >= crickethelper.MatchDays(match.MatchFormat) {
+				inningScores, err := q.GetCricketScores(ctx, int32(match.ID))
+				if err != nil {
+					store.logger.Error("Failed to get inning scores: ", err)
+					return err
+				}
+				decided := crickethelper.DecideMultiDayMatch(crickethelper.InningSummaries(inningScores), true)
+				result = &decided
+				match, err = q.UpdateMatchResult(ctx, int32(match.ID), decided.WinnerTeamID)
+				if err != nil {
+					store.logger.Error("Failed to update match result: ", err)
+					return err
+				}
+				_, err = q.UpdateCricketStanding(ctx, int32(match.TournamentID), int32(match.AwayTeamID))
+				if err != nil {
+					store.logger.Error("Failed to update tournament standing: ", err)
+					return err
+				}
+				_, err = q.UpdateCricketStanding(ctx, int32(match.TournamentID), int32(match.HomeTeamID))
+				if err != nil {
+					store.logger.Error("Failed to update tournament standing: ", err)
+					return err
+				}
+				return nil
+			}
+			subStatus = &session
+		default:
+			subStatus = &session
+		}
+
+		match, err = q.UpdateCricketMatchDay(ctx, match.ID, dayNumber, subStatus)
+		if err != nil {
+			store.logger.Error("Failed to update match day: ", err)
+			return err
+		}
+		return nil
+	})
+	return match, result, err
+}
@@ -0,0 +1,162 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"khelogames/database"
+	"khelogames/database/models"
+
+	crickethelper "khelogames/api/sports/cricket_helper"
+
+	"github.com/google/uuid"
+)
+
+// cricketMaxOvers returns the overs per side of a limited overs match, 0 for other formats
+func cricketMaxOvers(match *models.Match) int {
+	return crickethelper.MaxOvers(match.MatchFormat)
+}
+
+// refreshCricketRevisedTarget recomputes the revised target and par score of the match
+// from its interruptions, nil is returned when no overs were lost
+func (store *SQLStore) refreshCricketRevisedTarget(ctx context.Context, q *database.Queries, match *models.Match) (*models.CricketRevisedTarget, error) {
+	maxOvers := cricketMaxOvers(match)
+	if maxOvers == 0 {
+		return nil, nil
+	}
+
+	interruptions, err := q.GetCricketInterruptions(ctx, int32(match.ID))
+	if err != nil {
+		store.logger.Error("Failed to get interruptions: ", err)
+		return nil, err
+	}
+	if len(interruptions) == 0 {
+		return nil, nil
+	}
+
+	inningScores, err := q.GetCricketScores(ctx, int32(match.ID))
+	if err != nil {
+		store.logger.Error("Failed to get inning scores: ", err)
+		return nil, err
+	}
+
+	var firstInning, secondInning *models.CricketScore
+	for i := range inningScores {
+		switch inningScores[i].InningNumber {
+		case 1:
+			firstInning = &inningScores[i]
+		case 2:
+			secondInning = &inningScores[i]
+		}
+	}
+
+	input := crickethelper.DLSInput{MaxOvers: maxOvers, G50: crickethelper.FormatG50(match.MatchFormat)}
+	for _, interruption := range interruptions {
+		input.Interruptions = append(input.Interruptions, crickethelper.Interruption{
+			InningNumber: interruption.InningNumber,
+			BallsBowled:  interruption.BallsBowled,
+			Wickets:      interruption.Wickets,
+			OversLost:    interruption.OversLost,
+		})
+	}
+	if firstInning != nil {
+		input.FirstInningScore = firstInning.Score
+	}
+
+	table := crickethelper.DefaultResourceTable()
+	result := table.RevisedTarget(input)
+
+	// the target is only known once the first inning is over
+	arg := database.UpsertCricketRevisedTargetParams{
+		MatchID:               int32(match.ID),
+		FirstInningOvers:      result.FirstInningOvers,
+		SecondInningOvers:     result.SecondInningOvers,
+		FirstInningResources:  result.FirstInningResources,
+		SecondInningResources: result.SecondInningResources,
+	}
+	if firstInning != nil && firstInning.IsInningCompleted {
+		arg.RevisedTarget = result.RevisedTarget
+		if secondInning != nil {
+			arg.ParScore = table.ParScore(result, secondInning.Overs, secondInning.Wickets)
+		}
+	}
+
+	revision, err := q.UpsertCricketRevisedTarget(ctx, arg)
+	if err != nil {
+		store.logger.Error("Failed to update revised target: ", err)
+		return nil, err
+	}
+	return revision, nil
+}
+
+// Record overs lost to an interruption and revise the target of the match
+func (store *SQLStore) AddCricketInterruptionTx(ctx context.Context, matchPublicID, teamPublicID uuid.UUID, inningNumber int, oversLost int) (*models.CricketRevisedTarget, *models.CricketScore, error) {
+	var revision *models.CricketRevisedTarget
+	var inningScore *models.CricketScore
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		match, err := q.GetMatchModelByPublicId(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get match: ", err)
+			return err
+		}
+		if cricketMaxOvers(match) == 0 {
+			return fmt.Errorf("revised targets only apply to limited overs matches")
+		}
+
+		inningScore, err = q.GetCricketScoreByInning(ctx, matchPublicID, teamPublicID, inningNumber)
+		if err != nil {
+			store.logger.Error("Failed to get inning score: ", err)
+			return err
+		}
+		if inningScore == nil {
+			return fmt.Errorf("inning %d has not started", inningNumber)
+		}
+
+		_, err = q.AddCricketInterruption(ctx, database.AddCricketInterruptionParams{
+			MatchID:      int32(match.ID),
+			TeamID:       inningScore.TeamID,
+			InningNumber: inningNumber,
+			BallsBowled:  inningScore.Overs,
+			Wickets:      inningScore.Wickets,
+			OversLost:    oversLost,
+		})
+		if err != nil {
+			store.logger.Error("Failed to add interruption: ", err)
+			return err
+		}
+
+		revision, err = store.refreshCricketRevisedTarget(ctx, q, match)
+		if err != nil {
+			return err
+		}
+
+		// the inning ends at the interruption when its remaining overs were lost
+		if !inningScore.IsInningCompleted && isCricketInningOver(match, inningScore, revision) {
+			inningScore, _, _, err = q.UpdateInningEndStatus(ctx, int32(match.ID), inningScore.TeamID, inningNumber)
+			if err != nil {
+				store.logger.Error("Failed to update inning end status: ", err)
+				return err
+			}
+			revision, err = store.refreshCricketRevisedTarget(ctx, q, match)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return revision, inningScore, err
+}
+
+// Recompute the revised target once an inning of a shortened match changes state
+func (store *SQLStore) RefreshCricketRevisedTargetTx(ctx context.Context, matchPublicID uuid.UUID) (*models.CricketRevisedTarget, error) {
+	var revision *models.CricketRevisedTarget
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		match, err := q.GetMatchModelByPublicId(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get match: ", err)
+			return err
+		}
+		revision, err = store.refreshCricketRevisedTarget(ctx, q, match)
+		return err
+	})
+	return revision, err
+}
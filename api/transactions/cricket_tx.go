@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"khelogames/database"
 	"khelogames/database/models"
+	"strings"
+
+	errorhandler "khelogames/error_handler"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -79,7 +82,10 @@ func (store *SQLStore) AddCricketBlowerTx(ctx context.Context,
 	var currentBowlerResponse models.BowlerScore
 	var prevBowler map[string]interface{}
 	err := store.execTx(ctx, func(q *database.Queries) error {
-		var err error
+		err := store.validateNextCricketBowler(ctx, q, matchPublicID, bowlerPublicID, inningNumber)
+		if err != nil {
+			return err
+		}
 
 		if prevBowlerPublicID != prevBowlerID {
 			prevBowlerResponse, err := q.UpdateBowlingBowlerStatus(ctx, matchPublicID, bowlerPublicID, prevBowlerPublicID, inningNumber)
@@ -141,19 +147,23 @@ func (store *SQLStore) UpdateCricketNoBallTx(ctx context.Context, matchPublicID,
 	err := store.execTx(ctx, func(q *database.Queries) error {
 		var err error
 
-		// Create user
-		batsmanScore, bowlerScore, inningScore, err = q.UpdateNoBallsRuns(ctx, matchPublicID, battingTeamPublicID, bowlerPublicID, runsScored, inningNumber)
+		extrasType := "no_ball"
+		arg := database.AddCricketDeliveryParams{
+			InningNumber: inningNumber,
+			Runs:         int(runsScored),
+			ExtrasType:   &extrasType,
+			ExtrasRuns:   1,
+			IsLegal:      false,
+		}
+
+		var delivery *models.CricketDelivery
+		delivery, batsmanScore, bowlerScore, inningScore, err = store.addCricketDelivery(ctx, q, matchPublicID, battingTeamPublicID, bowlerPublicID, arg)
 		if err != nil {
 			store.logger.Error("Failed to update no_ball: ", err)
 			return err
 		}
 
-		if bowlerScore.BallNumber%6 == 0 && runsScored%2 == 0 {
-			currentBatsman, err = q.ToggleCricketStricker(ctx, matchPublicID, inningNumber)
-			if err != nil {
-				store.logger.Error("Failed to update stricker: ", err)
-			}
-		} else if bowlerScore.BallNumber%6 != 0 && runsScored%2 != 0 {
+		if shouldRotateStrike(isOverCompleted(delivery, inningScore), int(runsScored)) {
 			currentBatsman, err = q.ToggleCricketStricker(ctx, matchPublicID, inningNumber)
 			if err != nil {
 				store.logger.Error("Failed to update stricker: ", err)
@@ -173,27 +183,75 @@ func (store *SQLStore) UpdateWideRunsTx(ctx context.Context, matchPublicID, bowl
 	err := store.execTx(ctx, func(q *database.Queries) error {
 		var err error
 
-		// Create user
-		batsmanScore, bowlerScore, inningScore, err = q.UpdateWideRuns(ctx, matchPublicID, battingTeamPublicID, bowlerPublicID, runsScored, inningNumber)
+		extrasType := "wide"
+		arg := database.AddCricketDeliveryParams{
+			InningNumber: inningNumber,
+			Runs:         0,
+			ExtrasType:   &extrasType,
+			ExtrasRuns:   int(runsScored) + 1,
+			IsLegal:      false,
+		}
+
+		var delivery *models.CricketDelivery
+		delivery, batsmanScore, bowlerScore, inningScore, err = store.addCricketDelivery(ctx, q, matchPublicID, battingTeamPublicID, bowlerPublicID, arg)
 		if err != nil {
 			return fmt.Errorf("Failed to update wide runs: %w", err)
 		}
 
-		if bowlerScore.BallNumber%6 == 0 && runsScored%2 == 0 {
+		if shouldRotateStrike(isOverCompleted(delivery, inningScore), int(runsScored)) {
 			currentBatsman, err = q.ToggleCricketStricker(ctx, matchPublicID, inningNumber)
 			if err != nil {
 				store.logger.Error("Failed to update stricker: ", err)
 			}
-		} else if bowlerScore.BallNumber%6 != 0 && runsScored%2 != 0 {
+		}
+
+		return err
+	})
+	if err != nil {
+		return models.BatsmanScore{}, nil, models.BowlerScore{}, models.CricketScore{}, err
+	}
+	return *batsmanScore, currentBatsman, *bowlerScore, *inningScore, err
+}
+
+// Update inning score for the runs scored off the bat
+func (store *SQLStore) UpdateInningScoreTx(ctx context.Context, matchPublicID, batsmanTeamPublicID, bowlerPublicID uuid.UUID, runsScored int32, inningNumber int) (*models.BatsmanScore, []models.BatsmanScore, *models.BowlerScore, *models.CricketScore, error) {
+	var bowlerScore *models.BowlerScore
+	var inningScore *models.CricketScore
+	var batsmanScore *models.BatsmanScore
+	var currentBatsman []models.BatsmanScore
+
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		var err error
+
+		arg := database.AddCricketDeliveryParams{
+			InningNumber: inningNumber,
+			Runs:         int(runsScored),
+			IsLegal:      true,
+		}
+
+		var delivery *models.CricketDelivery
+		delivery, batsmanScore, bowlerScore, inningScore, err = store.addCricketDelivery(ctx, q, matchPublicID, batsmanTeamPublicID, bowlerPublicID, arg)
+		if err != nil {
+			store.logger.Error("Failed to update inning score: ", err)
+			return err
+		}
+
+		if shouldRotateStrike(isOverCompleted(delivery, inningScore), int(runsScored)) {
 			currentBatsman, err = q.ToggleCricketStricker(ctx, matchPublicID, inningNumber)
 			if err != nil {
 				store.logger.Error("Failed to update stricker: ", err)
+				return err
+			}
+		} else {
+			currentBatsman, err = q.GetCurrentBattingBatsman(ctx, matchPublicID, batsmanTeamPublicID, inningNumber)
+			if err != nil {
+				store.logger.Error("Failed to get current batsman: ", err)
+				return err
 			}
 		}
-
 		return err
 	})
-	return *batsmanScore, currentBatsman, *bowlerScore, *inningScore, err
+	return batsmanScore, currentBatsman, bowlerScore, inningScore, err
 }
 
 func (store *SQLStore) UpdateCricketEndInningTx(ctx context.Context, matchID, batsmanTeamID int32, inningNumber int) (*models.CricketScore, *models.BatsmanScore, *models.BowlerScore, error) {
@@ -218,7 +276,10 @@ func (store *SQLStore) AddCricketBallTx(ctx context.Context, matchPublicID, team
 	var currentbowler models.BowlerScore
 	var prevBowler *models.BowlerScore
 	err := store.execTx(ctx, func(q *database.Queries) error {
-		var err error
+		err := store.validateNextCricketBowler(ctx, q, matchPublicID, bowlerPublicID, inningNumber)
+		if err != nil {
+			return err
+		}
 
 		prevBowlerPublicIDString := prevBowlerPulbicID.String()
 		var prevBowlerEmptyString string
@@ -255,12 +316,9 @@ func (store *SQLStore) AddCricketBallTx(ctx context.Context, matchPublicID, team
 // Add cricket wicket
 func (store *SQLStore) AddCricketWicketTx(ctx context.Context,
 	matchPublicID, battingTeamID, batsmanPublicID, bowlerPublicID uuid.UUID,
-	wicketNumber int,
 	wicketType string,
-	ballNumber int,
 	fielderPublicID uuid.UUID,
-	inningScore models.CricketScore,
-	bowlType *string,
+	bowlType string,
 	runsScored int32,
 	inningNumber int,
 	toggleStriker bool,
@@ -273,17 +331,104 @@ func (store *SQLStore) AddCricketWicketTx(ctx context.Context,
 	var wicketResponse *models.Wicket
 	err := store.execTx(ctx, func(q *database.Queries) error {
 		var err error
-		if bowlType != nil {
-			outBatsmanResponse, notOutBatsmanResponse, bowlerResponse, inningScoreResponse, wicketResponse, err = q.AddCricketWicketWithBowlType(ctx, matchPublicID, battingTeamID, batsmanPublicID, bowlerPublicID, wicketNumber, wicketType, ballNumber, &fielderPublicID, inningScore.Score, *bowlType, inningNumber)
-			if err != nil {
-				store.logger.Error("failed to add cricket wicket with bowl type: ", err)
-			}
-		} else {
-			outBatsmanResponse, notOutBatsmanResponse, bowlerResponse, inningScoreResponse, wicketResponse, err = q.AddCricketWicket(ctx, matchPublicID, battingTeamID, batsmanPublicID, bowlerPublicID, int(inningScore.Wickets), wicketType, int(inningScore.Overs), fielderPublicID, inningScore.Score, runsScored, inningNumber)
+
+		batsman, err := q.GetPlayerByPublicID(ctx, batsmanPublicID)
+		if err != nil {
+			store.logger.Error("failed to get batsman: ", err)
+			return err
+		}
+		dismissedBatsmanID := int32(batsman.ID)
+
+		var fielderID *int32
+		if fielderPublicID != uuid.Nil {
+			fielder, err := q.GetPlayerByPublicID(ctx, fielderPublicID)
 			if err != nil {
-				store.logger.Error("failed to add cricket wicket: ", err)
+				store.logger.Error("failed to get fielder: ", err)
 				return err
 			}
+			id := int32(fielder.ID)
+			fielderID = &id
+		}
+
+		dismissal, ok := database.GetCricketDismissal(wicketType)
+		if !ok {
+			return errorhandler.NewFieldError("wicket_type", "Unknown dismissal type")
+		}
+		if dismissal.FielderRequired && fielderID == nil {
+			return errorhandler.NewFieldError("fielder_public_id", fmt.Sprintf("Fielder is required for %s", wicketType))
+		}
+		if (bowlType == "wide" && !dismissal.OnWide) || (bowlType == "no_ball" && !dismissal.OnNoBall) {
+			return errorhandler.NewFieldError("wicket_type", fmt.Sprintf("%s is not possible off a %s", wicketType, strings.ReplaceAll(bowlType, "_", " ")))
+		}
+
+		// retirements happen between balls and do not add a delivery
+		if dismissal.BetweenDeliveries {
+			outBatsmanResponse, notOutBatsmanResponse, bowlerResponse, inningScoreResponse, wicketResponse, err = store.retireCricketBatsman(ctx, q, matchPublicID, battingTeamID, bowlerPublicID, dismissedBatsmanID, wicketType, dismissal, inningNumber)
+			currentBatsman = notOutBatsmanResponse
+			return err
+		}
+
+		arg := database.AddCricketDeliveryParams{
+			InningNumber:       inningNumber,
+			Runs:               int(runsScored),
+			IsLegal:            true,
+			IsWicket:           true,
+			WicketType:         &wicketType,
+			DismissedBatsmanID: &dismissedBatsmanID,
+			FielderID:          fielderID,
+		}
+		switch bowlType {
+		case "wide":
+			arg.ExtrasType = &bowlType
+			arg.Runs = 0
+			arg.ExtrasRuns = int(runsScored) + 1
+			arg.IsLegal = false
+		case "no_ball":
+			arg.ExtrasType = &bowlType
+			arg.ExtrasRuns = 1
+			arg.IsLegal = false
+		}
+
+		var delivery *models.CricketDelivery
+		delivery, _, bowlerResponse, inningScoreResponse, err = store.addCricketDelivery(ctx, q, matchPublicID, battingTeamID, bowlerPublicID, arg)
+		if err != nil {
+			store.logger.Error("failed to add cricket wicket: ", err)
+			return err
+		}
+
+		// the dismissed batter can be at either end for the modes which allow it
+		if dismissedBatsmanID != delivery.StrikerID && dismissedBatsmanID != delivery.NonStrikerID {
+			return errorhandler.NewFieldError("batsman_public_id", "Batsman is not at the crease")
+		}
+		if dismissedBatsmanID == delivery.NonStrikerID && !dismissal.NonStrikerAllowed {
+			return errorhandler.NewFieldError("batsman_public_id", fmt.Sprintf("Non-striker cannot be out %s", wicketType))
+		}
+
+		wicketResponse, err = q.AddCricketDeliveryWicket(ctx, *delivery, *inningScoreResponse)
+		if err != nil {
+			store.logger.Error("failed to add cricket wicket: ", err)
+			return err
+		}
+
+		outBatsmanResponse, err = q.DismissCricketBatsman(ctx, delivery.MatchID, delivery.TeamID, inningNumber, dismissedBatsmanID)
+		if err != nil {
+			store.logger.Error("failed to update out batsman: ", err)
+			return err
+		}
+
+		notOutBatsmanID := delivery.StrikerID
+		if notOutBatsmanID == dismissedBatsmanID {
+			notOutBatsmanID = delivery.NonStrikerID
+		}
+		currentBatsmen, err := q.GetCricketCurrentBatsmen(ctx, delivery.MatchID, delivery.TeamID, inningNumber)
+		if err != nil {
+			store.logger.Error("failed to get current batsman: ", err)
+			return err
+		}
+		for i := range currentBatsmen {
+			if currentBatsmen[i].BatsmanID == notOutBatsmanID {
+				notOutBatsmanResponse = &currentBatsmen[i]
+			}
 		}
 
 		matchData, err := q.GetMatchModelByPublicId(ctx, matchPublicID)
@@ -292,20 +437,13 @@ func (store *SQLStore) AddCricketWicketTx(ctx context.Context,
 			return err
 		}
 
-		if inningScoreResponse.Wickets == 10 {
-			inningScoreResponse, notOutBatsmanResponse, bowlerResponse, err = q.UpdateInningEndStatus(ctx, int32(matchData.ID), notOutBatsmanResponse.TeamID, inningNumber)
-			if err != nil {
-				store.logger.Error("failed to update inning_numberscore: ", err)
-				return err
-			}
-		} else if *matchData.MatchFormat == "T20" && inningScoreResponse.Overs/6 == 20 {
-			inningScoreResponse, notOutBatsmanResponse, bowlerResponse, err = q.UpdateInningEndStatus(ctx, int32(matchData.ID), notOutBatsmanResponse.TeamID, inningNumber)
-			if err != nil {
-				store.logger.Error("failed to update inning_numberscore: ", err)
-				return err
-			}
-		} else if *matchData.MatchFormat == "ODI" && inningScoreResponse.Overs/6 == 50 {
-			inningScoreResponse, notOutBatsmanResponse, bowlerResponse, err = q.UpdateInningEndStatus(ctx, int32(matchData.ID), notOutBatsmanResponse.TeamID, inningNumber)
+		revision, err := q.GetCricketRevisedTarget(ctx, int32(matchData.ID))
+		if err != nil {
+			store.logger.Error("failed to get revised target: ", err)
+			return err
+		}
+		if isCricketInningOver(matchData, inningScoreResponse, revision) {
+			inningScoreResponse, notOutBatsmanResponse, bowlerResponse, err = q.UpdateInningEndStatus(ctx, int32(matchData.ID), delivery.TeamID, inningNumber)
 			if err != nil {
 				store.logger.Error("failed to update inning_numberscore: ", err)
 				return err
@@ -318,27 +456,207 @@ func (store *SQLStore) AddCricketWicketTx(ctx context.Context,
 				store.logger.Error("failed to toggle batsman: ", err)
 				return err
 			}
-			notOutBatsmanResponse = &notOut[0]
+			if len(notOut) > 0 {
+				notOutBatsmanResponse = &notOut[0]
+			}
 		}
 		currentBatsman = notOutBatsmanResponse
-		if bowlerResponse.BallNumber%6 == 0 {
+		if isOverCompleted(delivery, inningScoreResponse) {
 			currentBatsmanResponse, err := q.ToggleCricketStricker(ctx, matchPublicID, inningNumber)
 			if err != nil {
 				store.logger.Error("Failed to update stricker: ", err)
 			}
-			currentBatsman = &currentBatsmanResponse[0]
+			if len(currentBatsmanResponse) > 0 {
+				currentBatsman = &currentBatsmanResponse[0]
+			}
 		}
 		return err
 	})
 	return outBatsmanResponse, currentBatsman, bowlerResponse, inningScoreResponse, wicketResponse, err
 }
 
+// retireCricketBatsman takes a batsman off between deliveries. Retired out counts as a
+// wicket of the inning, retired hurt does not and the batsman may resume later.
+func (store *SQLStore) retireCricketBatsman(ctx context.Context, q *database.Queries, matchPublicID, battingTeamID, bowlerPublicID uuid.UUID, batsmanID int32, wicketType string, dismissal database.CricketDismissal, inningNumber int) (*models.BatsmanScore, *models.BatsmanScore, *models.BowlerScore, *models.CricketScore, *models.Wicket, error) {
+	currentBatsmen, err := q.GetCurrentBattingBatsman(ctx, matchPublicID, battingTeamID, inningNumber)
+	if err != nil {
+		store.logger.Error("failed to get current batsman: ", err)
+		return nil, nil, nil, nil, nil, err
+	}
+
+	var notOutBatsman *models.BatsmanScore
+	atCrease := false
+	for i := range currentBatsmen {
+		if currentBatsmen[i].BatsmanID == batsmanID {
+			atCrease = true
+		} else {
+			notOutBatsman = &currentBatsmen[i]
+		}
+	}
+	if !atCrease || notOutBatsman == nil {
+		return nil, nil, nil, nil, nil, errorhandler.NewFieldError("batsman_public_id", "Batsman is not at the crease")
+	}
+
+	bowler, err := q.GetPlayerByPublicID(ctx, bowlerPublicID)
+	if err != nil {
+		store.logger.Error("failed to get bowler: ", err)
+		return nil, nil, nil, nil, nil, err
+	}
+
+	inningScore, err := q.GetCricketScoreByInning(ctx, matchPublicID, battingTeamID, inningNumber)
+	if err != nil {
+		store.logger.Error("failed to get inning score: ", err)
+		return nil, nil, nil, nil, nil, err
+	}
+	if inningScore == nil {
+		return nil, nil, nil, nil, nil, fmt.Errorf("inning %d has not started", inningNumber)
+	}
+
+	if dismissal.TeamWicket {
+		inningScore, err = q.UpdateCricketInningWickets(ctx, inningScore.MatchID, inningScore.TeamID, inningNumber, 1)
+		if err != nil {
+			store.logger.Error("failed to update inning wickets: ", err)
+			return nil, nil, nil, nil, nil, err
+		}
+	}
+
+	retirement := models.CricketDelivery{
+		MatchID:            inningScore.MatchID,
+		TeamID:             inningScore.TeamID,
+		InningNumber:       inningNumber,
+		BowlerID:           int32(bowler.ID),
+		IsWicket:           true,
+		WicketType:         &wicketType,
+		DismissedBatsmanID: &batsmanID,
+	}
+	wicket, err := q.AddCricketDeliveryWicket(ctx, retirement, *inningScore)
+	if err != nil {
+		store.logger.Error("failed to add cricket wicket: ", err)
+		return nil, nil, nil, nil, nil, err
+	}
+
+	outBatsman, err := q.DismissCricketBatsman(ctx, inningScore.MatchID, inningScore.TeamID, inningNumber, batsmanID)
+	if err != nil {
+		store.logger.Error("failed to update out batsman: ", err)
+		return nil, nil, nil, nil, nil, err
+	}
+
+	bowlerScore, err := q.SetCricketCurrentBowler(ctx, inningScore.MatchID, inningNumber, int32(bowler.ID))
+	if err != nil {
+		store.logger.Error("failed to get current bowler: ", err)
+		return nil, nil, nil, nil, nil, err
+	}
+
+	if inningScore.Wickets >= 10 {
+		inningScore, notOutBatsman, bowlerScore, err = q.UpdateInningEndStatus(ctx, inningScore.MatchID, inningScore.TeamID, inningNumber)
+		if err != nil {
+			store.logger.Error("failed to update inning end status: ", err)
+			return nil, nil, nil, nil, nil, err
+		}
+	}
+
+	return outBatsman, notOutBatsman, bowlerScore, inningScore, wicket, nil
+}
+
+// Bring a retired hurt batsman back to the crease
+func (store *SQLStore) ResumeCricketBatsmanTx(ctx context.Context, matchPublicID, battingTeamPublicID, batsmanPublicID uuid.UUID, inningNumber int) (*models.BatsmanScore, []models.BatsmanScore, error) {
+	var resumedBatsman *models.BatsmanScore
+	var currentBatsman []models.BatsmanScore
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		inningScore, err := q.GetCricketScoreByInning(ctx, matchPublicID, battingTeamPublicID, inningNumber)
+		if err != nil {
+			store.logger.Error("Failed to get inning score: ", err)
+			return err
+		}
+		if inningScore == nil || inningScore.IsInningCompleted {
+			return errorhandler.NewFieldError("inning_number", "Inning is not in progress")
+		}
+
+		batsman, err := q.GetPlayerByPublicID(ctx, batsmanPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get batsman: ", err)
+			return err
+		}
+		batsmanID := int32(batsman.ID)
+
+		wicket, err := q.GetCricketRetiredHurtWicket(ctx, inningScore.MatchID, inningScore.TeamID, inningNumber, batsmanID)
+		if err != nil {
+			store.logger.Error("Failed to get retired hurt wicket: ", err)
+			return err
+		}
+		if wicket == nil {
+			return errorhandler.NewFieldError("batsman_public_id", "Batsman has not retired hurt")
+		}
+
+		currentBatsmen, err := q.GetCricketCurrentBatsmen(ctx, inningScore.MatchID, inningScore.TeamID, inningNumber)
+		if err != nil {
+			store.logger.Error("Failed to get current batsman: ", err)
+			return err
+		}
+		if len(currentBatsmen) >= 2 {
+			return errorhandler.NewFieldError("batsman_public_id", "Both batsmen are already at the crease")
+		}
+		hasStriker := false
+		for _, current := range currentBatsmen {
+			if current.IsStriker {
+				hasStriker = true
+			}
+		}
+
+		retirement := models.CricketDelivery{
+			MatchID:            inningScore.MatchID,
+			TeamID:             inningScore.TeamID,
+			InningNumber:       inningNumber,
+			DismissedBatsmanID: &batsmanID,
+		}
+		err = q.DeleteCricketDeliveryWicket(ctx, retirement)
+		if err != nil {
+			store.logger.Error("Failed to delete retired hurt wicket: ", err)
+			return err
+		}
+
+		resumedBatsman, err = q.ResumeCricketBatsman(ctx, inningScore.MatchID, inningScore.TeamID, inningNumber, batsmanID, !hasStriker)
+		if err != nil {
+			store.logger.Error("Failed to resume batsman: ", err)
+			return err
+		}
+
+		currentBatsman, err = q.GetCricketCurrentBatsmen(ctx, inningScore.MatchID, inningScore.TeamID, inningNumber)
+		if err != nil {
+			store.logger.Error("Failed to get current batsman: ", err)
+			return err
+		}
+		return nil
+	})
+	return resumedBatsman, currentBatsman, err
+}
+
+// validateNextCricketBowler checks the over rules for a bowler about to take the ball
+func (store *SQLStore) validateNextCricketBowler(ctx context.Context, q *database.Queries, matchPublicID, bowlerPublicID uuid.UUID, inningNumber int) error {
+	match, err := q.GetMatchModelByPublicId(ctx, matchPublicID)
+	if err != nil {
+		store.logger.Error("Failed to get match: ", err)
+		return err
+	}
+
+	bowler, err := q.GetPlayerByPublicID(ctx, bowlerPublicID)
+	if err != nil {
+		store.logger.Error("Failed to get bowler: ", err)
+		return err
+	}
+
+	return store.validateCricketBowler(ctx, q, match, inningNumber, int32(bowler.ID))
+}
+
 // Update bowling bowler status
 func (store *SQLStore) UpdateBowlingBowlerStatusTx(ctx context.Context, matchPublicID, teamPublicID, currentBowlerPublicID, nextBowlerPublicID uuid.UUID, inningNumber int) (*models.BowlerScore, *models.BowlerScore, error) {
 	var currentBowlerResponse *models.BowlerScore
 	var nextBowlerResponse *models.BowlerScore
 	err := store.execTx(ctx, func(q *database.Queries) error {
-		var err error
+		err := store.validateNextCricketBowler(ctx, q, matchPublicID, nextBowlerPublicID, inningNumber)
+		if err != nil {
+			return err
+		}
 
 		currentBowlerResponse, err = q.UpdateBowlingBowlerStatus(ctx, matchPublicID, teamPublicID, currentBowlerPublicID, inningNumber)
 		if err != nil {
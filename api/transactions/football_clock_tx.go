@@ -0,0 +1,136 @@
+package transactions
+
+import (
+	"context"
+	"khelogames/database"
+	"khelogames/database/models"
+	"time"
+
+	footballhelper "khelogames/api/sports/football_helper"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/google/uuid"
+)
+
+// UpdateFootballClockTx applies a clock action to the match clock: change_state moves the clock to
+// the next state, pause and resume stop and restart the running period and added_time announces
+// the stoppage time. Leaving a period records its marker in the incidents.
+func (store *SQLStore) UpdateFootballClockTx(ctx context.Context, matchPublicID uuid.UUID, action string, state string, addedMinutes int) (*models.FootballMatchClock, *models.FootballIncident, error) {
+	var clock *models.FootballMatchClock
+	var periodIncident *models.FootballIncident
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		match, err := q.GetMatchModelByPublicId(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get match: ", err)
+			return err
+		}
+
+		current, err := q.GetFootballMatchClock(ctx, int32(match.ID))
+		if err != nil {
+			store.logger.Error("Failed to get football clock: ", err)
+			return err
+		}
+		if current == nil {
+			current = &models.FootballMatchClock{MatchID: int32(match.ID), State: footballhelper.ClockNotStarted}
+		}
+
+		now := time.Now().Unix()
+		arg := database.UpsertFootballMatchClockParams{
+			MatchID:        current.MatchID,
+			State:          current.State,
+			IsRunning:      current.IsRunning,
+			ElapsedSeconds: current.ElapsedSeconds,
+			StartedAt:      current.StartedAt,
+			AddedMinutes:   current.AddedMinutes,
+			UpdatedAt:      now,
+		}
+
+		switch action {
+		case "change_state":
+			if msg := footballhelper.ValidateClockTransition(current.State, state); msg != "" {
+				return errorhandler.NewFieldError("state", msg)
+			}
+			if periods, ok := footballhelper.PeriodIncident(state); ok {
+				minute := footballhelper.ClockMinuteAt(*current, now)
+				periodIncident, err = q.AddFootballPeriodIncident(ctx, int32(match.ID), periods, minute.Minute, minute.AddedTime)
+				if err != nil {
+					store.logger.Error("Failed to add period incident: ", err)
+					return err
+				}
+			}
+			arg.State = state
+			arg.ElapsedSeconds = 0
+			arg.AddedMinutes = 0
+			arg.IsRunning = footballhelper.IsPlayingPeriod(state)
+			arg.StartedAt = nil
+			if arg.IsRunning {
+				arg.StartedAt = &now
+			}
+		case "pause":
+			if !current.IsRunning {
+				return errorhandler.NewFieldError("action", "Clock is not running")
+			}
+			arg.ElapsedSeconds = footballhelper.ElapsedSeconds(*current, now)
+			arg.IsRunning = false
+			arg.StartedAt = nil
+		case "resume":
+			if current.IsRunning || !footballhelper.IsPlayingPeriod(current.State) {
+				return errorhandler.NewFieldError("action", "Clock is not paused")
+			}
+			arg.IsRunning = true
+			arg.StartedAt = &now
+		case "added_time":
+			if !footballhelper.IsPlayingPeriod(current.State) {
+				return errorhandler.NewFieldError("action", "Added time can only be announced during a period of play")
+			}
+			if addedMinutes < 0 {
+				return errorhandler.NewFieldError("added_minutes", "Added minutes cannot be negative")
+			}
+			arg.AddedMinutes = addedMinutes
+		default:
+			return errorhandler.NewFieldError("action", "Unknown clock action")
+		}
+
+		clock, err = q.UpsertFootballMatchClock(ctx, arg)
+		if err != nil {
+			store.logger.Error("Failed to update football clock: ", err)
+			return err
+		}
+		return nil
+	})
+	return clock, periodIncident, err
+}
+
+// playingFootballMinute returns the period and minute of the match clock while a period is being
+// played, a nil minute otherwise
+func (store *SQLStore) playingFootballMinute(ctx context.Context, q *database.Queries, matchID int32) (string, *footballhelper.ClockMinute, error) {
+	clock, err := q.GetFootballMatchClock(ctx, matchID)
+	if err != nil {
+		store.logger.Error("Failed to get football clock: ", err)
+		return "", nil, err
+	}
+	if clock == nil || !footballhelper.IsPlayingPeriod(clock.State) {
+		return "", nil, nil
+	}
+	minute := footballhelper.ClockMinuteAt(*clock, time.Now().Unix())
+	return clock.State, &minute, nil
+}
+
+// stampFootballIncident sets the minute of the incident from the match clock while a period is
+// being played, the minute sent by the scorer is kept otherwise
+func (store *SQLStore) stampFootballIncident(ctx context.Context, q *database.Queries, arg *database.CreateFootballIncidentsParams) error {
+	match, err := q.GetMatchModelByPublicId(ctx, arg.MatchPublicID)
+	if err != nil {
+		store.logger.Error("Failed to get match: ", err)
+		return err
+	}
+
+	periods, minute, err := store.playingFootballMinute(ctx, q, int32(match.ID))
+	if err != nil || minute == nil {
+		return err
+	}
+	arg.IncidentTime = minute.Minute
+	arg.AddedTime = minute.AddedTime
+	arg.Periods = periods
+	return nil
+}
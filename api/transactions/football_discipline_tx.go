@@ -0,0 +1,71 @@
+package transactions
+
+import (
+	"context"
+	"khelogames/database"
+	"khelogames/database/models"
+
+	footballhelper "khelogames/api/sports/football_helper"
+)
+
+// footballDisciplinaryRules returns the rules of the tournament or the default rules when it has
+// not set its own
+func footballDisciplinaryRules(ctx context.Context, q *database.Queries, tournamentID int32) (footballhelper.DisciplinaryRules, error) {
+	rules, err := q.GetFootballDisciplinaryRules(ctx, tournamentID)
+	if err != nil || rules == nil {
+		return footballhelper.DefaultDisciplinaryRules, err
+	}
+	return footballhelper.DisciplinaryRules{
+		YellowCardThreshold: rules.YellowCardThreshold,
+		YellowCardBan:       rules.YellowCardBan,
+		RedCardBan:          rules.RedCardBan,
+		CarryOverToKnockout: rules.CarryOverToKnockout,
+	}, nil
+}
+
+// updateFootballSuspensions works out the bans picked up in a finished match and writes them to the
+// suspension ledger, replacing the ones worked out before a correction of the incidents
+func (store *SQLStore) updateFootballSuspensions(ctx context.Context, q *database.Queries, match *models.Match) error {
+	rules, err := footballDisciplinaryRules(ctx, q, match.TournamentID)
+	if err != nil {
+		store.logger.Error("Failed to get disciplinary rules: ", err)
+		return err
+	}
+
+	err = q.DeleteFootballMatchSuspensions(ctx, int32(match.ID))
+	if err != nil {
+		store.logger.Error("Failed to clear match suspensions: ", err)
+		return err
+	}
+
+	cards, err := q.GetFootballMatchCards(ctx, int32(match.ID))
+	if err != nil {
+		store.logger.Error("Failed to get match cards: ", err)
+		return err
+	}
+
+	knockoutOnly := !rules.CarryOverToKnockout && match.Stage == "knockout"
+	for _, card := range cards {
+		previousYellows, err := q.GetFootballPreviousYellowCards(ctx, int32(match.ID), card.PlayerID, knockoutOnly)
+		if err != nil {
+			store.logger.Error("Failed to get previous yellow cards: ", err)
+			return err
+		}
+
+		for reason, matches := range footballhelper.MatchSuspensions(rules, previousYellows, card.YellowCards, card.RedCards) {
+			_, err := q.AddFootballSuspension(ctx, database.AddFootballSuspensionParams{
+				TournamentID:  match.TournamentID,
+				PlayerID:      card.PlayerID,
+				TeamID:        card.TeamID,
+				SourceMatchID: int32(match.ID),
+				Reason:        reason,
+				MatchesBanned: matches,
+			})
+			if err != nil {
+				store.logger.Error("Failed to add suspension: ", err)
+				return err
+			}
+		}
+	}
+	return nil
+}
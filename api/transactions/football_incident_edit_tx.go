@@ -0,0 +1,258 @@
+package transactions
+
+import (
+	"context"
+	"khelogames/database"
+	"khelogames/database/models"
+
+	footballhelper "khelogames/api/sports/football_helper"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/google/uuid"
+)
+
+// UpdateFootballIncidentTxParams holds the corrected incident. PlayerPublicID is required for player
+// incidents, PlayerInPublicID and PlayerOutPublicID for substitutions.
+type UpdateFootballIncidentTxParams struct {
+	MatchPublicID         uuid.UUID
+	IncidentPublicID      uuid.UUID
+	TeamPublicID          *uuid.UUID
+	PlayerPublicID        *uuid.UUID
+	AssistPlayerPublicID  *uuid.UUID
+	PlayerInPublicID      *uuid.UUID
+	PlayerOutPublicID     *uuid.UUID
+	Periods               string
+	IncidentType          string
+	IncidentTime          int
+	AddedTime             int
+	Description           string
+	PenaltyShootoutScored bool
+}
+
+// footballTeamIncidentStatistics builds the statistics of the team from its incidents that are
+// not cancelled
+func footballTeamIncidentStatistics(ctx context.Context, q *database.Queries, matchID int64, teamID int32) (map[string]interface{}, error) {
+	incidents, err := q.GetFootballIncidentByTeam(ctx, matchID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	var stats map[string]interface{}
+	for _, incident := range *incidents {
+		stats = footballhelper.GetStatisticsUpdateFromIncident(stats, incident.IncidentType)
+	}
+	return stats, nil
+}
+
+// applyFootballMatchStats adds what the incidents of a finished match put into the team statistics
+// and the player stats, a sign of -1 takes it back
+func (store *SQLStore) applyFootballMatchStats(ctx context.Context, q *database.Queries, match *models.Match, sign int) error {
+	for _, teamID := range []int32{match.HomeTeamID, match.AwayTeamID} {
+		stats, err := footballTeamIncidentStatistics(ctx, q, match.ID, teamID)
+		if err != nil {
+			store.logger.Error("Failed to get football incident by team: ", err)
+			return err
+		}
+		err = applyFootballStatistics(ctx, q, int32(match.ID), teamID, stats, int32(sign))
+		if err != nil {
+			store.logger.Error("Failed to update football statistics: ", err)
+			return err
+		}
+	}
+
+	playerStats, err := q.GetFootballPlayerMatchStats(ctx, match.PublicID)
+	if err != nil {
+		store.logger.Error("Failed to get football player match stats: ", err)
+		return err
+	}
+	for _, stats := range playerStats {
+		err = q.AdjustFootballPlayerStats(ctx, stats, sign)
+		if err != nil {
+			store.logger.Error("Failed to adjust football player stats: ", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// rebuildFootballMatch runs a correction of the incidents and rebuilds the score from the incidents
+// left. Once the match has finished its team statistics and player stats were already added, so
+// they are taken back before the correction and added again after it, and its result, standings
+// and bans are worked out again.
+func (store *SQLStore) rebuildFootballMatch(ctx context.Context, q *database.Queries, match *models.Match, correct func() error) ([]map[string]interface{}, error) {
+	finished := match.StatusCode == "finished"
+	if finished {
+		if err := store.applyFootballMatchStats(ctx, q, match, -1); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := correct(); err != nil {
+		return nil, err
+	}
+
+	scores, err := q.RecomputeFootballScore(ctx, int32(match.ID))
+	if err != nil {
+		store.logger.Error("Failed to recompute football score: ", err)
+		return nil, err
+	}
+
+	if finished {
+		if err := store.applyFootballMatchStats(ctx, q, match, 1); err != nil {
+			return nil, err
+		}
+		if err := store.updateFootballResult(ctx, q, match); err != nil {
+			return nil, err
+		}
+		if err := store.UpdateFootballStandings(ctx, q, match); err != nil {
+			return nil, err
+		}
+		if err := store.updateFootballSuspensions(ctx, q, match); err != nil {
+			return nil, err
+		}
+		if err := store.updateFootballPlayerRatings(ctx, q, match); err != nil {
+			return nil, err
+		}
+	}
+
+	var scoreData []map[string]interface{}
+	for i := range scores {
+		scoreData = append(scoreData, footballScoreData(&scores[i]))
+	}
+	return scoreData, nil
+}
+
+// updateFootballResult records the winner of a finished match again from its rebuilt score, a
+// match that is now drawn has its result cleared
+func (store *SQLStore) updateFootballResult(ctx context.Context, q *database.Queries, match *models.Match) error {
+	winnerTeamID, err := store.FootballMatchResult(ctx, q, match)
+	if err != nil {
+		return err
+	}
+	if winnerTeamID != nil {
+		_, err = q.UpdateMatchResult(ctx, int32(match.ID), *winnerTeamID)
+	} else {
+		_, err = q.ClearMatchResult(ctx, int32(match.ID))
+	}
+	if err != nil {
+		store.logger.Error("Failed to update match result: ", err)
+		return err
+	}
+	return nil
+}
+
+// getMatchFootballIncident returns the match and the incident after checking the incident is part
+// of the match
+func (store *SQLStore) getMatchFootballIncident(ctx context.Context, q *database.Queries, matchPublicID, incidentPublicID uuid.UUID) (*models.Match, *models.FootballIncident, error) {
+	match, err := q.GetMatchModelByPublicId(ctx, matchPublicID)
+	if err != nil {
+		store.logger.Error("Failed to get match: ", err)
+		return nil, nil, err
+	}
+
+	incident, err := q.GetFootballIncidentByPublicID(ctx, incidentPublicID)
+	if err != nil {
+		store.logger.Error("Failed to get football incident: ", err)
+		return nil, nil, err
+	}
+	if incident == nil || int64(incident.MatchID) != match.ID {
+		return nil, nil, errorhandler.NewFieldError("incident_public_id", "Incident not found in this match")
+	}
+	return match, incident, nil
+}
+
+// UpdateFootballIncidentTx corrects an incident and rebuilds the score, team statistics and player
+// stats of the match from the incidents
+func (store *SQLStore) UpdateFootballIncidentTx(ctx context.Context, arg UpdateFootballIncidentTxParams) (*models.FootballIncident, []map[string]interface{}, error) {
+	var incident *models.FootballIncident
+	var scoreData []map[string]interface{}
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		match, current, err := store.getMatchFootballIncident(ctx, q, arg.MatchPublicID, arg.IncidentPublicID)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case current.IncidentType == "period" || current.IncidentType == "var_review":
+			return errorhandler.NewFieldError("incident_public_id", "Period and VAR review incidents cannot be edited")
+		case arg.IncidentType == "period" || arg.IncidentType == "var_review":
+			return errorhandler.NewFieldError("incident_type", "Incident cannot be changed to a period or VAR review")
+		case (current.IncidentType == "substitution") != (arg.IncidentType == "substitution"):
+			return errorhandler.NewFieldError("incident_type", "Substitutions cannot be changed to other incidents")
+		case (current.IncidentType == "penalty_shootout") != (arg.IncidentType == "penalty_shootout"):
+			return errorhandler.NewFieldError("incident_type", "Shootout kicks cannot be changed to other incidents")
+		}
+
+		scoreData, err = store.rebuildFootballMatch(ctx, q, match, func() error {
+			incident, err = q.UpdateFootballIncident(ctx, database.UpdateFootballIncidentParams{
+				ID:                    current.ID,
+				TeamPublicID:          arg.TeamPublicID,
+				Periods:               arg.Periods,
+				IncidentType:          arg.IncidentType,
+				IncidentTime:          arg.IncidentTime,
+				AddedTime:             arg.AddedTime,
+				Description:           arg.Description,
+				PenaltyShootoutScored: arg.PenaltyShootoutScored,
+				AssistPlayerPublicID:  arg.AssistPlayerPublicID,
+			})
+			if err != nil {
+				store.logger.Error("Failed to update football incident: ", err)
+				return err
+			}
+
+			if arg.IncidentType == "substitution" {
+				if arg.PlayerInPublicID == nil || arg.PlayerOutPublicID == nil {
+					return nil
+				}
+				// the live formation follows the corrected players
+				err = q.MoveFootballFormationSlot(ctx, current.ID, true)
+				if err == nil {
+					err = q.UpdateFootballSubsPlayer(ctx, current.ID, *arg.PlayerInPublicID, *arg.PlayerOutPublicID)
+				}
+				if err == nil {
+					err = q.MoveFootballFormationSlot(ctx, current.ID, false)
+				}
+			} else if arg.PlayerPublicID != nil {
+				err = q.UpdateFootballIncidentPlayer(ctx, current.ID, *arg.PlayerPublicID)
+			}
+			if err != nil {
+				store.logger.Error("Failed to update football incident player: ", err)
+				return err
+			}
+			return nil
+		})
+		return err
+	})
+	return incident, scoreData, err
+}
+
+// DeleteFootballIncidentTx removes an incident and rebuilds the score, team statistics and player
+// stats of the match from the incidents left
+func (store *SQLStore) DeleteFootballIncidentTx(ctx context.Context, matchPublicID, incidentPublicID uuid.UUID) (*models.FootballIncident, []map[string]interface{}, error) {
+	var incident *models.FootballIncident
+	var scoreData []map[string]interface{}
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		match, current, err := store.getMatchFootballIncident(ctx, q, matchPublicID, incidentPublicID)
+		if err != nil {
+			return err
+		}
+
+		scoreData, err = store.rebuildFootballMatch(ctx, q, match, func() error {
+			if current.IncidentType == "substitution" {
+				err = q.MoveFootballFormationSlot(ctx, current.ID, true)
+				if err != nil {
+					store.logger.Error("Failed to restore live formation: ", err)
+					return err
+				}
+			}
+
+			incident, err = q.DeleteFootballIncident(ctx, current.ID)
+			if err != nil {
+				store.logger.Error("Failed to delete football incident: ", err)
+				return err
+			}
+			return nil
+		})
+		return err
+	})
+	return incident, scoreData, err
+}
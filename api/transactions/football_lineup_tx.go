@@ -0,0 +1,138 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"khelogames/database"
+	"khelogames/database/models"
+
+	footballhelper "khelogames/api/sports/football_helper"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/google/uuid"
+)
+
+// AddFootballLineupTx replaces the squad of the team with a lineup before the match starts: the
+// starters are put in the slots of the formation and the substitutes on the bench
+func (store *SQLStore) AddFootballLineupTx(ctx context.Context, matchPublicID, teamPublicID uuid.UUID, formation string, starters []footballhelper.LineupPlayer, substitutes []string) (*models.FootballFormation, []models.FootballSquad, error) {
+	var footballFormation *models.FootballFormation
+	var squad []models.FootballSquad
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		match, err := q.GetMatchModelByPublicId(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get match: ", err)
+			return err
+		}
+		// the squad is replaced, which would lose the substitutions of a match already under way
+		if match.StatusCode != "not_started" {
+			return errorhandler.NewFieldError("match_public_id", "Lineup can only be set before the match starts")
+		}
+
+		team, err := q.GetTeamByPublicID(ctx, teamPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get team: ", err)
+			return err
+		}
+		teamID := int32(team.ID)
+		if teamID != match.HomeTeamID && teamID != match.AwayTeamID {
+			return errorhandler.NewFieldError("team_public_id", "Team is not playing this match")
+		}
+
+		players, err := q.GetPlayerByTeam(ctx, teamPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get team players: ", err)
+			return err
+		}
+		teamPlayers := make(map[string]bool, len(players))
+		for _, player := range players {
+			teamPlayers[fmt.Sprint(player["public_id"])] = true
+		}
+
+		if msg := footballhelper.ValidateLineup(formation, starters, substitutes, teamPlayers); msg != "" {
+			return errorhandler.NewFieldError("lineup", msg)
+		}
+
+		suspended, err := q.GetFootballSuspendedPlayers(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get suspended players: ", err)
+			return err
+		}
+		for _, suspension := range suspended {
+			player, _ := suspension["player"].(map[string]interface{})
+			publicID := fmt.Sprint(player["public_id"])
+			for _, starter := range starters {
+				if starter.PlayerPublicID == publicID {
+					return errorhandler.NewFieldError("lineup", fmt.Sprintf("%v is suspended for this match", player["name"]))
+				}
+			}
+			for _, substitute := range substitutes {
+				if substitute == publicID {
+					return errorhandler.NewFieldError("lineup", fmt.Sprintf("%v is suspended for this match", player["name"]))
+				}
+			}
+		}
+
+		slots, err := footballhelper.FormationSlots(formation)
+		if err != nil {
+			return errorhandler.NewFieldError("formation", err.Error())
+		}
+		slotRoles := make(map[string]string, len(slots))
+		for _, slot := range slots {
+			slotRoles[slot.Slot] = slot.Role
+		}
+
+		err = q.DeleteFootballSquad(ctx, int32(match.ID), teamID)
+		if err != nil {
+			store.logger.Error("Failed to clear football squad: ", err)
+			return err
+		}
+
+		for _, starter := range starters {
+			playerPublicID, err := uuid.Parse(starter.PlayerPublicID)
+			if err != nil {
+				return errorhandler.NewFieldError("starters", "Invalid UUID format")
+			}
+			slot := starter.Slot
+			role := slotRoles[slot]
+			player, err := q.AddFootballLineupPlayer(ctx, database.AddFootballLineupPlayerParams{
+				MatchID:        int32(match.ID),
+				TeamID:         teamID,
+				PlayerPublicID: playerPublicID,
+				Position:       &role,
+				IsSubstitute:   false,
+				FormationSlot:  &slot,
+			})
+			if err != nil {
+				store.logger.Error("Failed to add lineup player: ", err)
+				return err
+			}
+			squad = append(squad, *player)
+		}
+
+		for _, substitute := range substitutes {
+			playerPublicID, err := uuid.Parse(substitute)
+			if err != nil {
+				return errorhandler.NewFieldError("substitutes", "Invalid UUID format")
+			}
+			player, err := q.AddFootballLineupPlayer(ctx, database.AddFootballLineupPlayerParams{
+				MatchID:        int32(match.ID),
+				TeamID:         teamID,
+				PlayerPublicID: playerPublicID,
+				IsSubstitute:   true,
+			})
+			if err != nil {
+				store.logger.Error("Failed to add lineup substitute: ", err)
+				return err
+			}
+			squad = append(squad, *player)
+		}
+
+		footballFormation, err = q.UpsertFootballFormation(ctx, int32(match.ID), teamID, formation)
+		if err != nil {
+			store.logger.Error("Failed to save football formation: ", err)
+			return err
+		}
+		return nil
+	})
+	return footballFormation, squad, err
+}
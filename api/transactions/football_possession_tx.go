@@ -0,0 +1,66 @@
+package transactions
+
+import (
+	"context"
+	"khelogames/database"
+
+	footballhelper "khelogames/api/sports/football_helper"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/google/uuid"
+)
+
+// UpdateFootballPossessionTx records the possession of both teams in a period, the away team
+// gets what the home team did not have. It returns the statistics of the match.
+func (store *SQLStore) UpdateFootballPossessionTx(ctx context.Context, matchPublicID uuid.UUID, periods string, homePossession int) (map[string]interface{}, error) {
+	var statistics map[string]interface{}
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		if !footballhelper.IsPlayingPeriod(periods) {
+			return errorhandler.NewFieldError("periods", "Possession can only be recorded for a period of play")
+		}
+		if homePossession < 0 || homePossession > 100 {
+			return errorhandler.NewFieldError("home_possession", "Possession must be between 0 and 100")
+		}
+
+		match, err := q.GetMatchModelByPublicId(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get match: ", err)
+			return err
+		}
+
+		_, err = q.UpsertFootballPossession(ctx, database.UpsertFootballPossessionParams{
+			MatchID:    int32(match.ID),
+			TeamID:     match.HomeTeamID,
+			Periods:    periods,
+			Possession: homePossession,
+		})
+		if err != nil {
+			store.logger.Error("Failed to update home possession: ", err)
+			return err
+		}
+		_, err = q.UpsertFootballPossession(ctx, database.UpsertFootballPossessionParams{
+			MatchID:    int32(match.ID),
+			TeamID:     match.AwayTeamID,
+			Periods:    periods,
+			Possession: 100 - homePossession,
+		})
+		if err != nil {
+			store.logger.Error("Failed to update away possession: ", err)
+			return err
+		}
+
+		counts, err := q.GetFootballIncidentCounts(ctx, int32(match.ID))
+		if err != nil {
+			store.logger.Error("Failed to get incident counts: ", err)
+			return err
+		}
+		possession, err := q.GetFootballPossession(ctx, int32(match.ID))
+		if err != nil {
+			store.logger.Error("Failed to get possession: ", err)
+			return err
+		}
+		statistics = footballhelper.MatchStatistics(match, counts, possession)
+		return nil
+	})
+	return statistics, err
+}
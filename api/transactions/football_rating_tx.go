@@ -0,0 +1,89 @@
+package transactions
+
+import (
+	"context"
+	"khelogames/database"
+	"khelogames/database/models"
+
+	footballhelper "khelogames/api/sports/football_helper"
+)
+
+// updateFootballPlayerRatings rates every player who took part in a finished match and picks the
+// man of the match, replacing the ratings worked out before a correction of the incidents
+func (store *SQLStore) updateFootballPlayerRatings(ctx context.Context, q *database.Queries, match *models.Match) error {
+	err := q.DeleteFootballPlayerRatings(ctx, int32(match.ID))
+	if err != nil {
+		store.logger.Error("Failed to clear player ratings: ", err)
+		return err
+	}
+
+	matchPerformances, err := q.GetFootballPlayerMatchPerformances(ctx, match.PublicID)
+	if err != nil {
+		store.logger.Error("Failed to get player performances: ", err)
+		return err
+	}
+
+	homeScore, err := q.GetFootballScore(ctx, database.GetFootballScoreParams{MatchID: match.ID, TeamID: int64(match.HomeTeamID)})
+	if err != nil {
+		store.logger.Error("Failed to get home score: ", err)
+		return err
+	}
+	awayScore, err := q.GetFootballScore(ctx, database.GetFootballScoreParams{MatchID: match.ID, TeamID: int64(match.AwayTeamID)})
+	if err != nil {
+		store.logger.Error("Failed to get away score: ", err)
+		return err
+	}
+	conceded := map[int32]int{
+		match.HomeTeamID: awayScore.Goals,
+		match.AwayTeamID: homeScore.Goals,
+	}
+
+	weights := footballhelper.DefaultRatingWeights
+	performances := make([]footballhelper.PlayerPerformance, 0, len(matchPerformances))
+	ratings := make([]float64, 0, len(matchPerformances))
+	for _, p := range matchPerformances {
+		role := footballhelper.PlayerRole(p.FormationSlot, p.Position)
+		performance := footballhelper.PlayerPerformance{
+			PlayerID:      p.PlayerID,
+			TeamID:        p.TeamID,
+			Role:          role,
+			MinutesPlayed: p.MinutesPlayed,
+			Goals:         p.GoalsScored,
+			Assists:       p.Assists,
+			OwnGoals:      p.OwnGoals,
+			YellowCards:   p.YellowCards,
+			RedCards:      p.RedCards,
+			CleanSheet:    conceded[p.TeamID] == 0 && footballhelper.EarnsCleanSheet(weights, role, p.MinutesPlayed),
+		}
+		performances = append(performances, performance)
+		ratings = append(ratings, footballhelper.PlayerRating(weights, performance))
+	}
+
+	manOfTheMatch := footballhelper.ManOfTheMatch(performances, ratings)
+	for i, performance := range performances {
+		// unused substitutes are not rated
+		if performance.MinutesPlayed == 0 {
+			continue
+		}
+		_, err := q.AddFootballPlayerRating(ctx, database.AddFootballPlayerRatingParams{
+			MatchID:         int32(match.ID),
+			TournamentID:    match.TournamentID,
+			PlayerID:        performance.PlayerID,
+			TeamID:          performance.TeamID,
+			Rating:          ratings[i],
+			MinutesPlayed:   performance.MinutesPlayed,
+			Goals:           performance.Goals,
+			Assists:         performance.Assists,
+			OwnGoals:        performance.OwnGoals,
+			YellowCards:     performance.YellowCards,
+			RedCards:        performance.RedCards,
+			CleanSheet:      performance.CleanSheet,
+			IsManOfTheMatch: i == manOfTheMatch,
+		})
+		if err != nil {
+			store.logger.Error("Failed to add player rating: ", err)
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"khelogames/database"
+	"khelogames/database/models"
+
+	footballhelper "khelogames/api/sports/football_helper"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/google/uuid"
+)
+
+// AddFootballShootoutKickTxParams is a kick taken in the penalty shootout of a match
+type AddFootballShootoutKickTxParams struct {
+	MatchPublicID  uuid.UUID
+	TeamPublicID   uuid.UUID
+	TakerPublicID  uuid.UUID
+	KeeperPublicID *uuid.UUID
+	Outcome        string
+	IncidentTime   int
+	Description    string
+}
+
+// footballShootoutState replays the kicks taken so far in the shootout of the match
+func footballShootoutState(ctx context.Context, q *database.Queries, match *models.Match) (footballhelper.ShootoutState, error) {
+	kicks, err := q.GetFootballShootoutKicks(ctx, int32(match.ID))
+	if err != nil {
+		return footballhelper.ShootoutState{}, err
+	}
+	return footballhelper.MatchShootout(match, kicks), nil
+}
+
+// AddFootballShootoutKickTx adds the next kick of the penalty shootout. The kick is recorded as a
+// penalty_shootout incident, so the shootout score keeps counting from the incidents. The winner of
+// the shootout becomes the match result when the match is finished.
+func (store *SQLStore) AddFootballShootoutKickTx(ctx context.Context, arg AddFootballShootoutKickTxParams) (*models.FootballShootoutKick, *models.FootballIncident, *footballhelper.ShootoutState, []map[string]interface{}, error) {
+	var kick *models.FootballShootoutKick
+	var incident *models.FootballIncident
+	var state footballhelper.ShootoutState
+	var scoreData []map[string]interface{}
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		match, err := q.GetMatchModelByPublicId(ctx, arg.MatchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get match: ", err)
+			return err
+		}
+		if match.StatusCode != "in_progress" {
+			return errorhandler.NewFieldError("match_public_id", "Match is not in progress")
+		}
+
+		clock, err := q.GetFootballMatchClock(ctx, int32(match.ID))
+		if err != nil {
+			store.logger.Error("Failed to get football clock: ", err)
+			return err
+		}
+		if clock == nil || clock.State != footballhelper.ClockPenaltyShootout {
+			return errorhandler.NewFieldError("match_public_id", "Penalty shootout has not started")
+		}
+
+		homeScore, err := q.GetFootballScore(ctx, database.GetFootballScoreParams{MatchID: match.ID, TeamID: int64(match.HomeTeamID)})
+		if err != nil {
+			store.logger.Error("Failed to get home score: ", err)
+			return err
+		}
+		awayScore, err := q.GetFootballScore(ctx, database.GetFootballScoreParams{MatchID: match.ID, TeamID: int64(match.AwayTeamID)})
+		if err != nil {
+			store.logger.Error("Failed to get away score: ", err)
+			return err
+		}
+		if homeScore.Goals != awayScore.Goals {
+			return errorhandler.NewFieldError("match_public_id", "Only a drawn match goes to a penalty shootout")
+		}
+
+		team, err := q.GetTeamByPublicID(ctx, arg.TeamPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get team: ", err)
+			return err
+		}
+		teamID := int32(team.ID)
+		if teamID != match.HomeTeamID && teamID != match.AwayTeamID {
+			return errorhandler.NewFieldError("team_public_id", "Team is not playing this match")
+		}
+
+		players, err := q.GetPlayerByTeam(ctx, arg.TeamPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get team players: ", err)
+			return err
+		}
+		takerInTeam := false
+		for _, player := range players {
+			if fmt.Sprint(player["public_id"]) == arg.TakerPublicID.String() {
+				takerInTeam = true
+				break
+			}
+		}
+		if !takerInTeam {
+			return errorhandler.NewFieldError("taker_public_id", "Taker does not play for the team")
+		}
+
+		state, err = footballShootoutState(ctx, q, match)
+		if err != nil {
+			store.logger.Error("Failed to get shootout kicks: ", err)
+			return err
+		}
+		if msg := footballhelper.ValidateShootoutKick(state, teamID, arg.Outcome); msg != "" {
+			return errorhandler.NewFieldError("team_public_id", msg)
+		}
+
+		taker, err := q.GetPlayerByPublicID(ctx, arg.TakerPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get taker: ", err)
+			return err
+		}
+		var keeperID *int32
+		if arg.KeeperPublicID != nil {
+			keeper, err := q.GetPlayerByPublicID(ctx, *arg.KeeperPublicID)
+			if err != nil {
+				store.logger.Error("Failed to get keeper: ", err)
+				return err
+			}
+			if keeper == nil {
+				return errorhandler.NewFieldError("keeper_public_id", "Keeper not found")
+			}
+			id := int32(keeper.ID)
+			keeperID = &id
+		}
+
+		incident, err = q.AddFootballShootoutIncident(ctx, int32(match.ID), teamID, arg.IncidentTime, arg.Description, arg.Outcome == footballhelper.KickScored)
+		if err != nil {
+			store.logger.Error("Failed to add shootout incident: ", err)
+			return err
+		}
+		_, err = q.AddFootballIncidentPlayer(ctx, incident.ID, arg.TakerPublicID)
+		if err != nil {
+			store.logger.Error("Failed to add shootout taker: ", err)
+			return err
+		}
+
+		kick, err = q.AddFootballShootoutKick(ctx, database.AddFootballShootoutKickParams{
+			MatchID:    int32(match.ID),
+			TeamID:     teamID,
+			IncidentID: incident.ID,
+			TakerID:    int32(taker.ID),
+			KeeperID:   keeperID,
+			Outcome:    arg.Outcome,
+		})
+		if err != nil {
+			store.logger.Error("Failed to add shootout kick: ", err)
+			return err
+		}
+
+		scores, err := q.RecomputeFootballScore(ctx, int32(match.ID))
+		if err != nil {
+			store.logger.Error("Failed to recompute football score: ", err)
+			return err
+		}
+		for i := range scores {
+			scoreData = append(scoreData, footballScoreData(&scores[i]))
+		}
+
+		state, err = footballShootoutState(ctx, q, match)
+		if err != nil {
+			store.logger.Error("Failed to get shootout kicks: ", err)
+			return err
+		}
+		return nil
+	})
+	return kick, incident, &state, scoreData, err
+}
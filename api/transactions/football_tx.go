@@ -1,9 +1,12 @@
 package transactions
 
 import (
+	"context"
 	"khelogames/database"
 	"khelogames/database/models"
 
+	footballhelper "khelogames/api/sports/football_helper"
+
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
@@ -18,6 +21,11 @@ func (store *SQLStore) AddFootballIncidentsTx(ctx *gin.Context, arg database.Cre
 	result := &AddFootballIncidentsTxResult{}
 	err := store.execTx(ctx, func(q *database.Queries) error {
 		var err error
+		err = store.stampFootballIncident(ctx, q, &arg)
+		if err != nil {
+			return err
+		}
+
 		incidents, err := q.CreateFootballIncidents(ctx, arg)
 		if err != nil {
 			store.logger.Error("Failed to create football incidents: ", err)
@@ -42,74 +50,21 @@ func (store *SQLStore) AddFootballIncidentsTx(ctx *gin.Context, arg database.Cre
 				return err
 			}
 
-			//Handle goals, penalty_shootout and penalty
+			//Handle goals and penalty
 			switch incidents.IncidentType {
-			case "goal", "penalty":
-				if incidents.Periods == "first_half" {
-					argGoalScore := database.UpdateFirstHalfScoreParams{
-						FirstHalf: 1,
-						MatchID:   incidents.MatchID,
-						TeamID:    *incidents.TeamID,
-					}
-
-					firstHalfData, err := q.UpdateFirstHalfScore(ctx, argGoalScore)
-					if err != nil {
-						store.logger.Error("Failed to update football score: ", err)
-						return err
-					}
-
-					result.ScoreData = map[string]interface{}{
-						"id":               firstHalfData.ID,
-						"public_id":        firstHalfData.PublicID,
-						"match_id":         firstHalfData.MatchID,
-						"team_id":          firstHalfData.TeamID,
-						"first_half":       firstHalfData.FirstHalf,
-						"second_half":      firstHalfData.SecondHalf,
-						"goals":            firstHalfData.Goals,
-						"penalty_shootout": firstHalfData.PenaltyShootOut,
-					}
-				} else if incidents.Periods == "second_half" {
-					argGoalScore := database.UpdateSecondHalfScoreParams{
-						SecondHalf: 1,
-						MatchID:    incidents.MatchID,
-						TeamID:     *incidents.TeamID,
-					}
-
-					secondHalfData, err := q.UpdateSecondHalfScore(ctx, argGoalScore)
-					if err != nil {
-						store.logger.Error("Failed to update football score: ", err)
-						return err
-					}
-
-					result.ScoreData = map[string]interface{}{
-						"id":               secondHalfData.ID,
-						"public_id":        secondHalfData.PublicID,
-						"match_id":         secondHalfData.MatchID,
-						"team_id":          secondHalfData.TeamID,
-						"first_half":       secondHalfData.FirstHalf,
-						"second_half":      secondHalfData.SecondHalf,
-						"goals":            secondHalfData.Goals,
-						"penalty_shootout": secondHalfData.PenaltyShootOut,
-					}
+			case "goal", "penalty", "own_goal":
+				match, err := q.GetMatchModelByPublicId(ctx, arg.MatchPublicID)
+				if err != nil {
+					store.logger.Error("Failed to get match: ", err)
+					return err
 				}
-			case "penalty_shootout":
-				if incidents.PenaltyShootoutScored {
-					penaltyData, err := q.UpdatePenaltyShootoutScore(ctx, incidents.MatchID, *incidents.TeamID)
-					if err != nil {
-						store.logger.Error("Failed to update penalty shootout score: ", err)
-						return err
-					}
 
-					result.ScoreData = map[string]interface{}{
-						"id":               penaltyData.ID,
-						"public_id":        penaltyData.PublicID,
-						"match_id":         penaltyData.MatchID,
-						"team_id":          penaltyData.TeamID,
-						"first_half":       penaltyData.FirstHalf,
-						"second_half":      penaltyData.SecondHalf,
-						"goals":            penaltyData.Goals,
-						"penalty_shootout": penaltyData.PenaltyShootOut,
-					}
+				scoreData, err := store.updateFootballGoalScore(ctx, q, incidents.Periods, incidents.MatchID, footballScoringTeamID(match, incidents), 1)
+				if err != nil {
+					return err
+				}
+				if scoreData != nil {
+					result.ScoreData = footballScoreData(scoreData)
 				}
 			default:
 				// Non-score incidents (foul, yellow_card, red_card, etc.) — no score update needed
@@ -123,6 +78,7 @@ func (store *SQLStore) AddFootballIncidentsTx(ctx *gin.Context, arg database.Cre
 				"periods":                 incidents.Periods,
 				"incident_type":           incidents.IncidentType,
 				"incident_time":           incidents.IncidentTime,
+				"added_time":              incidents.AddedTime,
 				"description":             incidents.Description,
 				"penalty_shootout_scored": incidents.PenaltyShootoutScored,
 				"tournament_id":           incidents.TournamentID,
@@ -140,8 +96,27 @@ func (store *SQLStore) AddFootballIncidentsTx(ctx *gin.Context, arg database.Cre
 				},
 			}
 
+			if incidents.AssistPlayerID != nil {
+				assistData, err := q.GetPlayerByID(ctx, int64(*incidents.AssistPlayerID))
+				if err != nil {
+					store.logger.Error("Failed to get assist player: ", err)
+					return err
+				}
+				result.IncidentData["assist_player"] = map[string]interface{}{
+					"id":         assistData.ID,
+					"public_id":  assistData.PublicID,
+					"user_id":    assistData.UserID,
+					"name":       assistData.Name,
+					"slug":       assistData.Slug,
+					"short_name": assistData.ShortName,
+					"positions":  assistData.Positions,
+					"country":    assistData.Country,
+					"media_url":  assistData.MediaUrl,
+				}
+			}
+
 			// Only include scores for score-changing incidents
-			if incidents.IncidentType == "goal" || incidents.IncidentType == "penalty" || incidents.IncidentType == "penalty_shootout" {
+			if incidents.IncidentType == "goal" || incidents.IncidentType == "penalty" || incidents.IncidentType == "own_goal" {
 				currentMatch, err := q.GetMatchByPublicId(ctx, arg.MatchPublicID, 1)
 				if err == nil && currentMatch != nil {
 					if homeScore, ok := currentMatch["homeScore"].(map[string]interface{}); ok {
@@ -166,6 +141,7 @@ func (store *SQLStore) AddFootballIncidentsTx(ctx *gin.Context, arg database.Cre
 				"periods":                 incidents.Periods,
 				"incident_type":           incidents.IncidentType,
 				"incident_time":           incidents.IncidentTime,
+				"added_time":              incidents.AddedTime,
 				"description":             incidents.Description,
 				"penalty_shootout_scored": incidents.PenaltyShootoutScored,
 				"tournament_id":           incidents.TournamentID,
@@ -192,18 +168,29 @@ func (store *SQLStore) AddFootballIncidentsSubsTx(ctx *gin.Context, matchPublicI
 			PenaltyShootoutScored: false,
 		}
 
-		incidents, err := store.CreateFootballIncidents(ctx, arg)
+		err = store.stampFootballIncident(ctx, q, &arg)
+		if err != nil {
+			return err
+		}
+
+		incidents, err := q.CreateFootballIncidents(ctx, arg)
 		if err != nil {
 			store.logger.Error("Failed to create football incidents: ", err)
 			return err
 		}
 
-		data, err := store.ADDFootballSubsPlayer(ctx, incidents.PublicID, playerInPublicID, playerOutPublicID)
+		data, err := q.ADDFootballSubsPlayer(ctx, incidents.PublicID, playerInPublicID, playerOutPublicID)
 		if err != nil {
 			store.logger.Error("Failed to create football incidents: ", err)
 			return err
 		}
 
+		err = q.MoveFootballFormationSlot(ctx, incidents.ID, false)
+		if err != nil {
+			store.logger.Error("Failed to update live formation: ", err)
+			return err
+		}
+
 		subsData := *data
 
 		incidentData = map[string]interface{}{
@@ -214,6 +201,7 @@ func (store *SQLStore) AddFootballIncidentsSubsTx(ctx *gin.Context, matchPublicI
 			"periods":                 incidents.Periods,
 			"incident_type":           incidents.IncidentType,
 			"incident_time":           incidents.IncidentTime,
+			"added_time":              incidents.AddedTime,
 			"description":             incidents.Description,
 			"penalty_shootout_scored": incidents.PenaltyShootoutScored,
 			"tournament_id":           incidents.TournamentID,
@@ -225,3 +213,78 @@ func (store *SQLStore) AddFootballIncidentsSubsTx(ctx *gin.Context, matchPublicI
 	})
 	return &incidentData, err
 }
+
+// footballScoreData is the score of a team as broadcast to the clients
+func footballScoreData(score *models.FootballScore) map[string]interface{} {
+	return map[string]interface{}{
+		"id":               score.ID,
+		"public_id":        score.PublicID,
+		"match_id":         score.MatchID,
+		"team_id":          score.TeamID,
+		"first_half":       score.FirstHalf,
+		"second_half":      score.SecondHalf,
+		"goals":            score.Goals,
+		"penalty_shootout": score.PenaltyShootOut,
+	}
+}
+
+// footballScoringTeamID returns the team credited with a goal incident, an own goal counts for
+// the opponents of the player's team
+func footballScoringTeamID(match *models.Match, incident *models.FootballIncident) int32 {
+	if incident.IncidentType != "own_goal" {
+		return *incident.TeamID
+	}
+	if *incident.TeamID == match.HomeTeamID {
+		return match.AwayTeamID
+	}
+	return match.HomeTeamID
+}
+
+// updateFootballGoalScore adds goals, or takes them back when negative, in the half of the period.
+// Extra time goals are kept with the second half as the score has no extra time column; a nil
+// score is returned for periods that do not count towards the score.
+func (store *SQLStore) updateFootballGoalScore(ctx context.Context, q *database.Queries, periods string, matchID, teamID int32, goals int) (*models.FootballScore, error) {
+	switch periods {
+	case footballhelper.ClockFirstHalf:
+		score, err := q.UpdateFirstHalfScore(ctx, database.UpdateFirstHalfScoreParams{
+			FirstHalf: goals,
+			MatchID:   matchID,
+			TeamID:    teamID,
+		})
+		if err != nil {
+			store.logger.Error("Failed to update football score: ", err)
+			return nil, err
+		}
+		return &score, nil
+	case footballhelper.ClockSecondHalf, footballhelper.ClockExtraTimeFirstHalf, footballhelper.ClockExtraTimeSecondHalf:
+		score, err := q.UpdateSecondHalfScore(ctx, database.UpdateSecondHalfScoreParams{
+			SecondHalf: int32(goals),
+			MatchID:    matchID,
+			TeamID:     teamID,
+		})
+		if err != nil {
+			store.logger.Error("Failed to update football score: ", err)
+			return nil, err
+		}
+		return &score, nil
+	}
+	return nil, nil
+}
+
+// applyFootballStatistics adds the statistics built by GetStatisticsUpdateFromIncident to the team,
+// a sign of -1 takes them back
+func applyFootballStatistics(ctx context.Context, q *database.Queries, matchID, teamID int32, stats map[string]interface{}, sign int32) error {
+	_, err := q.UpdateFootballStatistics(ctx,
+		matchID,
+		teamID,
+		sign*footballhelper.GetInt32(stats["shots_on_target"]),
+		sign*footballhelper.GetInt32(stats["total_shots"]),
+		sign*footballhelper.GetInt32(stats["corner_kicks"]),
+		sign*footballhelper.GetInt32(stats["fouls"]),
+		sign*footballhelper.GetInt32(stats["goal_keeper_saves"]),
+		sign*footballhelper.GetInt32(stats["free_kicks"]),
+		sign*footballhelper.GetInt32(stats["yellow_cards"]),
+		sign*footballhelper.GetInt32(stats["red_cards"]),
+	)
+	return err
+}
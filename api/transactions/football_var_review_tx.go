@@ -0,0 +1,98 @@
+package transactions
+
+import (
+	"context"
+	"khelogames/database"
+	"khelogames/database/models"
+
+	errorhandler "khelogames/error_handler"
+
+	"github.com/google/uuid"
+)
+
+// incidents a VAR review can cancel
+var varReviewableIncidents = map[string]bool{
+	"goal":        true,
+	"penalty":     true,
+	"own_goal":    true,
+	"yellow_card": true,
+	"red_card":    true,
+}
+
+// AddFootballVarReviewTx cancels a goal or card overturned by VAR and rebuilds the match without
+// it, so a finished match also has its statistics, result and standings worked out again.
+func (store *SQLStore) AddFootballVarReviewTx(ctx context.Context, matchPublicID, incidentPublicID uuid.UUID, incidentTime int, description string) (*models.FootballIncident, *models.FootballIncident, map[string]interface{}, error) {
+	var review *models.FootballIncident
+	var cancelled *models.FootballIncident
+	var scoreData map[string]interface{}
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		match, err := q.GetMatchModelByPublicId(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get match: ", err)
+			return err
+		}
+
+		incident, err := q.GetFootballIncidentByPublicID(ctx, incidentPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get football incident: ", err)
+			return err
+		}
+		if incident == nil || int64(incident.MatchID) != match.ID {
+			return errorhandler.NewFieldError("incident_public_id", "Incident not found in this match")
+		}
+		if !varReviewableIncidents[incident.IncidentType] || incident.TeamID == nil {
+			return errorhandler.NewFieldError("incident_public_id", "Only goals and cards can be overturned by VAR")
+		}
+
+		scores, err := store.rebuildFootballMatch(ctx, q, match, func() error {
+			cancelled, err = q.CancelFootballIncident(ctx, incident.ID)
+			if err != nil {
+				store.logger.Error("Failed to cancel football incident: ", err)
+				return err
+			}
+			if cancelled == nil {
+				return errorhandler.NewFieldError("incident_public_id", "Incident has already been cancelled")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		switch cancelled.IncidentType {
+		case "goal", "penalty", "own_goal":
+			scoringTeamID := footballScoringTeamID(match, cancelled)
+			for _, score := range scores {
+				if score["team_id"] == scoringTeamID {
+					scoreData = score
+				}
+			}
+		}
+
+		arg := database.AddFootballVarReviewIncidentParams{
+			MatchID:             cancelled.MatchID,
+			TeamID:              cancelled.TeamID,
+			Periods:             cancelled.Periods,
+			IncidentTime:        incidentTime,
+			Description:         description,
+			CancelledIncidentID: cancelled.ID,
+		}
+		periods, minute, err := store.playingFootballMinute(ctx, q, cancelled.MatchID)
+		if err != nil {
+			return err
+		}
+		if minute != nil {
+			arg.Periods = periods
+			arg.IncidentTime = minute.Minute
+			arg.AddedTime = minute.AddedTime
+		}
+
+		review, err = q.AddFootballVarReviewIncident(ctx, arg)
+		if err != nil {
+			store.logger.Error("Failed to add var review incident: ", err)
+			return err
+		}
+		return nil
+	})
+	return review, cancelled, scoreData, err
+}
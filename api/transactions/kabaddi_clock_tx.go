@@ -0,0 +1,82 @@
+package transactions
+
+import (
+	"context"
+	"khelogames/database"
+	"khelogames/database/models"
+	"time"
+
+	kabaddihelper "khelogames/api/sports/kabaddi_helper"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/google/uuid"
+)
+
+// UpdateKabaddiClockTx applies a clock action to the match clock: change_state moves the clock to
+// the next state, pause and resume stop and restart the running half
+func (store *SQLStore) UpdateKabaddiClockTx(ctx context.Context, matchPublicID uuid.UUID, action string, state string) (*models.KabaddiMatchClock, error) {
+	var clock *models.KabaddiMatchClock
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		match, err := q.GetMatchModelByPublicId(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get match: ", err)
+			return err
+		}
+
+		current, err := q.GetKabaddiMatchClock(ctx, int32(match.ID))
+		if err != nil {
+			store.logger.Error("Failed to get kabaddi clock: ", err)
+			return err
+		}
+		if current == nil {
+			current = &models.KabaddiMatchClock{MatchID: int32(match.ID), State: kabaddihelper.ClockNotStarted}
+		}
+
+		now := time.Now().Unix()
+		arg := database.UpsertKabaddiMatchClockParams{
+			MatchID:        current.MatchID,
+			State:          current.State,
+			IsRunning:      current.IsRunning,
+			ElapsedSeconds: current.ElapsedSeconds,
+			StartedAt:      current.StartedAt,
+			UpdatedAt:      now,
+		}
+
+		switch action {
+		case "change_state":
+			if msg := kabaddihelper.ValidateClockTransition(current.State, state); msg != "" {
+				return errorhandler.NewFieldError("state", msg)
+			}
+			arg.State = state
+			arg.ElapsedSeconds = 0
+			arg.IsRunning = kabaddihelper.IsPlayingHalf(state)
+			arg.StartedAt = nil
+			if arg.IsRunning {
+				arg.StartedAt = &now
+			}
+		case "pause":
+			if !current.IsRunning {
+				return errorhandler.NewFieldError("action", "Clock is not running")
+			}
+			arg.ElapsedSeconds = kabaddihelper.ElapsedSeconds(*current, now)
+			arg.IsRunning = false
+			arg.StartedAt = nil
+		case "resume":
+			if current.IsRunning || !kabaddihelper.IsPlayingHalf(current.State) {
+				return errorhandler.NewFieldError("action", "Clock is not paused")
+			}
+			arg.IsRunning = true
+			arg.StartedAt = &now
+		default:
+			return errorhandler.NewFieldError("action", "Unknown clock action")
+		}
+
+		clock, err = q.UpsertKabaddiMatchClock(ctx, arg)
+		if err != nil {
+			store.logger.Error("Failed to update kabaddi clock: ", err)
+			return err
+		}
+		return nil
+	})
+	return clock, err
+}
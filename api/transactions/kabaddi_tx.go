@@ -0,0 +1,335 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"khelogames/database"
+	"khelogames/database/models"
+	"time"
+
+	kabaddihelper "khelogames/api/sports/kabaddi_helper"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/google/uuid"
+)
+
+// StartKabaddiMatch locks the venue and opens the score of both teams
+func (store *SQLStore) StartKabaddiMatch(ctx context.Context, q *database.Queries, match *models.Match) error {
+	_, err := q.UpdateMatchLocationLocked(ctx, match.ID)
+	if err != nil {
+		store.logger.Error("Failed to update match location locked: ", err)
+		return err
+	}
+
+	for _, teamID := range []int32{match.HomeTeamID, match.AwayTeamID} {
+		score, err := q.NewKabaddiScore(ctx, int32(match.ID), teamID)
+		if err != nil {
+			store.logger.Error("Failed to add the kabaddi score: ", err)
+			return err
+		}
+
+		if store.scoreBroadcaster != nil {
+			err := store.scoreBroadcaster.BroadcastMatchEvent(ctx, "ADD_KABADDI_SCORE", map[string]interface{}{
+				"match_public_id": match.PublicID,
+				"score":           score,
+			})
+			if err != nil {
+				store.logger.Error("Failed to broadcast kabaddi event: ", err)
+			}
+		}
+	}
+	return nil
+}
+
+// KabaddiMatchResult returns the team with more points, nil for a tie
+func (store *SQLStore) KabaddiMatchResult(ctx context.Context, q *database.Queries, match *models.Match) (*int32, error) {
+	score, err := q.GetKabaddiMatchScore(ctx, match.PublicID, match.HomeTeamID, match.AwayTeamID)
+	if err != nil {
+		store.logger.Error("Failed to get kabaddi score: ", err)
+		return nil, err
+	}
+	if score.Home == nil || score.Away == nil {
+		return nil, nil
+	}
+
+	if score.Home.Points > score.Away.Points {
+		return &match.HomeTeamID, nil
+	} else if score.Away.Points > score.Home.Points {
+		return &match.AwayTeamID, nil
+	}
+	return nil, nil
+}
+
+// UpdateKabaddiStandings recomputes the tournament standing of both teams from their finished group
+// and league matches
+func (store *SQLStore) UpdateKabaddiStandings(ctx context.Context, q *database.Queries, match *models.Match) error {
+	for _, teamID := range []int32{match.HomeTeamID, match.AwayTeamID} {
+		results, err := q.GetKabaddiTeamTournamentResults(ctx, match.TournamentID, teamID)
+		if err != nil {
+			store.logger.Error("Failed to get kabaddi results: ", err)
+			return err
+		}
+
+		arg := database.UpdateKabaddiStandingParams{
+			TournamentID: match.TournamentID,
+			TeamID:       teamID,
+			Matches:      len(results),
+		}
+		for _, result := range results {
+			switch {
+			case result.PointsFor > result.PointsAgainst:
+				arg.Wins++
+			case result.PointsFor < result.PointsAgainst:
+				arg.Loss++
+			default:
+				arg.Draw++
+			}
+			arg.PointsFor += result.PointsFor
+			arg.PointsAgainst += result.PointsAgainst
+			arg.Points += kabaddihelper.LeaguePoints(result.PointsFor, result.PointsAgainst)
+		}
+
+		_, err = q.UpdateKabaddiStanding(ctx, arg)
+		if err != nil {
+			store.logger.Error("Failed to update kabaddi standing: ", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// FinishKabaddiMatch adds the raids and tackles of the finished match to the stats of every player
+// of both teams
+func (store *SQLStore) FinishKabaddiMatch(ctx context.Context, q *database.Queries, match *models.Match) error {
+	matchStats, err := q.GetKabaddiMatchPlayerStats(ctx, int32(match.ID))
+	if err != nil {
+		return fmt.Errorf("failed to get kabaddi match player stats: %w", err)
+	}
+
+	for _, teamID := range []int32{match.HomeTeamID, match.AwayTeamID} {
+		playerIDs, err := q.GetPlayerIDsByTeamID(ctx, teamID)
+		if err != nil {
+			return fmt.Errorf("failed to get team players: %w", err)
+		}
+
+		for _, playerID := range playerIDs {
+			_, err := q.AddOrUpdateKabaddiPlayerStats(ctx, playerID, matchStats[playerID])
+			if err != nil {
+				return fmt.Errorf("failed to upsert player %d stats: %w", playerID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// kabaddiCourtState replays the raids of the match
+func kabaddiCourtState(ctx context.Context, q *database.Queries, match *models.Match) (kabaddihelper.CourtState, error) {
+	raids, err := q.GetKabaddiRaids(ctx, int32(match.ID))
+	if err != nil {
+		return kabaddihelper.CourtState{}, err
+	}
+
+	played := make([]kabaddihelper.Raid, 0, len(raids))
+	for _, raid := range raids {
+		played = append(played, kabaddihelper.Raid{
+			Half:          raid.Half,
+			RaidingTeamID: raid.RaidingTeamID,
+			TouchPoints:   raid.TouchPoints,
+			BonusPoint:    raid.BonusPoint,
+			Tackled:       raid.Tackled,
+		})
+	}
+	return kabaddihelper.ReplayRaids(match.HomeTeamID, match.AwayTeamID, played), nil
+}
+
+type AddKabaddiRaidTxParams struct {
+	MatchPublicID       uuid.UUID
+	RaidingTeamPublicID uuid.UUID
+	RaiderPublicID      uuid.UUID
+	DefenderPublicID    *uuid.UUID
+	TouchPoints         int
+	BonusPoint          bool
+	Tackled             bool
+}
+
+// AddKabaddiRaidTx records a raid of the half being played and adds the points it won to both
+// teams. Returns the raid, the score of both teams and the state of the court after it.
+func (store *SQLStore) AddKabaddiRaidTx(ctx context.Context, arg AddKabaddiRaidTxParams) (*models.KabaddiRaid, *database.KabaddiMatchScore, *kabaddihelper.CourtState, error) {
+	var raid *models.KabaddiRaid
+	var score *database.KabaddiMatchScore
+	var court *kabaddihelper.CourtState
+
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		match, err := q.GetMatchModelByPublicId(ctx, arg.MatchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get match: ", err)
+			return err
+		}
+		if match.StatusCode != "in_progress" {
+			return errorhandler.NewFieldError("match_public_id", "Match is not in progress")
+		}
+
+		clock, err := q.GetKabaddiMatchClock(ctx, int32(match.ID))
+		if err != nil {
+			store.logger.Error("Failed to get kabaddi clock: ", err)
+			return err
+		}
+		if clock == nil || !kabaddihelper.IsPlayingHalf(clock.State) {
+			return errorhandler.NewFieldError("match_public_id", "Raids can only be added during a half")
+		}
+		if kabaddihelper.IsHalfOver(*clock, time.Now().Unix()) {
+			return errorhandler.NewFieldError("match_public_id", "Half is over")
+		}
+
+		team, err := q.GetTeamByPublicID(ctx, arg.RaidingTeamPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get team: ", err)
+			return err
+		}
+		raidingTeamID := int32(team.ID)
+		if raidingTeamID != match.HomeTeamID && raidingTeamID != match.AwayTeamID {
+			return errorhandler.NewFieldError("raiding_team_public_id", "Team is not playing this match")
+		}
+		defendingTeamID := match.HomeTeamID
+		if raidingTeamID == match.HomeTeamID {
+			defendingTeamID = match.AwayTeamID
+		}
+
+		raiderID, err := kabaddiTeamPlayer(ctx, q, arg.RaiderPublicID, raidingTeamID)
+		if err != nil {
+			return err
+		}
+		if raiderID == 0 {
+			return errorhandler.NewFieldError("raider_public_id", "Raider is not in the raiding team")
+		}
+
+		var defenderID *int32
+		if arg.DefenderPublicID != nil {
+			if !arg.Tackled {
+				return errorhandler.NewFieldError("defender_public_id", "Only a tackle is credited to a defender")
+			}
+			id, err := kabaddiTeamPlayer(ctx, q, *arg.DefenderPublicID, defendingTeamID)
+			if err != nil {
+				return err
+			}
+			if id == 0 {
+				return errorhandler.NewFieldError("defender_public_id", "Defender is not in the defending team")
+			}
+			defenderID = &id
+		}
+
+		before, err := kabaddiCourtState(ctx, q, match)
+		if err != nil {
+			store.logger.Error("Failed to get kabaddi court state: ", err)
+			return err
+		}
+
+		played := kabaddihelper.Raid{
+			Half:          clock.State,
+			RaidingTeamID: raidingTeamID,
+			TouchPoints:   arg.TouchPoints,
+			BonusPoint:    arg.BonusPoint,
+			Tackled:       arg.Tackled,
+		}
+		if msg := kabaddihelper.ValidateRaid(before, played); msg != "" {
+			return errorhandler.NewFieldError("raid", msg)
+		}
+
+		after, result := kabaddihelper.ApplyRaid(before, played)
+
+		raid, err = q.AddKabaddiRaid(ctx, database.AddKabaddiRaidParams{
+			MatchID:          int32(match.ID),
+			Half:             clock.State,
+			RaidNumber:       after.RaidNumber,
+			MatchMinute:      kabaddihelper.MatchMinute(*clock, time.Now().Unix()),
+			RaidingTeamID:    raidingTeamID,
+			RaiderPlayerID:   raiderID,
+			DefenderPlayerID: defenderID,
+			Outcome:          result.Outcome,
+			TouchPoints:      arg.TouchPoints,
+			BonusPoint:       arg.BonusPoint,
+			Tackled:          arg.Tackled,
+			SuperTackle:      result.SuperTackle,
+			DoOrDie:          result.DoOrDie,
+			AllOut:           result.AllOut,
+			RaidingPoints:    result.RaidingPoints,
+			DefendingPoints:  result.DefendingPoints,
+			HomeOnCourt:      after.Home.OnCourt,
+			AwayOnCourt:      after.Away.OnCourt,
+		})
+		if err != nil {
+			store.logger.Error("Failed to add kabaddi raid: ", err)
+			return err
+		}
+
+		// all-out points are kept apart from the raid and tackle points they came with
+		raidingAllOut, defendingAllOut := 0, 0
+		switch result.AllOutTeamID {
+		case defendingTeamID:
+			raidingAllOut = kabaddihelper.AllOutPoints
+		case raidingTeamID:
+			defendingAllOut = kabaddihelper.AllOutPoints
+		}
+
+		_, err = q.AddKabaddiScorePoints(ctx, int32(match.ID), raidingTeamID, result.RaidingPoints-raidingAllOut, 0, raidingAllOut)
+		if err != nil {
+			store.logger.Error("Failed to update kabaddi score: ", err)
+			return err
+		}
+		_, err = q.AddKabaddiScorePoints(ctx, int32(match.ID), defendingTeamID, 0, result.DefendingPoints-defendingAllOut, defendingAllOut)
+		if err != nil {
+			store.logger.Error("Failed to update kabaddi score: ", err)
+			return err
+		}
+
+		score, err = q.GetKabaddiMatchScore(ctx, match.PublicID, match.HomeTeamID, match.AwayTeamID)
+		if err != nil {
+			store.logger.Error("Failed to get kabaddi score: ", err)
+			return err
+		}
+		court = &after
+		return nil
+	})
+	return raid, score, court, err
+}
+
+// GetKabaddiCourtStateTx returns the state of the court after the raids played so far
+func (store *SQLStore) GetKabaddiCourtStateTx(ctx context.Context, matchPublicID uuid.UUID) (*kabaddihelper.CourtState, error) {
+	var court *kabaddihelper.CourtState
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		match, err := q.GetMatchModelByPublicId(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get match: ", err)
+			return err
+		}
+
+		state, err := kabaddiCourtState(ctx, q, match)
+		if err != nil {
+			store.logger.Error("Failed to get kabaddi court state: ", err)
+			return err
+		}
+		court = &state
+		return nil
+	})
+	return court, err
+}
+
+// kabaddiTeamPlayer returns the id of the player when they play for the team, 0 otherwise
+func kabaddiTeamPlayer(ctx context.Context, q *database.Queries, playerPublicID uuid.UUID, teamID int32) (int32, error) {
+	player, err := q.GetPlayer(ctx, playerPublicID)
+	if err != nil {
+		return 0, err
+	}
+	if player == nil {
+		return 0, nil
+	}
+
+	teamPlayerIDs, err := q.GetPlayerIDsByTeamID(ctx, teamID)
+	if err != nil {
+		return 0, err
+	}
+	if !containsPlayer(teamPlayerIDs, int32(player.ID)) {
+		return 0, nil
+	}
+	return int32(player.ID), nil
+}
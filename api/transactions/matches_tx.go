@@ -6,7 +6,9 @@ import (
 	"khelogames/database"
 	"khelogames/database/models"
 
-	footballhelper "khelogames/api/sports/football_helper"
+	"khelogames/api/sports"
+	crickethelper "khelogames/api/sports/cricket_helper"
+	errorhandler "khelogames/error_handler"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -32,8 +34,9 @@ import (
 // 	}
 // }
 
-// Update match status transaction
-func (store *SQLStore) UpdateMatchStatusTx(ctx *gin.Context, matchPublicID uuid.UUID, statusCode string, gameID models.Game) (*models.Match, error) {
+// Update match status transaction. Starting a match sets up its score, finishing it records the
+// result, the standings and whatever else the sport keeps for a finished match
+func (store *SQLStore) UpdateMatchStatusTx(ctx *gin.Context, matchPublicID uuid.UUID, statusCode string, sport sports.Sport) (*models.Match, error) {
 	var updatedMatchData *models.Match
 
 	err := store.execTx(ctx, func(q *database.Queries) error {
@@ -48,86 +51,29 @@ func (store *SQLStore) UpdateMatchStatusTx(ctx *gin.Context, matchPublicID uuid.
 		// Handle status-specific logic
 		switch updatedMatchData.StatusCode {
 		case "finished":
-			if gameID.Name == "football" {
-				_, err = q.AddORUpdateFootballPlayerStats(ctx, matchPublicID)
-				if err != nil {
-					return fmt.Errorf("Failed to update player stats: ", err)
-				}
-				if err := UpdateFootballStatusCode(ctx, updatedMatchData, gameID.ID, q, store); err != nil {
-					return fmt.Errorf("Failed to update football status code: ", err)
-				}
-
-				homeIncident, err := q.GetFootballIncidentByTeam(ctx, updatedMatchData.ID, updatedMatchData.HomeTeamID)
-				if err != nil {
-					return fmt.Errorf("Failed to football incident by team: ", err)
-				}
-
-				awayIncident, err := q.GetFootballIncidentByTeam(ctx, updatedMatchData.ID, updatedMatchData.AwayTeamID)
-				if err != nil {
-					return fmt.Errorf("Failed to football incident by team: ", err)
-				}
-
-				var homeCurrentStats map[string]interface{}
-				var awayCurrentStats map[string]interface{}
-				for _, incident := range *homeIncident {
-					homeCurrentStats = footballhelper.GetStatisticsUpdateFromIncident(homeCurrentStats, incident.IncidentType)
-				}
-
-				_, err = q.UpdateFootballStatistics(ctx,
-					int32(updatedMatchData.ID),
-					updatedMatchData.HomeTeamID,
-					footballhelper.GetInt32(homeCurrentStats["shot_on_target"]),
-					footballhelper.GetInt32(homeCurrentStats["total_shots"]),
-					footballhelper.GetInt32(homeCurrentStats["corner_kicks"]),
-					footballhelper.GetInt32(homeCurrentStats["fouls"]),
-					footballhelper.GetInt32(homeCurrentStats["goal_keeper_saves"]),
-					footballhelper.GetInt32(homeCurrentStats["free_kicks"]),
-					footballhelper.GetInt32(homeCurrentStats["yellow_cards"]),
-					footballhelper.GetInt32(homeCurrentStats["red_cards"]),
-				)
+			winnerTeamID, err := sport.MatchResult(ctx, q, updatedMatchData)
+			if err != nil {
+				return fmt.Errorf("Failed to get the %s match result: %w", sport.Name(), err)
+			}
+			if winnerTeamID != nil {
+				updatedMatchData, err = q.UpdateMatchResult(ctx, int32(updatedMatchData.ID), *winnerTeamID)
 				if err != nil {
-					return fmt.Errorf("Failed to update football statistics: ", err)
-				}
-
-				for _, incident := range *awayIncident {
-					awayCurrentStats = footballhelper.GetStatisticsUpdateFromIncident(awayCurrentStats, incident.IncidentType)
+					store.logger.Error("Failed to update match result: ", err)
+					return err
 				}
+			}
 
-				_, err = q.UpdateFootballStatistics(ctx,
-					int32(updatedMatchData.ID),
-					updatedMatchData.AwayTeamID,
-					footballhelper.GetInt32(awayCurrentStats["shot_on_target"]),
-					footballhelper.GetInt32(awayCurrentStats["total_shots"]),
-					footballhelper.GetInt32(awayCurrentStats["corner_kicks"]),
-					footballhelper.GetInt32(awayCurrentStats["fouls"]),
-					footballhelper.GetInt32(awayCurrentStats["goal_keeper_saves"]),
-					footballhelper.GetInt32(awayCurrentStats["free_kicks"]),
-					footballhelper.GetInt32(awayCurrentStats["yellow_cards"]),
-					footballhelper.GetInt32(awayCurrentStats["red_cards"]),
-				)
-				if err != nil {
-					return fmt.Errorf("Failed to update football statistics: ", err)
-				}
+			if err := sport.UpdateStandings(ctx, q, updatedMatchData); err != nil {
+				return fmt.Errorf("Failed to update the %s standings: %w", sport.Name(), err)
+			}
 
-			} else if gameID.Name == "cricket" {
-				if err := UpdateCricketStatusCode(ctx, updatedMatchData, gameID.ID, q, store); err != nil {
-					return fmt.Errorf("Failed to update cricket status code: ", err)
-				}
+			if err := sport.FinishMatch(ctx, q, updatedMatchData); err != nil {
+				return fmt.Errorf("Failed to finish the %s match: %w", sport.Name(), err)
 			}
 
 		case "in_progress":
-			if gameID.Name == "football" {
-				if err := UpdateFootballStatusCode(ctx, updatedMatchData, gameID.ID, q, store); err != nil {
-					return fmt.Errorf("Failed to initialize the football score: %w", err)
-				}
-			} else if gameID.Name == "cricket" {
-				if err := UpdateCricketStatusCode(ctx, updatedMatchData, gameID.ID, q, store); err != nil {
-					return fmt.Errorf("Failed to initialize the cricket score: %w", err)
-				}
-			} else if gameID.Name == "badminton" {
-				if err := UpdateBadmintonStatusCode(ctx, updatedMatchData, gameID.ID, q, store); err != nil {
-					return fmt.Errorf("Failed to initialize the badminton score: %w", err)
-				}
+			if err := sport.StartMatch(ctx, q, updatedMatchData); err != nil {
+				return fmt.Errorf("Failed to initialize the %s score: %w", sport.Name(), err)
 			}
 		}
 		return err
@@ -135,273 +81,357 @@ func (store *SQLStore) UpdateMatchStatusTx(ctx *gin.Context, matchPublicID uuid.
 	return updatedMatchData, err
 }
 
-func UpdateFootballStatusCode(ctx context.Context, updatedMatchData *models.Match, gameID int64, q *database.Queries, store *SQLStore) error {
+// StartFootballMatch locks the venue and opens the score and statistics of both teams
+func (store *SQLStore) StartFootballMatch(ctx context.Context, q *database.Queries, updatedMatchData *models.Match) error {
 	var ct *gin.Context
 
-	if updatedMatchData.StatusCode == "in_progress" {
+	//update location locked
+	_, err := q.UpdateMatchLocationLocked(ctx, updatedMatchData.ID)
+	if err != nil {
+		store.logger.Error("Failed to update match location locked: ", err)
+		return err
+	}
 
-		//update location locked
-		_, err := q.UpdateMatchLocationLocked(ctx, updatedMatchData.ID)
-		if err != nil {
-			store.logger.Error("Failed to update match location locked: ", err)
-			return err
-		}
+	var penaltyShootOut *int
+	argAway := database.NewFootballScoreParams{
+		MatchID:         int32(updatedMatchData.ID),
+		TeamID:          int32(updatedMatchData.AwayTeamID),
+		FirstHalf:       0,
+		SecondHalf:      0,
+		Goals:           0,
+		PenaltyShootOut: penaltyShootOut,
+	}
 
-		var penaltyShootOut *int
-		argAway := database.NewFootballScoreParams{
-			MatchID:         int32(updatedMatchData.ID),
-			TeamID:          int32(updatedMatchData.AwayTeamID),
-			FirstHalf:       0,
-			SecondHalf:      0,
-			Goals:           0,
-			PenaltyShootOut: penaltyShootOut,
-		}
+	awayScoreData, err := q.NewFootballScore(ctx, argAway)
+	if err != nil {
+		store.logger.Error("unable to add the football match score: ", err)
+		return err
+	}
 
-		awayScoreData, err := q.NewFootballScore(ctx, argAway)
+	awayScore := map[string]interface{}{
+		"id":               awayScoreData.ID,
+		"public_id":        awayScoreData.PublicID,
+		"match_id":         awayScoreData.MatchID,
+		"team_id":          awayScoreData.TeamID,
+		"first_half":       awayScoreData.FirstHalf,
+		"second_half":      awayScoreData.SecondHalf,
+		"penalty_shootout": awayScoreData.PenaltyShootOut,
+	}
+
+	if store.scoreBroadcaster != nil {
+		err := store.scoreBroadcaster.BroadcastTournamentEvent(ct, "ADD_FOOTBALL_SCORE", awayScore)
 		if err != nil {
-			store.logger.Error("unable to add the football match score: ", err)
-			return err
+			store.logger.Error("Failed to broadcast football event: ", err)
 		}
+	}
 
-		awayScore := map[string]interface{}{
-			"id":               awayScoreData.ID,
-			"public_id":        awayScoreData.PublicID,
-			"match_id":         awayScoreData.MatchID,
-			"team_id":          awayScoreData.TeamID,
-			"first_half":       awayScoreData.FirstHalf,
-			"second_half":      awayScoreData.SecondHalf,
-			"penalty_shootout": awayScoreData.PenaltyShootOut,
-		}
+	argHome := database.NewFootballScoreParams{
+		MatchID:         int32(updatedMatchData.ID),
+		TeamID:          int32(updatedMatchData.HomeTeamID),
+		FirstHalf:       0,
+		SecondHalf:      0,
+		Goals:           0,
+		PenaltyShootOut: penaltyShootOut,
+	}
 
-		if store.scoreBroadcaster != nil {
-			err := store.scoreBroadcaster.BroadcastTournamentEvent(ct, "ADD_FOOTBALL_SCORE", awayScore)
-			if err != nil {
-				store.logger.Error("Failed to broadcast football event: ", err)
-			}
-		}
+	homeScoreData, err := q.NewFootballScore(ctx, argHome)
+	if err != nil {
+		store.logger.Error("unable to add the football match score: ", err)
+		return err
+	}
 
-		argHome := database.NewFootballScoreParams{
-			MatchID:         int32(updatedMatchData.ID),
-			TeamID:          int32(updatedMatchData.HomeTeamID),
-			FirstHalf:       0,
-			SecondHalf:      0,
-			Goals:           0,
-			PenaltyShootOut: penaltyShootOut,
-		}
+	homeScore := map[string]interface{}{
+		"id":               homeScoreData.ID,
+		"public_id":        homeScoreData.PublicID,
+		"match_id":         homeScoreData.MatchID,
+		"team_id":          homeScoreData.TeamID,
+		"first_half":       homeScoreData.FirstHalf,
+		"second_half":      homeScoreData.SecondHalf,
+		"penalty_shootout": homeScoreData.PenaltyShootOut,
+	}
 
-		homeScoreData, err := q.NewFootballScore(ctx, argHome)
+	if store.scoreBroadcaster != nil {
+		err := store.scoreBroadcaster.BroadcastTournamentEvent(ct, "ADD_FOOTBALL_SCORE", homeScore)
 		if err != nil {
-			store.logger.Error("unable to add the football match score: ", err)
-			return err
+			store.logger.Error("Failed to broadcast football event: ", err)
 		}
+	}
 
-		homeScore := map[string]interface{}{
-			"id":               homeScoreData.ID,
-			"public_id":        homeScoreData.PublicID,
-			"match_id":         homeScoreData.MatchID,
-			"team_id":          homeScoreData.TeamID,
-			"first_half":       homeScoreData.FirstHalf,
-			"second_half":      homeScoreData.SecondHalf,
-			"penalty_shootout": homeScoreData.PenaltyShootOut,
-		}
+	argStatisticsHome := database.CreateFootballStatisticsParams{
+		MatchID:         int32(updatedMatchData.ID),
+		TeamID:          int32(updatedMatchData.HomeTeamID),
+		ShotsOnTarget:   0,
+		TotalShots:      0,
+		CornerKicks:     0,
+		Fouls:           0,
+		GoalkeeperSaves: 0,
+		FreeKicks:       0,
+		YellowCards:     0,
+		RedCards:        0,
+	}
 
-		if store.scoreBroadcaster != nil {
-			err := store.scoreBroadcaster.BroadcastTournamentEvent(ct, "ADD_FOOTBALL_SCORE", homeScore)
-			if err != nil {
-				store.logger.Error("Failed to broadcast football event: ", err)
-			}
-		}
+	argStatisticsAway := database.CreateFootballStatisticsParams{
+		MatchID:         int32(updatedMatchData.ID),
+		TeamID:          int32(updatedMatchData.AwayTeamID),
+		ShotsOnTarget:   0,
+		TotalShots:      0,
+		CornerKicks:     0,
+		Fouls:           0,
+		GoalkeeperSaves: 0,
+		FreeKicks:       0,
+		YellowCards:     0,
+		RedCards:        0,
+	}
 
-		argStatisticsHome := database.CreateFootballStatisticsParams{
-			MatchID:         int32(updatedMatchData.ID),
-			TeamID:          int32(updatedMatchData.HomeTeamID),
-			ShotsOnTarget:   0,
-			TotalShots:      0,
-			CornerKicks:     0,
-			Fouls:           0,
-			GoalkeeperSaves: 0,
-			FreeKicks:       0,
-			YellowCards:     0,
-			RedCards:        0,
-		}
+	_, err = q.CreateFootballStatistics(ctx, argStatisticsHome)
+	if err != nil {
+		store.logger.Error("Failed to add the football statistics: ", err)
+		return err
+	}
 
-		argStatisticsAway := database.CreateFootballStatisticsParams{
-			MatchID:         int32(updatedMatchData.ID),
-			TeamID:          int32(updatedMatchData.AwayTeamID),
-			ShotsOnTarget:   0,
-			TotalShots:      0,
-			CornerKicks:     0,
-			Fouls:           0,
-			GoalkeeperSaves: 0,
-			FreeKicks:       0,
-			YellowCards:     0,
-			RedCards:        0,
-		}
+	_, err = q.CreateFootballStatistics(ctx, argStatisticsAway)
+	if err != nil {
+		store.logger.Error("Failed to add the football statistics: ", err)
+		return err
+	}
+	return nil
+}
 
-		_, err = q.CreateFootballStatistics(ctx, argStatisticsHome)
-		if err != nil {
-			store.logger.Error("Failed to add the football statistics: ", err)
-			return err
-		}
+// FootballMatchResult returns the team with more goals, the winner of the shootout when the match
+// was drawn and decided on penalties
+func (store *SQLStore) FootballMatchResult(ctx context.Context, q *database.Queries, updatedMatchData *models.Match) (*int32, error) {
+	argAway := database.GetFootballScoreParams{
+		MatchID: updatedMatchData.ID,
+		TeamID:  int64(updatedMatchData.AwayTeamID),
+	}
 
-		_, err = q.CreateFootballStatistics(ctx, argStatisticsAway)
-		if err != nil {
-			store.logger.Error("Failed to add the football statistics: ", err)
-			return err
-		}
-	} else if updatedMatchData.StatusCode == "finished" {
+	awayScore, err := q.GetFootballScore(ctx, argAway)
+	if err != nil {
+		store.logger.Error("Failed to get away score: ", err)
+		return nil, err
+	}
 
-		argAway := database.GetFootballScoreParams{
-			MatchID: updatedMatchData.ID,
-			TeamID:  int64(updatedMatchData.AwayTeamID),
-		}
+	argHome := database.GetFootballScoreParams{
+		MatchID: updatedMatchData.ID,
+		TeamID:  int64(updatedMatchData.HomeTeamID),
+	}
 
-		awayScore, err := q.GetFootballScore(ctx, argAway)
-		if err != nil {
-			store.logger.Error("Failed to get away score: ", err)
-			return err
-		}
+	homeScore, err := q.GetFootballScore(ctx, argHome)
+	if err != nil {
+		store.logger.Error("Failed to get home score: ", err)
+		return nil, err
+	}
 
-		argHome := database.GetFootballScoreParams{
-			MatchID: updatedMatchData.ID,
-			TeamID:  int64(updatedMatchData.HomeTeamID),
-		}
+	if awayScore.Goals > homeScore.Goals {
+		return &updatedMatchData.AwayTeamID, nil
+	} else if homeScore.Goals > awayScore.Goals {
+		return &updatedMatchData.HomeTeamID, nil
+	}
 
-		homeScore, err := q.GetFootballScore(ctx, argHome)
-		if err != nil {
-			store.logger.Error("Failed to get away score: ", err)
-			return err
-		}
+	// a drawn match decided on penalties goes to the winner of the shootout
+	state, err := footballShootoutState(ctx, q, updatedMatchData)
+	if err != nil {
+		store.logger.Error("Failed to get shootout kicks: ", err)
+		return nil, err
+	}
+	if state.Finished {
+		return &state.WinnerTeamID, nil
+	}
+	return nil, nil
+}
 
-		if awayScore.Goals > homeScore.Goals {
-			_, err := q.UpdateMatchResult(ctx, int32(updatedMatchData.ID), int32(updatedMatchData.AwayTeamID))
-			if err != nil {
-				store.logger.Error("Failed to update match result: ", err)
-				return err
-			}
-			_, err = q.UpdateFootballStanding(ctx, int64(updatedMatchData.TournamentID), int64(updatedMatchData.HomeTeamID))
-			if err != nil {
-				store.logger.Error("Failed to update football standing: ", err)
-				return err
-			}
+// UpdateFootballStandings updates the tournament standing of both teams
+func (store *SQLStore) UpdateFootballStandings(ctx context.Context, q *database.Queries, updatedMatchData *models.Match) error {
+	_, err := q.UpdateFootballStanding(ctx, int64(updatedMatchData.TournamentID), int64(updatedMatchData.HomeTeamID))
+	if err != nil {
+		store.logger.Error("Failed to update football standing: ", err)
+		return err
+	}
 
-			_, err = q.UpdateFootballStanding(ctx, int64(updatedMatchData.TournamentID), int64(updatedMatchData.AwayTeamID))
-			if err != nil {
-				store.logger.Error("Failed to update football standing: ", err)
-				return err
-			}
-		} else if homeScore.Goals > awayScore.Goals {
-			_, err := q.UpdateMatchResult(ctx, int32(updatedMatchData.ID), int32(updatedMatchData.HomeTeamID))
-			if err != nil {
-				store.logger.Error("Failed to update match result: ", err)
-				return err
-			}
+	_, err = q.UpdateFootballStanding(ctx, int64(updatedMatchData.TournamentID), int64(updatedMatchData.AwayTeamID))
+	if err != nil {
+		store.logger.Error("Failed to update football standing: ", err)
+		return err
+	}
+	return nil
+}
 
-			_, err = q.UpdateFootballStanding(ctx, int64(updatedMatchData.TournamentID), int64(updatedMatchData.HomeTeamID))
-			if err != nil {
-				store.logger.Error("Failed to update football standing: ", err)
-				return err
-			}
+// FinishFootballMatch records the player stats, ratings, team statistics and suspensions of the
+// finished match
+func (store *SQLStore) FinishFootballMatch(ctx context.Context, q *database.Queries, updatedMatchData *models.Match) error {
+	_, err := q.AddORUpdateFootballPlayerStats(ctx, updatedMatchData.PublicID)
+	if err != nil {
+		return fmt.Errorf("Failed to update player stats: %w", err)
+	}
 
-			_, err = q.UpdateFootballStanding(ctx, int64(updatedMatchData.TournamentID), int64(updatedMatchData.AwayTeamID))
-			if err != nil {
-				store.logger.Error("Failed to update football standing: ", err)
-				return err
-			}
-		}
+	if err := store.updateFootballPlayerRatings(ctx, q, updatedMatchData); err != nil {
+		return err
+	}
+
+	homeCurrentStats, err := footballTeamIncidentStatistics(ctx, q, updatedMatchData.ID, updatedMatchData.HomeTeamID)
+	if err != nil {
+		return fmt.Errorf("Failed to football incident by team: %w", err)
+	}
+
+	awayCurrentStats, err := footballTeamIncidentStatistics(ctx, q, updatedMatchData.ID, updatedMatchData.AwayTeamID)
+	if err != nil {
+		return fmt.Errorf("Failed to football incident by team: %w", err)
+	}
+
+	err = applyFootballStatistics(ctx, q, int32(updatedMatchData.ID), updatedMatchData.HomeTeamID, homeCurrentStats, 1)
+	if err != nil {
+		return fmt.Errorf("Failed to update football statistics: %w", err)
+	}
+
+	err = applyFootballStatistics(ctx, q, int32(updatedMatchData.ID), updatedMatchData.AwayTeamID, awayCurrentStats, 1)
+	if err != nil {
+		return fmt.Errorf("Failed to update football statistics: %w", err)
+	}
+
+	if err := store.updateFootballSuspensions(ctx, q, updatedMatchData); err != nil {
+		return fmt.Errorf("Failed to update football suspensions: %w", err)
 	}
 	return nil
 }
 
-func UpdateCricketStatusCode(ctx context.Context, updatedMatchData *models.Match, gameID int64, q *database.Queries, store *SQLStore) error {
-	if updatedMatchData.StatusCode == "finished" {
+// CricketMatchResult decides the finished match: a multi day match on its innings, a tied knockout
+// on its latest super over, a shortened match on the revised target and otherwise on runs. A drawn
+// or tied match records no winning team, but a tied knockout can only finish once a super over has
+// decided it.
+func (store *SQLStore) CricketMatchResult(ctx context.Context, q *database.Queries, updatedMatchData *models.Match) (*int32, error) {
+	inningScores, err := q.GetCricketScores(ctx, int32(updatedMatchData.ID))
+	if err != nil {
+		store.logger.Error("Failed to get inning scores: ", err)
+		return nil, err
+	}
 
-		awayScore, err := q.GetCricketScore(ctx, int32(updatedMatchData.ID), int32(updatedMatchData.AwayTeamID))
-		if err != nil {
-			store.logger.Error("Failed to get away score: ", err)
-			return err
+	if crickethelper.IsMultiDayFormat(updatedMatchData.MatchFormat) {
+		// finishing a multi day match ends play, an undecided match is drawn
+		result := crickethelper.DecideMultiDayMatch(crickethelper.InningSummaries(inningScores), true)
+		if !result.Decided || result.WinnerTeamID == 0 {
+			return nil, nil
 		}
+		return &result.WinnerTeamID, nil
+	}
 
-		homeScore, err := q.GetCricketScore(ctx, int32(updatedMatchData.ID), int32(updatedMatchData.HomeTeamID))
-		if err != nil {
-			store.logger.Error("Failed to get away score: ", err)
-			return err
+	// a tied knockout which went to super overs is decided by the latest completed one
+	if len(inningScores) > 2 {
+		result := crickethelper.DecideSuperOver(crickethelper.InningSummaries(inningScores))
+		if !result.Decided || result.WinnerTeamID == 0 {
+			return nil, errorhandler.NewFieldError("status_code", "Super over has not decided the match yet")
 		}
+		return &result.WinnerTeamID, nil
+	}
 
-		if awayScore.Score > homeScore.Score {
-			_, err := q.UpdateMatchResult(ctx, int32(updatedMatchData.ID), int32(updatedMatchData.AwayTeamID))
-			if err != nil {
-				store.logger.Error("Failed to update match result: ", err)
-				return err
-			}
-			_, err = q.UpdateCricketStanding(ctx, int32(updatedMatchData.TournamentID), int32(updatedMatchData.AwayTeamID))
-			if err != nil {
-				store.logger.Error("Failed to update tournament standing: ", err)
-				return err
-			}
-			_, err = q.UpdateCricketStanding(ctx, int32(updatedMatchData.TournamentID), int32(updatedMatchData.HomeTeamID))
-			if err != nil {
-				store.logger.Error("Failed to update tournament standing: ", err)
-				return err
-			}
-		} else if homeScore.Score > awayScore.Score {
-			_, err := q.UpdateMatchResult(ctx, int32(updatedMatchData.ID), int32(updatedMatchData.HomeTeamID))
-			if err != nil {
-				store.logger.Error("Failed to update match result: ", err)
-				return err
-			}
-			_, err = q.UpdateCricketStanding(ctx, int32(updatedMatchData.TournamentID), int32(updatedMatchData.AwayTeamID))
-			if err != nil {
-				store.logger.Error("Failed to update tournament standing: ", err)
-				return err
-			}
-			_, err = q.UpdateCricketStanding(ctx, int32(updatedMatchData.TournamentID), int32(updatedMatchData.HomeTeamID))
-			if err != nil {
-				store.logger.Error("Failed to update tournament standing: ", err)
-				return err
-			}
+	awayScore, err := q.GetCricketScore(ctx, int32(updatedMatchData.ID), int32(updatedMatchData.AwayTeamID))
+	if err != nil {
+		store.logger.Error("Failed to get away score: ", err)
+		return nil, err
+	}
+
+	homeScore, err := q.GetCricketScore(ctx, int32(updatedMatchData.ID), int32(updatedMatchData.HomeTeamID))
+	if err != nil {
+		store.logger.Error("Failed to get home score: ", err)
+		return nil, err
+	}
+
+	revision, err := store.refreshCricketRevisedTarget(ctx, q, updatedMatchData)
+	if err != nil {
+		store.logger.Error("Failed to get revised target: ", err)
+		return nil, err
+	}
+
+	// a shortened match is decided on the revised target of the chasing side
+	if revision != nil && revision.RevisedTarget > 0 && awayScore != nil && homeScore != nil {
+		chaseScore, defendScore := awayScore, homeScore
+		if homeScore.InningNumber > awayScore.InningNumber {
+			chaseScore, defendScore = homeScore, awayScore
 		}
+
+		var winnerTeamID int32
+		switch crickethelper.ResultByRevisedTarget(revision.RevisedTarget, revision.ParScore, chaseScore.Score, chaseScore.IsInningCompleted) {
+		case crickethelper.ChaseWon:
+			winnerTeamID = chaseScore.TeamID
+		case crickethelper.ChaseLost:
+			winnerTeamID = defendScore.TeamID
+		}
+		if winnerTeamID == 0 {
+			return nil, cricketTieResult(updatedMatchData)
+		}
+		return &winnerTeamID, nil
+	}
+
+	if awayScore.Score > homeScore.Score {
+		return &updatedMatchData.AwayTeamID, nil
+	} else if homeScore.Score > awayScore.Score {
+		return &updatedMatchData.HomeTeamID, nil
+	}
+	return nil, cricketTieResult(updatedMatchData)
+}
+
+// cricketTieResult lets a tied match finish without a winner, except a knockout which goes to a
+// super over first
+func cricketTieResult(match *models.Match) error {
+	if match.Stage == "knockout" {
+		return errorhandler.NewFieldError("status_code", "Tied knockout match must be decided by a super over")
 	}
 	return nil
 }
 
-func UpdateBadmintonStatusCode(ctx context.Context, updatedMatchData *models.Match, gameID int64, q *database.Queries, store *SQLStore) error {
+// UpdateCricketStandings updates the tournament standing of both teams
+func (store *SQLStore) UpdateCricketStandings(ctx context.Context, q *database.Queries, updatedMatchData *models.Match) error {
+	_, err := q.UpdateCricketStanding(ctx, int32(updatedMatchData.TournamentID), int32(updatedMatchData.AwayTeamID))
+	if err != nil {
+		store.logger.Error("Failed to update tournament standing: ", err)
+		return err
+	}
+	_, err = q.UpdateCricketStanding(ctx, int32(updatedMatchData.TournamentID), int32(updatedMatchData.HomeTeamID))
+	if err != nil {
+		store.logger.Error("Failed to update tournament standing: ", err)
+		return err
+	}
+	return nil
+}
+
+// StartBadmintonMatch locks the venue and opens the first set from the handicaps of the match
+func (store *SQLStore) StartBadmintonMatch(ctx context.Context, q *database.Queries, updatedMatchData *models.Match) error {
 	var ct *gin.Context
 
-	if updatedMatchData.StatusCode == "in_progress" {
+	//update location locked
+	_, err := q.UpdateMatchLocationLocked(ctx, updatedMatchData.ID)
+	if err != nil {
+		store.logger.Error("Failed to update match location locked: ", err)
+		return err
+	}
 
-		//update location locked
-		_, err := q.UpdateMatchLocationLocked(ctx, updatedMatchData.ID)
-		if err != nil {
-			store.logger.Error("Failed to update match location locked: ", err)
-			return err
-		}
+	rules, err := badmintonMatchRules(ctx, q, updatedMatchData)
+	if err != nil {
+		store.logger.Error("Failed to get badminton scoring rules: ", err)
+		return err
+	}
 
-		badmintonScore, err := q.AddBadmintonScore(ctx, int32(updatedMatchData.ID), 1)
-		if err != nil {
-			store.logger.Error("unable to add the badminton match score: ", err)
-			return err
-		}
+	badmintonScore, err := q.AddBadmintonScore(ctx, int32(updatedMatchData.ID), 1, rules.HomeHandicap, rules.AwayHandicap)
+	if err != nil {
+		store.logger.Error("unable to add the badminton match score: ", err)
+		return err
+	}
 
-		score := map[string]interface{}{
-			"public_id":       badmintonScore.PublicID,
-			"match_public_id": updatedMatchData.PublicID,
-			"set_number":      badmintonScore.SetNumber,
-			"home_score":      badmintonScore.HomeScore,
-			"away_score":      badmintonScore.AwayScore,
-			"set_status":      badmintonScore.SetStatus,
-			"created_at":      badmintonScore.CreatedAt,
-		}
+	score := map[string]interface{}{
+		"public_id":       badmintonScore.PublicID,
+		"match_public_id": updatedMatchData.PublicID,
+		"set_number":      badmintonScore.SetNumber,
+		"home_score":      badmintonScore.HomeScore,
+		"away_score":      badmintonScore.AwayScore,
+		"set_status":      badmintonScore.SetStatus,
+		"created_at":      badmintonScore.CreatedAt,
+	}
 
-		if store.scoreBroadcaster != nil {
-			err := store.scoreBroadcaster.BroadcastTournamentEvent(ct, "ADD_BADMINTON_SCORE", score)
-			if err != nil {
-				store.logger.Error("Failed to broadcast badminton event: ", err)
-			}
+	if store.scoreBroadcaster != nil {
+		err := store.scoreBroadcaster.BroadcastTournamentEvent(ct, "ADD_BADMINTON_SCORE", score)
+		if err != nil {
+			store.logger.Error("Failed to broadcast badminton event: ", err)
 		}
 	}
-	// Note: badminton match finish is handled in badminton_tx.go (UpdateBadmintonScoreTx)
-	// when a player wins enough sets, not via manual status update.
 	return nil
 }
 
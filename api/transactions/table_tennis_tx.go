@@ -0,0 +1,235 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"khelogames/database"
+	"khelogames/database/models"
+
+	tabletennishelper "khelogames/api/sports/table_tennis_helper"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/google/uuid"
+)
+
+// StartTableTennisMatch locks the venue and opens the first game
+func (store *SQLStore) StartTableTennisMatch(ctx context.Context, q *database.Queries, match *models.Match) error {
+	_, err := q.UpdateMatchLocationLocked(ctx, match.ID)
+	if err != nil {
+		store.logger.Error("Failed to update match location locked: ", err)
+		return err
+	}
+
+	tableTennisScore, err := q.AddTableTennisScore(ctx, int32(match.ID), 1)
+	if err != nil {
+		store.logger.Error("Failed to add the table tennis score: ", err)
+		return err
+	}
+
+	if store.scoreBroadcaster != nil {
+		err := store.scoreBroadcaster.BroadcastMatchEvent(ctx, "ADD_TABLE_TENNIS_SCORE", buildTableTennisSetScore(tableTennisScore, match.PublicID))
+		if err != nil {
+			store.logger.Error("Failed to broadcast table tennis event: ", err)
+		}
+	}
+	return nil
+}
+
+// UpdateTableTennisScoreTx adds a point to the scoring team. A point that wins the game opens the
+// next one, or decides the match and adds it to the player stats. Returns who serves the next
+// point, nil once the match is over.
+func (store *SQLStore) UpdateTableTennisScoreTx(ctx context.Context, matchPublicID, teamPublicID uuid.UUID, setNumber int) (*models.Match, map[string]interface{}, *models.TableTennisPoint, *models.TableTennisScore, *tabletennishelper.ServeState, error) {
+	var matchResult *models.Match
+	var setScore map[string]interface{}
+	var point *models.TableTennisPoint
+	var newSet *models.TableTennisScore
+	var serve *tabletennishelper.ServeState
+
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		rules := tabletennishelper.DefaultRules
+
+		match, err := q.GetMatchModelByPublicId(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get match: ", err)
+			return err
+		}
+		if match.StatusCode != "in_progress" {
+			return errorhandler.NewFieldError("match_public_id", "Match is not in progress")
+		}
+
+		team, err := q.GetTeamByPublicID(ctx, teamPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get team: ", err)
+			return err
+		}
+		teamID := int32(team.ID)
+		if teamID != match.HomeTeamID && teamID != match.AwayTeamID {
+			return errorhandler.NewFieldError("team_public_id", "Team is not playing this match")
+		}
+
+		current, err := q.GetTableTennisSetScore(ctx, int32(match.ID), setNumber)
+		if err != nil {
+			store.logger.Error("Failed to get table tennis game: ", err)
+			return err
+		}
+		if current == nil {
+			return errorhandler.NewFieldError("set_number", "Game has not started")
+		}
+		if current.SetStatus == "finished" {
+			return errorhandler.NewFieldError("set_number", "Game is already finished")
+		}
+
+		server := tabletennishelper.Serve(rules, setNumber, match.HomeTeamID, match.AwayTeamID, current.HomeScore, current.AwayScore)
+
+		updatedScore, err := q.UpdateTableTennisScore(ctx, int32(match.ID), setNumber, teamID)
+		if err != nil {
+			store.logger.Error("Failed to update table tennis score: ", err)
+			return err
+		}
+
+		point, err = q.AddTableTennisPoint(ctx, database.AddTableTennisPointParams{
+			MatchID:       int32(match.ID),
+			SetNumber:     setNumber,
+			ScoringTeamID: teamID,
+			ServerTeamID:  server.ServerTeamID,
+			HomeScore:     updatedScore.HomeScore,
+			AwayScore:     updatedScore.AwayScore,
+			PointNumber:   current.HomeScore + current.AwayScore + 1,
+		})
+		if err != nil {
+			store.logger.Error("Failed to add table tennis point: ", err)
+			return err
+		}
+
+		scorerScore, otherScore := updatedScore.HomeScore, updatedScore.AwayScore
+		if teamID == match.AwayTeamID {
+			scorerScore, otherScore = otherScore, scorerScore
+		}
+
+		if !tabletennishelper.IsSetFinished(rules, updatedScore.HomeScore, updatedScore.AwayScore) {
+			next := tabletennishelper.Serve(rules, setNumber, match.HomeTeamID, match.AwayTeamID, updatedScore.HomeScore, updatedScore.AwayScore)
+			next.EndChange = tabletennishelper.IsEndChange(rules, setNumber, scorerScore, otherScore)
+			serve = &next
+			setScore = buildTableTennisSetScore(updatedScore, matchPublicID)
+			return nil
+		}
+
+		updatedScore, err = q.UpdateTableTennisSetStatus(ctx, int32(match.ID), setNumber, "finished")
+		if err != nil {
+			store.logger.Error("Failed to update table tennis game status: ", err)
+			return err
+		}
+		setScore = buildTableTennisSetScore(updatedScore, matchPublicID)
+
+		sets, err := q.GetTableTennisMatchSetsScore(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get table tennis games: ", err)
+			return err
+		}
+
+		var homeSetsWon, awaySetsWon int
+		for _, set := range sets {
+			if set.SetStatus != "finished" {
+				continue
+			}
+			if set.HomeScore > set.AwayScore {
+				homeSetsWon++
+			} else {
+				awaySetsWon++
+			}
+		}
+
+		if homeSetsWon < rules.SetsToWin && awaySetsWon < rules.SetsToWin {
+			newSet, err = q.AddTableTennisScore(ctx, int32(match.ID), setNumber+1)
+			if err != nil {
+				store.logger.Error("Failed to add next table tennis game: ", err)
+				return err
+			}
+			next := tabletennishelper.Serve(rules, setNumber+1, match.HomeTeamID, match.AwayTeamID, 0, 0)
+			serve = &next
+			return nil
+		}
+
+		winnerTeamID := match.HomeTeamID
+		if awaySetsWon > homeSetsWon {
+			winnerTeamID = match.AwayTeamID
+		}
+
+		matchResult, err = q.UpdateMatchResult(ctx, int32(match.ID), winnerTeamID)
+		if err != nil {
+			store.logger.Error("Failed to update match result: ", err)
+			return err
+		}
+
+		if err := updateTableTennisStatsOnFinish(ctx, q, match, sets, winnerTeamID, homeSetsWon, awaySetsWon); err != nil {
+			store.logger.Error("Failed to update table tennis player stats: ", err)
+			return err
+		}
+		return nil
+	})
+
+	return matchResult, setScore, point, newSet, serve, err
+}
+
+func buildTableTennisSetScore(score *models.TableTennisScore, matchPublicID uuid.UUID) map[string]interface{} {
+	return map[string]interface{}{
+		"public_id":       score.PublicID,
+		"match_public_id": matchPublicID,
+		"set_number":      score.SetNumber,
+		"home_score":      score.HomeScore,
+		"away_score":      score.AwayScore,
+		"set_status":      score.SetStatus,
+	}
+}
+
+// updateTableTennisStatsOnFinish adds the finished match to the stats of every player of both teams
+func updateTableTennisStatsOnFinish(ctx context.Context, q *database.Queries, match *models.Match, sets []models.TableTennisScore, winnerTeamID int32, homeSetsWon, awaySetsWon int) error {
+	homePoints, awayPoints := 0, 0
+	for _, set := range sets {
+		homePoints += set.HomeScore
+		awayPoints += set.AwayScore
+	}
+
+	playType := "singles"
+	if match.Type == "double" {
+		playType = "doubles"
+	}
+
+	sides := []struct {
+		teamID                 int32
+		setsWon, setsLost      int
+		pointsScored, conceded int
+	}{
+		{match.HomeTeamID, homeSetsWon, awaySetsWon, homePoints, awayPoints},
+		{match.AwayTeamID, awaySetsWon, homeSetsWon, awayPoints, homePoints},
+	}
+
+	for _, side := range sides {
+		won := 0
+		if side.teamID == winnerTeamID {
+			won = 1
+		}
+
+		playerIDs, err := q.GetPlayerIDsByTeamID(ctx, side.teamID)
+		if err != nil {
+			return fmt.Errorf("failed to get team players: %w", err)
+		}
+
+		for _, playerID := range playerIDs {
+			_, err := q.AddOrUpdateTableTennisPlayerStats(ctx, database.AddOrUpdateTableTennisPlayerStatsParams{
+				PlayerID:       playerID,
+				PlayType:       playType,
+				Wins:           won,
+				Losses:         1 - won,
+				SetsWon:        side.setsWon,
+				SetsLost:       side.setsLost,
+				PointsScored:   side.pointsScored,
+				PointsConceded: side.conceded,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to upsert player %d stats: %w", playerID, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,388 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+	"khelogames/database"
+	"khelogames/database/models"
+	"sort"
+
+	volleyballhelper "khelogames/api/sports/volleyball_helper"
+	errorhandler "khelogames/error_handler"
+
+	"github.com/google/uuid"
+)
+
+// StartVolleyballMatch locks the venue and opens the first set
+func (store *SQLStore) StartVolleyballMatch(ctx context.Context, q *database.Queries, match *models.Match) error {
+	_, err := q.UpdateMatchLocationLocked(ctx, match.ID)
+	if err != nil {
+		store.logger.Error("Failed to update match location locked: ", err)
+		return err
+	}
+
+	volleyballScore, err := q.AddVolleyballScore(ctx, int32(match.ID), 1)
+	if err != nil {
+		store.logger.Error("Failed to add the volleyball score: ", err)
+		return err
+	}
+
+	if store.scoreBroadcaster != nil {
+		err := store.scoreBroadcaster.BroadcastMatchEvent(ctx, "ADD_VOLLEYBALL_SCORE", buildVolleyballSetScore(volleyballScore, match.PublicID))
+		if err != nil {
+			store.logger.Error("Failed to broadcast volleyball event: ", err)
+		}
+	}
+	return nil
+}
+
+// volleyballLineup returns the starting lineup of a team in a set. Without one set for the set the
+// first six players of the team start, in the order they joined.
+func volleyballLineup(ctx context.Context, q *database.Queries, matchID, teamID int32, setNumber int) (volleyballhelper.Lineup, error) {
+	playerIDs, err := q.GetVolleyballLineup(ctx, matchID, teamID, setNumber)
+	if err != nil {
+		return volleyballhelper.Lineup{}, err
+	}
+
+	if len(playerIDs) == 0 {
+		playerIDs, err = q.GetPlayerIDsByTeamID(ctx, teamID)
+		if err != nil {
+			return volleyballhelper.Lineup{}, err
+		}
+		sort.Slice(playerIDs, func(i, j int) bool { return playerIDs[i] < playerIDs[j] })
+		if len(playerIDs) > volleyballhelper.CourtPositions {
+			playerIDs = playerIDs[:volleyballhelper.CourtPositions]
+		}
+	}
+	return volleyballhelper.Lineup{TeamID: teamID, Players: playerIDs}, nil
+}
+
+// volleyballRotationState replays the rallies of a set from the starting lineups of both teams
+func volleyballRotationState(ctx context.Context, q *database.Queries, match *models.Match, setNumber int) (*volleyballhelper.RotationState, error) {
+	home, err := volleyballLineup(ctx, q, int32(match.ID), match.HomeTeamID, setNumber)
+	if err != nil {
+		return nil, err
+	}
+	away, err := volleyballLineup(ctx, q, int32(match.ID), match.AwayTeamID, setNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	points, err := q.GetVolleyballPoints(ctx, int32(match.ID), setNumber)
+	if err != nil {
+		return nil, err
+	}
+	scoringTeamIDs := make([]int32, 0, len(points))
+	for _, point := range points {
+		scoringTeamIDs = append(scoringTeamIDs, point.ScoringTeamID)
+	}
+
+	state := volleyballhelper.SetRotation(volleyballhelper.DefaultRules, setNumber, home, away, scoringTeamIDs)
+	return &state, nil
+}
+
+// UpdateVolleyballScoreTx adds a rally to the team winning it, recording who served it. A rally that
+// wins the set opens the next one, or decides the match and adds it to the player stats. Returns the
+// rotation of the set being played next, nil once the match is over.
+func (store *SQLStore) UpdateVolleyballScoreTx(ctx context.Context, matchPublicID, teamPublicID uuid.UUID, setNumber int) (*models.Match, map[string]interface{}, *models.VolleyballPoint, *models.VolleyballScore, *volleyballhelper.RotationState, error) {
+	var matchResult *models.Match
+	var setScore map[string]interface{}
+	var point *models.VolleyballPoint
+	var newSet *models.VolleyballScore
+	var rotation *volleyballhelper.RotationState
+
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		rules := volleyballhelper.DefaultRules
+
+		match, err := q.GetMatchModelByPublicId(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get match: ", err)
+			return err
+		}
+		if match.StatusCode != "in_progress" {
+			return errorhandler.NewFieldError("match_public_id", "Match is not in progress")
+		}
+
+		team, err := q.GetTeamByPublicID(ctx, teamPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get team: ", err)
+			return err
+		}
+		teamID := int32(team.ID)
+		if teamID != match.HomeTeamID && teamID != match.AwayTeamID {
+			return errorhandler.NewFieldError("team_public_id", "Team is not playing this match")
+		}
+
+		current, err := q.GetVolleyballSetScore(ctx, int32(match.ID), setNumber)
+		if err != nil {
+			store.logger.Error("Failed to get volleyball set: ", err)
+			return err
+		}
+		if current == nil {
+			return errorhandler.NewFieldError("set_number", "Set has not started")
+		}
+		if current.SetStatus == "finished" {
+			return errorhandler.NewFieldError("set_number", "Set is already finished")
+		}
+
+		before, err := volleyballRotationState(ctx, q, match, setNumber)
+		if err != nil {
+			store.logger.Error("Failed to get volleyball rotation: ", err)
+			return err
+		}
+
+		var serverPlayerID *int32
+		if before.ServerPlayerID != 0 {
+			serverPlayerID = &before.ServerPlayerID
+		}
+
+		updatedScore, err := q.UpdateVolleyballScore(ctx, int32(match.ID), setNumber, teamID)
+		if err != nil {
+			store.logger.Error("Failed to update volleyball score: ", err)
+			return err
+		}
+
+		point, err = q.AddVolleyballPoint(ctx, database.AddVolleyballPointParams{
+			MatchID:        int32(match.ID),
+			SetNumber:      setNumber,
+			ScoringTeamID:  teamID,
+			ServingTeamID:  before.ServingTeamID,
+			ServerPlayerID: serverPlayerID,
+			HomeScore:      updatedScore.HomeScore,
+			AwayScore:      updatedScore.AwayScore,
+			PointNumber:    current.HomeScore + current.AwayScore + 1,
+		})
+		if err != nil {
+			store.logger.Error("Failed to add volleyball point: ", err)
+			return err
+		}
+
+		rotation, err = volleyballRotationState(ctx, q, match, setNumber)
+		if err != nil {
+			store.logger.Error("Failed to get volleyball rotation: ", err)
+			return err
+		}
+
+		if !rotation.SetFinished {
+			setScore = buildVolleyballSetScore(updatedScore, matchPublicID)
+			return nil
+		}
+
+		updatedScore, err = q.UpdateVolleyballSetStatus(ctx, int32(match.ID), setNumber, "finished")
+		if err != nil {
+			store.logger.Error("Failed to update volleyball set status: ", err)
+			return err
+		}
+		setScore = buildVolleyballSetScore(updatedScore, matchPublicID)
+
+		sets, err := q.GetVolleyballMatchSetsScore(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get volleyball sets: ", err)
+			return err
+		}
+
+		var homeSetsWon, awaySetsWon int
+		for _, set := range sets {
+			if set.SetStatus != "finished" {
+				continue
+			}
+			if set.HomeScore > set.AwayScore {
+				homeSetsWon++
+			} else {
+				awaySetsWon++
+			}
+		}
+
+		if homeSetsWon < rules.SetsToWin && awaySetsWon < rules.SetsToWin {
+			newSet, err = q.AddVolleyballScore(ctx, int32(match.ID), setNumber+1)
+			if err != nil {
+				store.logger.Error("Failed to add next volleyball set: ", err)
+				return err
+			}
+			rotation, err = volleyballRotationState(ctx, q, match, setNumber+1)
+			if err != nil {
+				store.logger.Error("Failed to get volleyball rotation: ", err)
+				return err
+			}
+			return nil
+		}
+
+		rotation = nil
+		winnerTeamID := match.HomeTeamID
+		if awaySetsWon > homeSetsWon {
+			winnerTeamID = match.AwayTeamID
+		}
+
+		matchResult, err = q.UpdateMatchResult(ctx, int32(match.ID), winnerTeamID)
+		if err != nil {
+			store.logger.Error("Failed to update match result: ", err)
+			return err
+		}
+
+		if err := updateVolleyballStatsOnFinish(ctx, q, match, winnerTeamID, homeSetsWon, awaySetsWon); err != nil {
+			store.logger.Error("Failed to update volleyball player stats: ", err)
+			return err
+		}
+		return nil
+	})
+
+	return matchResult, setScore, point, newSet, rotation, err
+}
+
+// UpdateVolleyballLineupTx sets the starting lineup of a team for a set, before its first rally
+func (store *SQLStore) UpdateVolleyballLineupTx(ctx context.Context, matchPublicID, teamPublicID uuid.UUID, setNumber int, playerPublicIDs []uuid.UUID) (*volleyballhelper.RotationState, error) {
+	var rotation *volleyballhelper.RotationState
+
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		match, err := q.GetMatchModelByPublicId(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get match: ", err)
+			return err
+		}
+		if match.StatusCode == "finished" {
+			return errorhandler.NewFieldError("match_public_id", "Match is already finished")
+		}
+
+		team, err := q.GetTeamByPublicID(ctx, teamPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get team: ", err)
+			return err
+		}
+		teamID := int32(team.ID)
+		if teamID != match.HomeTeamID && teamID != match.AwayTeamID {
+			return errorhandler.NewFieldError("team_public_id", "Team is not playing this match")
+		}
+
+		set, err := q.GetVolleyballSetScore(ctx, int32(match.ID), setNumber)
+		if err != nil {
+			store.logger.Error("Failed to get volleyball set: ", err)
+			return err
+		}
+		if set != nil && set.HomeScore+set.AwayScore > 0 {
+			return errorhandler.NewFieldError("set_number", "Lineup can only be set before the first rally of the set")
+		}
+
+		teamPlayerIDs, err := q.GetPlayerIDsByTeamID(ctx, teamID)
+		if err != nil {
+			store.logger.Error("Failed to get team players: ", err)
+			return err
+		}
+
+		playerIDs := make([]int32, 0, len(playerPublicIDs))
+		for _, playerPublicID := range playerPublicIDs {
+			player, err := q.GetPlayer(ctx, playerPublicID)
+			if err != nil {
+				store.logger.Error("Failed to get player: ", err)
+				return err
+			}
+			if player == nil || !containsPlayer(teamPlayerIDs, int32(player.ID)) {
+				return errorhandler.NewFieldError("player_public_ids", "Player is not in the team")
+			}
+			if containsPlayer(playerIDs, int32(player.ID)) {
+				return errorhandler.NewFieldError("player_public_ids", "Player is in the lineup more than once")
+			}
+			playerIDs = append(playerIDs, int32(player.ID))
+		}
+
+		err = q.SetVolleyballLineup(ctx, int32(match.ID), teamID, setNumber, playerIDs)
+		if err != nil {
+			store.logger.Error("Failed to set volleyball lineup: ", err)
+			return err
+		}
+
+		rotation, err = volleyballRotationState(ctx, q, match, setNumber)
+		if err != nil {
+			store.logger.Error("Failed to get volleyball rotation: ", err)
+			return err
+		}
+		return nil
+	})
+	return rotation, err
+}
+
+// GetVolleyballRotationTx returns the rotation of the latest set of the match
+func (store *SQLStore) GetVolleyballRotationTx(ctx context.Context, matchPublicID uuid.UUID) (*volleyballhelper.RotationState, error) {
+	var rotation *volleyballhelper.RotationState
+
+	err := store.execTx(ctx, func(q *database.Queries) error {
+		match, err := q.GetMatchModelByPublicId(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get match: ", err)
+			return err
+		}
+
+		sets, err := q.GetVolleyballMatchSetsScore(ctx, matchPublicID)
+		if err != nil {
+			store.logger.Error("Failed to get volleyball sets: ", err)
+			return err
+		}
+
+		setNumber := 1
+		if len(sets) > 0 {
+			setNumber = sets[len(sets)-1].SetNumber
+		}
+
+		rotation, err = volleyballRotationState(ctx, q, match, setNumber)
+		if err != nil {
+			store.logger.Error("Failed to get volleyball rotation: ", err)
+			return err
+		}
+		return nil
+	})
+	return rotation, err
+}
+
+func buildVolleyballSetScore(score *models.VolleyballScore, matchPublicID uuid.UUID) map[string]interface{} {
+	return map[string]interface{}{
+		"public_id":       score.PublicID,
+		"match_public_id": matchPublicID,
+		"set_number":      score.SetNumber,
+		"home_score":      score.HomeScore,
+		"away_score":      score.AwayScore,
+		"set_status":      score.SetStatus,
+	}
+}
+
+// updateVolleyballStatsOnFinish adds the finished match to the stats of every player of both teams,
+// with the points their team won on their serve
+func updateVolleyballStatsOnFinish(ctx context.Context, q *database.Queries, match *models.Match, winnerTeamID int32, homeSetsWon, awaySetsWon int) error {
+	servicePoints, err := q.GetVolleyballServicePoints(ctx, int32(match.ID))
+	if err != nil {
+		return fmt.Errorf("failed to get service points: %w", err)
+	}
+
+	sides := []struct {
+		teamID            int32
+		setsWon, setsLost int
+	}{
+		{match.HomeTeamID, homeSetsWon, awaySetsWon},
+		{match.AwayTeamID, awaySetsWon, homeSetsWon},
+	}
+
+	for _, side := range sides {
+		won := 0
+		if side.teamID == winnerTeamID {
+			won = 1
+		}
+
+		playerIDs, err := q.GetPlayerIDsByTeamID(ctx, side.teamID)
+		if err != nil {
+			return fmt.Errorf("failed to get team players: %w", err)
+		}
+
+		for _, playerID := range playerIDs {
+			_, err := q.AddOrUpdateVolleyballPlayerStats(ctx, database.AddOrUpdateVolleyballPlayerStatsParams{
+				PlayerID:      playerID,
+				Wins:          won,
+				Losses:        1 - won,
+				SetsWon:       side.setsWon,
+				SetsLost:      side.setsLost,
+				ServicePoints: servicePoints[playerID],
+			})
+			if err != nil {
+				return fmt.Errorf("failed to upsert player %d stats: %w", playerID, err)
+			}
+		}
+	}
+	return nil
+}
@@ -13,15 +13,18 @@ import (
 const addBadmintonScore = `
 	INSERT INTO badminton_score (
 		match_id,
-		set_number
+		set_number,
+		home_score,
+		away_score
 	)
 	VALUES (
-		$1, $2
+		$1, $2, $3, $4
 	) RETURNING *;
 `
 
-func (q *Queries) AddBadmintonScore(ctx context.Context, matchID int32, setNumber int) (*models.BadmintonScore, error) {
-	row := q.db.QueryRowContext(ctx, addBadmintonScore, matchID, setNumber)
+// AddBadmintonScore starts a set at the handicap scores of the two sides
+func (q *Queries) AddBadmintonScore(ctx context.Context, matchID int32, setNumber, homeScore, awayScore int) (*models.BadmintonScore, error) {
+	row := q.db.QueryRowContext(ctx, addBadmintonScore, matchID, setNumber, homeScore, awayScore)
 	var i models.BadmintonScore
 	err := row.Scan(
 		&i.ID,
@@ -169,14 +172,15 @@ const getBadmintonMatchScore = `
 	GROUP BY m.status_code;
 `
 
-type setsWon struct {
+// SetsWon is the number of sets each team has won in a match of a sport scored in sets
+type SetsWon struct {
 	HomeSetsWon *int `json:"home_sets_won"`
 	AwaySetsWon *int `json:"away_sets_won"`
 }
 
-func (q *Queries) GetBadmintonMatchScore(ctx context.Context, matchPublicID uuid.UUID) (*setsWon, error) {
+func (q *Queries) GetBadmintonMatchScore(ctx context.Context, matchPublicID uuid.UUID) (*SetsWon, error) {
 	rows := q.db.QueryRowContext(ctx, getBadmintonMatchScore, matchPublicID)
-	var result setsWon
+	var result SetsWon
 	err := rows.Scan(&result.HomeSetsWon, &result.AwaySetsWon)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -379,7 +383,7 @@ const getBadmintonSetsPointsByTeam = `
 	SELECT bsp.*
 	FROM badminton_sets_points bsp
 	WHERE match_id = $1 AND set_number = $2
-	ORDER BY set_number;
+	ORDER BY point_number;
 `
 
 func (q *Queries) GetBadmintonSetsPointsByTeam(ctx context.Context, matchID int32, setNumber int) ([]models.BadmintonSetsPoints, error) {
@@ -0,0 +1,152 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"khelogames/database/models"
+
+	"github.com/google/uuid"
+)
+
+const badmintonScoringProfileColumns = `
+	id, tournament_id, match_id, points_per_game, win_by, max_points, games_to_win, interval_at, home_handicap, away_handicap, updated_at
+`
+
+func scanBadmintonScoringProfile(row *sql.Row) (*models.BadmintonScoringProfile, error) {
+	var i models.BadmintonScoringProfile
+	err := row.Scan(
+		&i.ID,
+		&i.TournamentID,
+		&i.MatchID,
+		&i.PointsPerGame,
+		&i.WinBy,
+		&i.MaxPoints,
+		&i.GamesToWin,
+		&i.IntervalAt,
+		&i.HomeHandicap,
+		&i.AwayHandicap,
+		&i.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+// BadmintonScoringProfileParams is a scoring format of badminton
+type BadmintonScoringProfileParams struct {
+	PointsPerGame int `json:"points_per_game"`
+	WinBy         int `json:"win_by"`
+	MaxPoints     int `json:"max_points"`
+	GamesToWin    int `json:"games_to_win"`
+	IntervalAt    int `json:"interval_at"`
+	HomeHandicap  int `json:"home_handicap"`
+	AwayHandicap  int `json:"away_handicap"`
+}
+
+const upsertBadmintonTournamentProfile = `
+	INSERT INTO badminton_scoring_profiles (
+		tournament_id,
+		points_per_game,
+		win_by,
+		max_points,
+		games_to_win,
+		interval_at,
+		updated_at
+	)
+	SELECT t.id, $2, $3, $4, $5, $6, NOW()
+	FROM tournaments t
+	WHERE t.public_id = $1
+	ON CONFLICT (tournament_id) DO UPDATE SET
+		points_per_game = EXCLUDED.points_per_game,
+		win_by = EXCLUDED.win_by,
+		max_points = EXCLUDED.max_points,
+		games_to_win = EXCLUDED.games_to_win,
+		interval_at = EXCLUDED.interval_at,
+		updated_at = EXCLUDED.updated_at
+	RETURNING ` + badmintonScoringProfileColumns
+
+// UpsertBadmintonTournamentProfile sets the scoring format of every match of the tournament that
+// does not set its own. Handicaps only apply to a single match.
+func (q *Queries) UpsertBadmintonTournamentProfile(ctx context.Context, tournamentPublicID uuid.UUID, arg BadmintonScoringProfileParams) (*models.BadmintonScoringProfile, error) {
+	row := q.db.QueryRowContext(ctx, upsertBadmintonTournamentProfile,
+		tournamentPublicID,
+		arg.PointsPerGame,
+		arg.WinBy,
+		arg.MaxPoints,
+		arg.GamesToWin,
+		arg.IntervalAt,
+	)
+	return scanBadmintonScoringProfile(row)
+}
+
+const upsertBadmintonMatchProfile = `
+	INSERT INTO badminton_scoring_profiles (
+		match_id,
+		points_per_game,
+		win_by,
+		max_points,
+		games_to_win,
+		interval_at,
+		home_handicap,
+		away_handicap,
+		updated_at
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+	ON CONFLICT (match_id) DO UPDATE SET
+		points_per_game = EXCLUDED.points_per_game,
+		win_by = EXCLUDED.win_by,
+		max_points = EXCLUDED.max_points,
+		games_to_win = EXCLUDED.games_to_win,
+		interval_at = EXCLUDED.interval_at,
+		home_handicap = EXCLUDED.home_handicap,
+		away_handicap = EXCLUDED.away_handicap,
+		updated_at = EXCLUDED.updated_at
+	RETURNING ` + badmintonScoringProfileColumns
+
+// UpsertBadmintonMatchProfile sets the scoring format of a single match, overriding the format of
+// its tournament
+func (q *Queries) UpsertBadmintonMatchProfile(ctx context.Context, matchID int32, arg BadmintonScoringProfileParams) (*models.BadmintonScoringProfile, error) {
+	row := q.db.QueryRowContext(ctx, upsertBadmintonMatchProfile,
+		matchID,
+		arg.PointsPerGame,
+		arg.WinBy,
+		arg.MaxPoints,
+		arg.GamesToWin,
+		arg.IntervalAt,
+		arg.HomeHandicap,
+		arg.AwayHandicap,
+	)
+	return scanBadmintonScoringProfile(row)
+}
+
+const getBadmintonScoringProfile = `
+	SELECT ` + badmintonScoringProfileColumns + `
+	FROM badminton_scoring_profiles
+	WHERE match_id = $1 OR tournament_id = $2
+	ORDER BY match_id IS NULL
+	LIMIT 1
+`
+
+// GetBadmintonScoringProfile returns the scoring format of the match, falling back on the format of
+// its tournament. It is nil when neither set one.
+func (q *Queries) GetBadmintonScoringProfile(ctx context.Context, matchID, tournamentID int32) (*models.BadmintonScoringProfile, error) {
+	row := q.db.QueryRowContext(ctx, getBadmintonScoringProfile, matchID, tournamentID)
+	return scanBadmintonScoringProfile(row)
+}
+
+const getBadmintonTournamentProfile = `
+	SELECT ` + badmintonScoringProfileColumns + `
+	FROM badminton_scoring_profiles
+	WHERE tournament_id = $1
+`
+
+// GetBadmintonTournamentProfile returns nil when the tournament has not set its own format
+func (q *Queries) GetBadmintonTournamentProfile(ctx context.Context, tournamentID int32) (*models.BadmintonScoringProfile, error) {
+	row := q.db.QueryRowContext(ctx, getBadmintonTournamentProfile, tournamentID)
+	return scanBadmintonScoringProfile(row)
+}
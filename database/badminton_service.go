@@ -0,0 +1,88 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"khelogames/database/models"
+)
+
+const badmintonServiceColumns = `
+	id, match_id, set_number, server_team_id, server_player_id, receiver_team_id, receiver_player_id, updated_at
+`
+
+func scanBadmintonService(row interface{ Scan(dest ...any) error }, i *models.BadmintonService) error {
+	return row.Scan(
+		&i.ID,
+		&i.MatchID,
+		&i.SetNumber,
+		&i.ServerTeamID,
+		&i.ServerPlayerID,
+		&i.ReceiverTeamID,
+		&i.ReceiverPlayerID,
+		&i.UpdatedAt,
+	)
+}
+
+const upsertBadmintonService = `
+	INSERT INTO badminton_service (
+		match_id,
+		set_number,
+		server_team_id,
+		server_player_id,
+		receiver_team_id,
+		receiver_player_id,
+		updated_at
+	) VALUES ($1, $2, $3, $4, $5, $6, NOW())
+	ON CONFLICT (match_id, set_number) DO UPDATE SET
+		server_team_id = EXCLUDED.server_team_id,
+		server_player_id = EXCLUDED.server_player_id,
+		receiver_team_id = EXCLUDED.receiver_team_id,
+		receiver_player_id = EXCLUDED.receiver_player_id,
+		updated_at = EXCLUDED.updated_at
+	RETURNING ` + badmintonServiceColumns
+
+type UpsertBadmintonServiceParams struct {
+	MatchID          int32 `json:"match_id"`
+	SetNumber        int   `json:"set_number"`
+	ServerTeamID     int32 `json:"server_team_id"`
+	ServerPlayerID   int32 `json:"server_player_id"`
+	ReceiverTeamID   int32 `json:"receiver_team_id"`
+	ReceiverPlayerID int32 `json:"receiver_player_id"`
+}
+
+// UpsertBadmintonService records the opening service of a game chosen by the umpire
+func (q *Queries) UpsertBadmintonService(ctx context.Context, arg UpsertBadmintonServiceParams) (*models.BadmintonService, error) {
+	row := q.db.QueryRowContext(ctx, upsertBadmintonService,
+		arg.MatchID,
+		arg.SetNumber,
+		arg.ServerTeamID,
+		arg.ServerPlayerID,
+		arg.ReceiverTeamID,
+		arg.ReceiverPlayerID,
+	)
+	var i models.BadmintonService
+	if err := scanBadmintonService(row, &i); err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const getBadmintonService = `
+	SELECT ` + badmintonServiceColumns + `
+	FROM badminton_service
+	WHERE match_id = $1 AND set_number = $2
+`
+
+// GetBadmintonService returns the opening service of a game, nil when none was recorded
+func (q *Queries) GetBadmintonService(ctx context.Context, matchID int32, setNumber int) (*models.BadmintonService, error) {
+	row := q.db.QueryRowContext(ctx, getBadmintonService, matchID, setNumber)
+	var i models.BadmintonService
+	if err := scanBadmintonService(row, &i); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
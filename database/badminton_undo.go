@@ -0,0 +1,247 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"khelogames/database/models"
+)
+
+const getBadmintonLastPoint = `
+	SELECT bsp.*
+	FROM badminton_sets_points bsp
+	WHERE bsp.match_id = $1
+	ORDER BY bsp.set_number DESC, bsp.point_number DESC
+	LIMIT 1
+`
+
+// GetBadmintonLastPoint returns the latest point of the match, nil before the first rally
+func (q *Queries) GetBadmintonLastPoint(ctx context.Context, matchID int32) (*models.BadmintonSetsPoints, error) {
+	row := q.db.QueryRowContext(ctx, getBadmintonLastPoint, matchID)
+	var i models.BadmintonSetsPoints
+	err := row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.SetNumber,
+		&i.ScoringTeamID,
+		&i.HomeScore,
+		&i.AwayScore,
+		&i.PointNumber,
+		&i.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const deleteBadmintonSetsPoint = `
+	DELETE FROM badminton_sets_points
+	WHERE id = $1
+`
+
+func (q *Queries) DeleteBadmintonSetsPoint(ctx context.Context, pointID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteBadmintonSetsPoint, pointID)
+	return err
+}
+
+const revertBadmintonSetScore = `
+	UPDATE badminton_score bs
+	SET
+		home_score = CASE WHEN m.home_team_id = $3 THEN bs.home_score - 1 ELSE bs.home_score END,
+		away_score = CASE WHEN m.away_team_id = $3 THEN bs.away_score - 1 ELSE bs.away_score END,
+		set_status = 'in_progress'
+	FROM matches m
+	WHERE bs.match_id = m.id
+	AND bs.match_id = $1
+	AND bs.set_number = $2
+	RETURNING bs.*;
+`
+
+// RevertBadmintonSetScore takes a point off the scoring team and reopens the set
+func (q *Queries) RevertBadmintonSetScore(ctx context.Context, matchID int32, setNumber int, scoringTeamID int32) (*models.BadmintonScore, error) {
+	row := q.db.QueryRowContext(ctx, revertBadmintonSetScore, matchID, setNumber, scoringTeamID)
+	var i models.BadmintonScore
+	err := row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.SetNumber,
+		&i.HomeScore,
+		&i.AwayScore,
+		&i.SetStatus,
+		&i.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const deleteBadmintonSetService = `
+	DELETE FROM badminton_service
+	WHERE match_id = $1 AND set_number = $2
+`
+
+const deleteBadmintonSet = `
+	DELETE FROM badminton_score
+	WHERE match_id = $1 AND set_number = $2
+	RETURNING *
+`
+
+// DeleteBadmintonSet removes a set opened by a point that was undone, along with the opening
+// service recorded for it
+func (q *Queries) DeleteBadmintonSet(ctx context.Context, matchID int32, setNumber int) (*models.BadmintonScore, error) {
+	_, err := q.db.ExecContext(ctx, deleteBadmintonSetService, matchID, setNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	row := q.db.QueryRowContext(ctx, deleteBadmintonSet, matchID, setNumber)
+	var i models.BadmintonScore
+	err = row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.SetNumber,
+		&i.HomeScore,
+		&i.AwayScore,
+		&i.SetStatus,
+		&i.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const getBadmintonPlayerMatchResults = `
+	SELECT
+		m.id,
+		COALESCE(m.result = tp.team_id, false) AS won,
+		COUNT(*) FILTER (
+			WHERE bs.set_status = 'finished'
+			AND CASE WHEN m.home_team_id = tp.team_id THEN bs.home_score > bs.away_score ELSE bs.away_score > bs.home_score END
+		) AS sets_won,
+		COUNT(*) FILTER (
+			WHERE bs.set_status = 'finished'
+			AND CASE WHEN m.home_team_id = tp.team_id THEN bs.home_score < bs.away_score ELSE bs.away_score < bs.home_score END
+		) AS sets_lost,
+		COALESCE(SUM(
+			CASE WHEN m.home_team_id = tp.team_id
+				THEN bs.home_score - COALESCE(p.home_handicap, 0)
+				ELSE bs.away_score - COALESCE(p.away_handicap, 0)
+			END
+		), 0) AS points_scored,
+		COALESCE(SUM(
+			CASE WHEN m.home_team_id = tp.team_id
+				THEN bs.away_score - COALESCE(p.away_handicap, 0)
+				ELSE bs.home_score - COALESCE(p.home_handicap, 0)
+			END
+		), 0) AS points_conceded
+	FROM (SELECT DISTINCT team_id FROM team_players WHERE player_id = $1) tp
+	JOIN matches m ON m.home_team_id = tp.team_id OR m.away_team_id = tp.team_id
+	JOIN badminton_score bs ON bs.match_id = m.id
+	LEFT JOIN badminton_scoring_profiles p ON p.match_id = m.id
+	WHERE m.status_code = 'finished'
+		AND (COALESCE(m.type, '') = 'double') = $2
+	GROUP BY m.id, m.start_timestamp, m.result, m.home_team_id, tp.team_id
+	ORDER BY m.start_timestamp, m.id
+`
+
+// GetBadmintonPlayerMatchResults returns the finished singles or doubles matches of a player in
+// the order they were played
+func (q *Queries) GetBadmintonPlayerMatchResults(ctx context.Context, playerID int32, doubles bool) ([]models.BadmintonPlayerMatchResult, error) {
+	rows, err := q.db.QueryContext(ctx, getBadmintonPlayerMatchResults, playerID, doubles)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var results []models.BadmintonPlayerMatchResult
+	for rows.Next() {
+		var i models.BadmintonPlayerMatchResult
+		err := rows.Scan(
+			&i.MatchID,
+			&i.Won,
+			&i.SetsWon,
+			&i.SetsLost,
+			&i.PointsScored,
+			&i.PointsConceded,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		results = append(results, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+const setBadmintonPlayerStats = `
+	INSERT INTO badminton_player_stats (
+		player_id, play_type, matches, wins, losses,
+		sets_won, sets_lost, points_scored, points_conceded,
+		win_percentage, current_streak, best_streak
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	ON CONFLICT (player_id, play_type) DO UPDATE SET
+		matches         = EXCLUDED.matches,
+		wins            = EXCLUDED.wins,
+		losses          = EXCLUDED.losses,
+		sets_won        = EXCLUDED.sets_won,
+		sets_lost       = EXCLUDED.sets_lost,
+		points_scored   = EXCLUDED.points_scored,
+		points_conceded = EXCLUDED.points_conceded,
+		win_percentage  = EXCLUDED.win_percentage,
+		current_streak  = EXCLUDED.current_streak,
+		best_streak     = EXCLUDED.best_streak,
+		updated_at = NOW()
+`
+
+type SetBadmintonPlayerStatsParams struct {
+	PlayerID       int32
+	PlayType       string
+	Matches        int
+	Wins           int
+	Losses         int
+	SetsWon        int
+	SetsLost       int
+	PointsScored   int
+	PointsConceded int
+	WinPercentage  float64
+	CurrentStreak  int
+	BestStreak     int
+}
+
+// SetBadmintonPlayerStats overwrites the stats of a player with totals worked out again from the
+// match results
+func (q *Queries) SetBadmintonPlayerStats(ctx context.Context, arg SetBadmintonPlayerStatsParams) error {
+	_, err := q.db.ExecContext(ctx, setBadmintonPlayerStats,
+		arg.PlayerID,
+		arg.PlayType,
+		arg.Matches,
+		arg.Wins,
+		arg.Losses,
+		arg.SetsWon,
+		arg.SetsLost,
+		arg.PointsScored,
+		arg.PointsConceded,
+		arg.WinPercentage,
+		arg.CurrentStreak,
+		arg.BestStreak,
+	)
+	return err
+}
@@ -0,0 +1,153 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"khelogames/database/models"
+
+	"github.com/google/uuid"
+)
+
+const cricketCommentaryColumns = `
+	c.id, c.public_id, c.match_id, c.delivery_id, c.inning_number, c.over_number,
+	c.ball_number, c.event_type, c.commentary, c.created_at
+`
+
+func scanCricketCommentary(row interface{ Scan(dest ...any) error }, i *models.CricketCommentary) error {
+	return row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.DeliveryID,
+		&i.InningNumber,
+		&i.OverNumber,
+		&i.BallNumber,
+		&i.EventType,
+		&i.Commentary,
+		&i.CreatedAt,
+	)
+}
+
+const addCricketCommentary = `
+	INSERT INTO cricket_commentary AS c (
+		match_id,
+		delivery_id,
+		inning_number,
+		over_number,
+		ball_number,
+		event_type,
+		commentary,
+		created_at
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP)
+	RETURNING ` + cricketCommentaryColumns
+
+type AddCricketCommentaryParams struct {
+	MatchID      int32  `json:"match_id"`
+	DeliveryID   int64  `json:"delivery_id"`
+	InningNumber int    `json:"inning_number"`
+	OverNumber   int    `json:"over_number"`
+	BallNumber   int    `json:"ball_number"`
+	EventType    string `json:"event_type"`
+	Commentary   string `json:"commentary"`
+}
+
+func (q *Queries) AddCricketCommentary(ctx context.Context, arg AddCricketCommentaryParams) (*models.CricketCommentary, error) {
+	row := q.db.QueryRowContext(ctx, addCricketCommentary,
+		arg.MatchID,
+		arg.DeliveryID,
+		arg.InningNumber,
+		arg.OverNumber,
+		arg.BallNumber,
+		arg.EventType,
+		arg.Commentary,
+	)
+	var i models.CricketCommentary
+	if err := scanCricketCommentary(row, &i); err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+// Commentary of undone deliveries is hidden until they are redone
+const getCricketCommentary = `
+	SELECT ` + cricketCommentaryColumns + `
+	FROM cricket_commentary c
+	JOIN cricket_deliveries d ON d.id = c.delivery_id
+	JOIN matches m ON m.id = c.match_id
+	WHERE m.public_id = $1 AND d.is_undone = false
+	ORDER BY c.id DESC
+	LIMIT $2 OFFSET $3
+`
+
+// GetCricketCommentary returns the commentary of a match, latest first
+func (q *Queries) GetCricketCommentary(ctx context.Context, matchPublicID uuid.UUID, limit, offset int) ([]models.CricketCommentary, error) {
+	rows, err := q.db.QueryContext(ctx, getCricketCommentary, matchPublicID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []models.CricketCommentary
+	for rows.Next() {
+		var i models.CricketCommentary
+		if err := scanCricketCommentary(rows, &i); err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		lines = append(lines, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+const getLatestCricketCommentary = `
+	SELECT ` + cricketCommentaryColumns + `
+	FROM cricket_commentary c
+	WHERE c.delivery_id = (
+		SELECT d.id FROM cricket_deliveries d
+		JOIN matches m ON m.id = d.match_id
+		WHERE m.public_id = $1 AND d.is_undone = false
+		ORDER BY d.id DESC
+		LIMIT 1
+	)
+	ORDER BY c.id ASC
+`
+
+// GetLatestCricketCommentary returns the lines generated for the last delivery of a match
+func (q *Queries) GetLatestCricketCommentary(ctx context.Context, matchPublicID uuid.UUID) ([]models.CricketCommentary, error) {
+	rows, err := q.db.QueryContext(ctx, getLatestCricketCommentary, matchPublicID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []models.CricketCommentary
+	for rows.Next() {
+		var i models.CricketCommentary
+		if err := scanCricketCommentary(rows, &i); err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		lines = append(lines, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+const getCricketOverRuns = `
+	SELECT COALESCE(SUM(runs + extras_runs), 0)
+	FROM cricket_deliveries
+	WHERE match_id = $1 AND inning_number = $2 AND over_number = $3 AND is_undone = false
+`
+
+// GetCricketOverRuns returns the runs conceded in an over of the inning
+func (q *Queries) GetCricketOverRuns(ctx context.Context, matchID int32, inningNumber int, overNumber int) (int, error) {
+	var runs int
+	err := q.db.QueryRowContext(ctx, getCricketOverRuns, matchID, inningNumber, overNumber).Scan(&runs)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return runs, nil
+}
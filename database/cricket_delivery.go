@@ -0,0 +1,755 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"khelogames/database/models"
+)
+
+const cricketDeliveryColumns = `
+	id, public_id, match_id, team_id, inning_number, over_number, ball_number,
+	bowler_id, striker_id, non_striker_id, runs, extras_type, extras_runs, is_legal,
+	is_wicket, wicket_type, dismissed_batsman_id, fielder_id, is_undone, created_at
+`
+
+func scanCricketDelivery(row interface{ Scan(dest ...any) error }, i *models.CricketDelivery) error {
+	return row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.TeamID,
+		&i.InningNumber,
+		&i.OverNumber,
+		&i.BallNumber,
+		&i.BowlerID,
+		&i.StrikerID,
+		&i.NonStrikerID,
+		&i.Runs,
+		&i.ExtrasType,
+		&i.ExtrasRuns,
+		&i.IsLegal,
+		&i.IsWicket,
+		&i.WicketType,
+		&i.DismissedBatsmanID,
+		&i.FielderID,
+		&i.IsUndone,
+		&i.CreatedAt,
+	)
+}
+
+// A new delivery invalidates the redo stack of the inning
+const deleteUndoneCricketDeliveries = `
+	DELETE FROM cricket_deliveries
+	WHERE match_id = $1 AND inning_number = $2 AND is_undone = true
+`
+
+const addCricketDelivery = `
+	INSERT INTO cricket_deliveries (
+		match_id,
+		team_id,
+		inning_number,
+		over_number,
+		ball_number,
+		bowler_id,
+		striker_id,
+		non_striker_id,
+		runs,
+		extras_type,
+		extras_runs,
+		is_legal,
+		is_wicket,
+		wicket_type,
+		dismissed_batsman_id,
+		fielder_id,
+		is_undone,
+		created_at
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, false, CURRENT_TIMESTAMP)
+	RETURNING ` + cricketDeliveryColumns
+
+type AddCricketDeliveryParams struct {
+	MatchID            int32   `json:"match_id"`
+	TeamID             int32   `json:"team_id"`
+	InningNumber       int     `json:"inning_number"`
+	OverNumber         int     `json:"over_number"`
+	BallNumber         int     `json:"ball_number"`
+	BowlerID           int32   `json:"bowler_id"`
+	StrikerID          int32   `json:"striker_id"`
+	NonStrikerID       int32   `json:"non_striker_id"`
+	Runs               int     `json:"runs"`
+	ExtrasType         *string `json:"extras_type"`
+	ExtrasRuns         int     `json:"extras_runs"`
+	IsLegal            bool    `json:"is_legal"`
+	IsWicket           bool    `json:"is_wicket"`
+	WicketType         *string `json:"wicket_type"`
+	DismissedBatsmanID *int32  `json:"dismissed_batsman_id"`
+	FielderID          *int32  `json:"fielder_id"`
+}
+
+func (q *Queries) AddCricketDelivery(ctx context.Context, arg AddCricketDeliveryParams) (*models.CricketDelivery, error) {
+	_, err := q.db.ExecContext(ctx, deleteUndoneCricketDeliveries, arg.MatchID, arg.InningNumber)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to clear undone deliveries: %w", err)
+	}
+
+	row := q.db.QueryRowContext(ctx, addCricketDelivery,
+		arg.MatchID,
+		arg.TeamID,
+		arg.InningNumber,
+		arg.OverNumber,
+		arg.BallNumber,
+		arg.BowlerID,
+		arg.StrikerID,
+		arg.NonStrikerID,
+		arg.Runs,
+		arg.ExtrasType,
+		arg.ExtrasRuns,
+		arg.IsLegal,
+		arg.IsWicket,
+		arg.WicketType,
+		arg.DismissedBatsmanID,
+		arg.FielderID,
+	)
+	var i models.CricketDelivery
+	if err := scanCricketDelivery(row, &i); err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const getLastCricketDelivery = `
+	SELECT ` + cricketDeliveryColumns + ` FROM cricket_deliveries
+	WHERE match_id = $1 AND inning_number = $2 AND is_undone = false
+	ORDER BY id DESC
+	LIMIT 1
+`
+
+// GetLastCricketDelivery returns the latest delivery which can be undone
+func (q *Queries) GetLastCricketDelivery(ctx context.Context, matchID int32, inningNumber int) (*models.CricketDelivery, error) {
+	row := q.db.QueryRowContext(ctx, getLastCricketDelivery, matchID, inningNumber)
+	var i models.CricketDelivery
+	if err := scanCricketDelivery(row, &i); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const getLastLegalCricketDelivery = `
+	SELECT ` + cricketDeliveryColumns + ` FROM cricket_deliveries
+	WHERE match_id = $1 AND inning_number = $2 AND is_undone = false AND is_legal = true
+	ORDER BY id DESC
+	LIMIT 1
+`
+
+// GetLastLegalCricketDelivery returns the latest ball which counted towards the over
+func (q *Queries) GetLastLegalCricketDelivery(ctx context.Context, matchID int32, inningNumber int) (*models.CricketDelivery, error) {
+	row := q.db.QueryRowContext(ctx, getLastLegalCricketDelivery, matchID, inningNumber)
+	var i models.CricketDelivery
+	if err := scanCricketDelivery(row, &i); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const countCricketLegalBalls = `
+	SELECT
+		COUNT(*),
+		COUNT(*) FILTER (WHERE bowler_id = $3)
+	FROM cricket_deliveries
+	WHERE match_id = $1 AND inning_number = $2 AND is_undone = false AND is_legal = true
+`
+
+// CountCricketLegalBalls returns the legal balls bowled in the inning and by the bowler
+func (q *Queries) CountCricketLegalBalls(ctx context.Context, matchID int32, inningNumber int, bowlerID int32) (int, int, error) {
+	var inningBalls, bowlerBalls int
+	err := q.db.QueryRowContext(ctx, countCricketLegalBalls, matchID, inningNumber, bowlerID).Scan(&inningBalls, &bowlerBalls)
+	if err != nil {
+		return 0, 0, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return inningBalls, bowlerBalls, nil
+}
+
+const getNextUndoneCricketDelivery = `
+	SELECT ` + cricketDeliveryColumns + ` FROM cricket_deliveries
+	WHERE match_id = $1 AND inning_number = $2 AND is_undone = true
+	ORDER BY id ASC
+	LIMIT 1
+`
+
+// GetNextUndoneCricketDelivery returns the earliest undone delivery which can be redone
+func (q *Queries) GetNextUndoneCricketDelivery(ctx context.Context, matchID int32, inningNumber int) (*models.CricketDelivery, error) {
+	row := q.db.QueryRowContext(ctx, getNextUndoneCricketDelivery, matchID, inningNumber)
+	var i models.CricketDelivery
+	if err := scanCricketDelivery(row, &i); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const setCricketDeliveryUndone = `
+	UPDATE cricket_deliveries
+	SET is_undone = $2
+	WHERE id = $1
+	RETURNING ` + cricketDeliveryColumns
+
+func (q *Queries) SetCricketDeliveryUndone(ctx context.Context, id int64, isUndone bool) (*models.CricketDelivery, error) {
+	row := q.db.QueryRowContext(ctx, setCricketDeliveryUndone, id, isUndone)
+	var i models.CricketDelivery
+	if err := scanCricketDelivery(row, &i); err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const getCricketDeliveries = `
+	SELECT ` + cricketDeliveryColumns + ` FROM cricket_deliveries
+	WHERE match_id = $1 AND inning_number = $2 AND is_undone = false
+	ORDER BY id ASC
+`
+
+func (q *Queries) GetCricketDeliveries(ctx context.Context, matchID int32, inningNumber int) ([]models.CricketDelivery, error) {
+	rows, err := q.db.QueryContext(ctx, getCricketDeliveries, matchID, inningNumber)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []models.CricketDelivery
+	for rows.Next() {
+		var i models.CricketDelivery
+		if err := scanCricketDelivery(rows, &i); err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		deliveries = append(deliveries, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+const applyCricketDeliveryBatsman = `
+	UPDATE batsman_score
+	SET runs_scored = runs_scored + $5,
+		balls_faced = balls_faced + $6,
+		fours = fours + $7,
+		sixes = sixes + $8
+	WHERE match_id = $1 AND team_id = $2 AND inning_number = $3 AND batsman_id = $4
+	RETURNING id, public_id, match_id, team_id, batsman_id, inning_number, position, runs_scored,
+		balls_faced, fours, sixes, batting_status, is_striker, is_currently_batting
+`
+
+const applyCricketDeliveryBowler = `
+	UPDATE bowler_score
+	SET runs = runs + $4,
+		ball_number = ball_number + $5,
+		wide = wide + $6,
+		no_ball = no_ball + $7,
+		wickets = wickets + $8
+	WHERE match_id = $1 AND inning_number = $2 AND bowler_id = $3
+	RETURNING id, public_id, match_id, team_id, bowler_id, inning_number, ball_number, runs,
+		wickets, wide, no_ball, bowling_status, is_current_bowler
+`
+
+const applyCricketDeliveryInning = `
+	UPDATE cricket_score
+	SET score = score + $4,
+		overs = overs + $5,
+		wickets = wickets + $6
+	WHERE match_id = $1 AND team_id = $2 AND inning_number = $3
+	RETURNING id, public_id, match_id, team_id, inning_number, score, wickets, overs, run_rate,
+		target_run_rate, follow_on, is_inning_completed, declared, inning_status
+`
+
+// IsBowlerCreditedWicket reports whether a dismissal counts towards the bowler
+func IsBowlerCreditedWicket(wicketType string) bool {
+	dismissal, ok := GetCricketDismissal(wicketType)
+	return ok && dismissal.BowlerCredited
+}
+
+// IsBowlerExemptExtras reports whether the extras of a delivery are left out of the bowler figures
+func IsBowlerExemptExtras(extrasType *string) bool {
+	if extrasType == nil {
+		return false
+	}
+	switch *extrasType {
+	case "bye", "leg_bye":
+		return true
+	}
+	return false
+}
+
+// ApplyCricketDelivery adds (sign = 1) or removes (sign = -1) the effect of a delivery
+// on the striker, the bowler, the fielder and the inning score.
+func (q *Queries) ApplyCricketDelivery(ctx context.Context, delivery models.CricketDelivery, sign int) (*models.BatsmanScore, *models.BowlerScore, *models.CricketScore, error) {
+	var batsman models.BatsmanScore
+	var bowler models.BowlerScore
+	var inningScore models.CricketScore
+
+	ballsFaced, fours, sixes := 1, 0, 0
+	if delivery.ExtrasType != nil && *delivery.ExtrasType == "wide" {
+		ballsFaced = 0
+	}
+	if delivery.Runs == 4 {
+		fours = 1
+	} else if delivery.Runs == 6 {
+		sixes = 1
+	}
+
+	legalBall, wide, noBall, bowlerWicket, teamWicket := 0, 0, 0, 0, 0
+	if delivery.IsLegal {
+		legalBall = 1
+	}
+	if delivery.ExtrasType != nil {
+		switch *delivery.ExtrasType {
+		case "wide":
+			wide = 1
+		case "no_ball":
+			noBall = 1
+		}
+	}
+	if delivery.IsWicket {
+		teamWicket = 1
+		if delivery.WicketType != nil && IsBowlerCreditedWicket(*delivery.WicketType) {
+			bowlerWicket = 1
+		}
+	}
+	totalRuns := delivery.Runs + delivery.ExtrasRuns
+	// byes and leg byes are not charged to the bowler
+	bowlerRuns := totalRuns
+	if IsBowlerExemptExtras(delivery.ExtrasType) {
+		bowlerRuns = delivery.Runs
+	}
+
+	row := q.db.QueryRowContext(ctx, applyCricketDeliveryBatsman,
+		delivery.MatchID, delivery.TeamID, delivery.InningNumber, delivery.StrikerID,
+		sign*delivery.Runs, sign*ballsFaced, sign*fours, sign*sixes,
+	)
+	err := row.Scan(
+		&batsman.ID,
+		&batsman.PublicID,
+		&batsman.MatchID,
+		&batsman.TeamID,
+		&batsman.BatsmanID,
+		&batsman.InningNumber,
+		&batsman.Position,
+		&batsman.RunsScored,
+		&batsman.BallsFaced,
+		&batsman.Fours,
+		&batsman.Sixes,
+		&batsman.BattingStatus,
+		&batsman.IsStriker,
+		&batsman.IsCurrentlyBatting,
+	)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("Failed to update batsman score: %w", err)
+	}
+
+	row = q.db.QueryRowContext(ctx, applyCricketDeliveryBowler,
+		delivery.MatchID, delivery.InningNumber, delivery.BowlerID,
+		sign*bowlerRuns, sign*legalBall, sign*wide, sign*noBall, sign*bowlerWicket,
+	)
+	err = row.Scan(
+		&bowler.ID,
+		&bowler.PublicID,
+		&bowler.MatchID,
+		&bowler.TeamID,
+		&bowler.BowlerID,
+		&bowler.InningNumber,
+		&bowler.BallNumber,
+		&bowler.Runs,
+		&bowler.Wickets,
+		&bowler.Wide,
+		&bowler.NoBall,
+		&bowler.BowlingStatus,
+		&bowler.IsCurrentBowler,
+	)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("Failed to update bowler score: %w", err)
+	}
+
+	err = q.ApplyCricketFielding(ctx, delivery, bowler.TeamID, sign)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	row = q.db.QueryRowContext(ctx, applyCricketDeliveryInning,
+		delivery.MatchID, delivery.TeamID, delivery.InningNumber,
+		sign*totalRuns, sign*legalBall, sign*teamWicket,
+	)
+	err = row.Scan(
+		&inningScore.ID,
+		&inningScore.PublicID,
+		&inningScore.MatchID,
+		&inningScore.TeamID,
+		&inningScore.InningNumber,
+		&inningScore.Score,
+		&inningScore.Wickets,
+		&inningScore.Overs,
+		&inningScore.RunRate,
+		&inningScore.TargetRunRate,
+		&inningScore.FollowOn,
+		&inningScore.IsInningCompleted,
+		&inningScore.Declared,
+		&inningScore.InningStatus,
+	)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("Failed to update inning score: %w", err)
+	}
+
+	return &batsman, &bowler, &inningScore, nil
+}
+
+const restoreCricketBattingPair = `
+	UPDATE batsman_score
+	SET is_currently_batting = (batsman_id = $4 OR batsman_id = $5),
+		is_striker = (batsman_id = $4)
+	WHERE match_id = $1 AND team_id = $2 AND inning_number = $3
+	  AND (is_currently_batting = true OR batsman_id = $4 OR batsman_id = $5)
+	RETURNING id, public_id, match_id, team_id, batsman_id, inning_number, position, runs_scored,
+		balls_faced, fours, sixes, batting_status, is_striker, is_currently_batting
+`
+
+// RestoreCricketBattingPair puts the given striker and non-striker back at the crease,
+// any other batsman currently batting is taken off.
+func (q *Queries) RestoreCricketBattingPair(ctx context.Context, matchID, teamID int32, inningNumber int, strikerID, nonStrikerID int32) ([]models.BatsmanScore, error) {
+	rows, err := q.db.QueryContext(ctx, restoreCricketBattingPair, matchID, teamID, inningNumber, strikerID, nonStrikerID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to restore batting pair: %w", err)
+	}
+	defer rows.Close()
+
+	var batsmen []models.BatsmanScore
+	for rows.Next() {
+		var bat models.BatsmanScore
+		err := rows.Scan(
+			&bat.ID,
+			&bat.PublicID,
+			&bat.MatchID,
+			&bat.TeamID,
+			&bat.BatsmanID,
+			&bat.InningNumber,
+			&bat.Position,
+			&bat.RunsScored,
+			&bat.BallsFaced,
+			&bat.Fours,
+			&bat.Sixes,
+			&bat.BattingStatus,
+			&bat.IsStriker,
+			&bat.IsCurrentlyBatting,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		batsmen = append(batsmen, bat)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return batsmen, nil
+}
+
+const getCricketCurrentBatsmen = `
+	SELECT id, public_id, match_id, team_id, batsman_id, inning_number, position, runs_scored,
+		balls_faced, fours, sixes, batting_status, is_striker, is_currently_batting
+	FROM batsman_score
+	WHERE match_id = $1 AND team_id = $2 AND inning_number = $3 AND is_currently_batting = true
+`
+
+func (q *Queries) GetCricketCurrentBatsmen(ctx context.Context, matchID, teamID int32, inningNumber int) ([]models.BatsmanScore, error) {
+	rows, err := q.db.QueryContext(ctx, getCricketCurrentBatsmen, matchID, teamID, inningNumber)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var batsmen []models.BatsmanScore
+	for rows.Next() {
+		var bat models.BatsmanScore
+		err := rows.Scan(
+			&bat.ID,
+			&bat.PublicID,
+			&bat.MatchID,
+			&bat.TeamID,
+			&bat.BatsmanID,
+			&bat.InningNumber,
+			&bat.Position,
+			&bat.RunsScored,
+			&bat.BallsFaced,
+			&bat.Fours,
+			&bat.Sixes,
+			&bat.BattingStatus,
+			&bat.IsStriker,
+			&bat.IsCurrentlyBatting,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		batsmen = append(batsmen, bat)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return batsmen, nil
+}
+
+// A batsman who walked in after the undone wicket and has not faced a ball is removed
+const deleteIncomingCricketBatsman = `
+	DELETE FROM batsman_score
+	WHERE match_id = $1 AND team_id = $2 AND inning_number = $3
+	  AND batsman_id <> $4 AND batsman_id <> $5
+	  AND is_currently_batting = true
+	  AND balls_faced = 0
+`
+
+func (q *Queries) DeleteIncomingCricketBatsman(ctx context.Context, matchID, teamID int32, inningNumber int, strikerID, nonStrikerID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteIncomingCricketBatsman, matchID, teamID, inningNumber, strikerID, nonStrikerID)
+	if err != nil {
+		return fmt.Errorf("Failed to delete incoming batsman: %w", err)
+	}
+	return nil
+}
+
+const setCricketCurrentBowler = `
+	UPDATE bowler_score
+	SET is_current_bowler = (bowler_id = $3)
+	WHERE match_id = $1 AND inning_number = $2
+	  AND (is_current_bowler = true OR bowler_id = $3)
+	RETURNING id, public_id, match_id, team_id, bowler_id, inning_number, ball_number, runs,
+		wickets, wide, no_ball, bowling_status, is_current_bowler
+`
+
+// SetCricketCurrentBowler marks the given bowler as the only current bowler of the inning
+func (q *Queries) SetCricketCurrentBowler(ctx context.Context, matchID int32, inningNumber int, bowlerID int32) (*models.BowlerScore, error) {
+	rows, err := q.db.QueryContext(ctx, setCricketCurrentBowler, matchID, inningNumber, bowlerID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to set current bowler: %w", err)
+	}
+	defer rows.Close()
+
+	var currentBowler *models.BowlerScore
+	for rows.Next() {
+		var bowler models.BowlerScore
+		err := rows.Scan(
+			&bowler.ID,
+			&bowler.PublicID,
+			&bowler.MatchID,
+			&bowler.TeamID,
+			&bowler.BowlerID,
+			&bowler.InningNumber,
+			&bowler.BallNumber,
+			&bowler.Runs,
+			&bowler.Wickets,
+			&bowler.Wide,
+			&bowler.NoBall,
+			&bowler.BowlingStatus,
+			&bowler.IsCurrentBowler,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		if bowler.IsCurrentBowler {
+			currentBowler = &bowler
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return currentBowler, nil
+}
+
+const dismissCricketBatsman = `
+	UPDATE batsman_score
+	SET is_currently_batting = false,
+		is_striker = false
+	WHERE match_id = $1 AND team_id = $2 AND inning_number = $3 AND batsman_id = $4
+	RETURNING id, public_id, match_id, team_id, batsman_id, inning_number, position, runs_scored,
+		balls_faced, fours, sixes, batting_status, is_striker, is_currently_batting
+`
+
+func (q *Queries) DismissCricketBatsman(ctx context.Context, matchID, teamID int32, inningNumber int, batsmanID int32) (*models.BatsmanScore, error) {
+	var bat models.BatsmanScore
+	row := q.db.QueryRowContext(ctx, dismissCricketBatsman, matchID, teamID, inningNumber, batsmanID)
+	err := row.Scan(
+		&bat.ID,
+		&bat.PublicID,
+		&bat.MatchID,
+		&bat.TeamID,
+		&bat.BatsmanID,
+		&bat.InningNumber,
+		&bat.Position,
+		&bat.RunsScored,
+		&bat.BallsFaced,
+		&bat.Fours,
+		&bat.Sixes,
+		&bat.BattingStatus,
+		&bat.IsStriker,
+		&bat.IsCurrentlyBatting,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &bat, nil
+}
+
+const addCricketDeliveryWicket = `
+	INSERT INTO wickets (
+		match_id,
+		team_id,
+		batsman_id,
+		bowler_id,
+		inning_number,
+		wickets_number,
+		wicket_type,
+		ball_number,
+		fielder_id,
+		score
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	RETURNING id, public_id, match_id, team_id, batsman_id, bowler_id, inning_number,
+		wickets_number, wicket_type, ball_number, fielder_id, score
+`
+
+// AddCricketDeliveryWicket records the wicket of a delivery against the inning score after the ball
+func (q *Queries) AddCricketDeliveryWicket(ctx context.Context, delivery models.CricketDelivery, inningScore models.CricketScore) (*models.Wicket, error) {
+	var wicket models.Wicket
+	row := q.db.QueryRowContext(ctx, addCricketDeliveryWicket,
+		delivery.MatchID,
+		delivery.TeamID,
+		*delivery.DismissedBatsmanID,
+		delivery.BowlerID,
+		delivery.InningNumber,
+		inningScore.Wickets,
+		*delivery.WicketType,
+		inningScore.Overs,
+		delivery.FielderID,
+		inningScore.Score,
+	)
+	err := row.Scan(
+		&wicket.ID,
+		&wicket.PublicID,
+		&wicket.MatchID,
+		&wicket.TeamID,
+		&wicket.BatsmanID,
+		&wicket.BowlerID,
+		&wicket.InningNumber,
+		&wicket.WicketsNumber,
+		&wicket.WicketType,
+		&wicket.BallNumber,
+		&wicket.FielderID,
+		&wicket.Score,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &wicket, nil
+}
+
+const deleteCricketDeliveryWicket = `
+	DELETE FROM wickets
+	WHERE match_id = $1 AND team_id = $2 AND inning_number = $3 AND batsman_id = $4
+`
+
+func (q *Queries) DeleteCricketDeliveryWicket(ctx context.Context, delivery models.CricketDelivery) error {
+	_, err := q.db.ExecContext(ctx, deleteCricketDeliveryWicket, delivery.MatchID, delivery.TeamID, delivery.InningNumber, *delivery.DismissedBatsmanID)
+	if err != nil {
+		return fmt.Errorf("Failed to delete wicket: %w", err)
+	}
+	return nil
+}
+
+const reopenCricketInning = `
+	UPDATE cricket_score
+	SET is_inning_completed = false,
+		inning_status = 'in_progress'
+	WHERE match_id = $1 AND team_id = $2 AND inning_number = $3 AND is_inning_completed = true
+`
+
+func (q *Queries) ReopenCricketInning(ctx context.Context, matchID, teamID int32, inningNumber int) error {
+	_, err := q.db.ExecContext(ctx, reopenCricketInning, matchID, teamID, inningNumber)
+	if err != nil {
+		return fmt.Errorf("Failed to reopen inning: %w", err)
+	}
+	return nil
+}
+
+const getCricketMatchExtras = `
+	SELECT
+		match_id,
+		team_id,
+		inning_number,
+		COALESCE(SUM(CASE WHEN extras_type = 'wide' THEN extras_runs ELSE 0 END), 0) AS wides,
+		COALESCE(SUM(CASE WHEN extras_type = 'no_ball' THEN extras_runs ELSE 0 END), 0) AS no_balls,
+		COALESCE(SUM(CASE WHEN extras_type = 'bye' THEN extras_runs ELSE 0 END), 0) AS byes,
+		COALESCE(SUM(CASE WHEN extras_type = 'leg_bye' THEN extras_runs ELSE 0 END), 0) AS leg_byes,
+		COALESCE(SUM(penalty_runs), 0) AS penalty,
+		COALESCE(SUM(extras_runs + penalty_runs), 0) AS total
+	FROM (
+		SELECT match_id, team_id, inning_number, extras_type, extras_runs, 0 AS penalty_runs
+		FROM cricket_deliveries
+		WHERE match_id = $1 AND is_undone = false
+		UNION ALL
+		SELECT match_id, team_id, inning_number, NULL, 0, runs
+		FROM cricket_penalty_runs
+		WHERE match_id = $1 AND inning_number IS NOT NULL
+	) extras
+	GROUP BY match_id, team_id, inning_number
+	ORDER BY inning_number
+`
+
+// GetCricketMatchExtras returns the extras breakdown of every inning of the match
+func (q *Queries) GetCricketMatchExtras(ctx context.Context, matchID int32) ([]models.CricketExtras, error) {
+	rows, err := q.db.QueryContext(ctx, getCricketMatchExtras, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var extras []models.CricketExtras
+	for rows.Next() {
+		var i models.CricketExtras
+		err := rows.Scan(
+			&i.MatchID,
+			&i.TeamID,
+			&i.InningNumber,
+			&i.Wides,
+			&i.NoBalls,
+			&i.Byes,
+			&i.LegByes,
+			&i.Penalty,
+			&i.Total,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		extras = append(extras, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return extras, nil
+}
+
+// AttachCricketExtras sets the extras breakdown on each inning score of the match
+func (q *Queries) AttachCricketExtras(ctx context.Context, matchID int32, scores []models.CricketScore) error {
+	extras, err := q.GetCricketMatchExtras(ctx, matchID)
+	if err != nil {
+		return err
+	}
+	for i := range scores {
+		for j := range extras {
+			if scores[i].TeamID == extras[j].TeamID && scores[i].InningNumber == extras[j].InningNumber {
+				scores[i].Extras = &extras[j]
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"khelogames/database/models"
+)
+
+// CricketDismissal describes how a mode of dismissal is scored
+type CricketDismissal struct {
+	// the wicket is credited to the bowler
+	BowlerCredited bool
+	// counts as a wicket of the inning, retired hurt does not
+	TeamWicket bool
+	// the batter leaves between deliveries rather than on a ball
+	BetweenDeliveries bool
+	// the non-striker can be the dismissed batter
+	NonStrikerAllowed bool
+	// possible off a no ball
+	OnNoBall bool
+	// possible off a wide
+	OnWide bool
+	// a fielder must be named
+	FielderRequired bool
+	// fielding column credited to the fielder
+	FieldingCredit string
+}
+
+var cricketDismissals = map[string]CricketDismissal{
+	"Bowled":                {BowlerCredited: true, TeamWicket: true},
+	"Caught":                {BowlerCredited: true, TeamWicket: true, FielderRequired: true, FieldingCredit: "catches"},
+	"LBW":                   {BowlerCredited: true, TeamWicket: true},
+	"Stumped":               {BowlerCredited: true, TeamWicket: true, OnWide: true, FielderRequired: true, FieldingCredit: "stumpings"},
+	"Hit Wicket":            {BowlerCredited: true, TeamWicket: true, OnWide: true},
+	"Run Out":               {TeamWicket: true, NonStrikerAllowed: true, OnNoBall: true, OnWide: true, FieldingCredit: "run_outs"},
+	"Obstructing The Field": {TeamWicket: true, NonStrikerAllowed: true, OnNoBall: true, OnWide: true},
+	"Hit The Ball Twice":    {TeamWicket: true, OnNoBall: true},
+	"Retired Out":           {TeamWicket: true, BetweenDeliveries: true, NonStrikerAllowed: true},
+	"Retired Hurt":          {BetweenDeliveries: true, NonStrikerAllowed: true},
+}
+
+// GetCricketDismissal returns the scoring rules of a wicket type
+func GetCricketDismissal(wicketType string) (CricketDismissal, bool) {
+	dismissal, ok := cricketDismissals[wicketType]
+	return dismissal, ok
+}
+
+const applyCricketFielding = `
+	INSERT INTO cricket_fielding_scores (
+		match_id,
+		team_id,
+		player_id,
+		inning_number,
+		catches,
+		stumpings,
+		run_outs
+	) VALUES ($1, $2, $3, $4, $5, $6, $7)
+	ON CONFLICT (match_id, player_id, inning_number) DO UPDATE SET
+		catches = cricket_fielding_scores.catches + EXCLUDED.catches,
+		stumpings = cricket_fielding_scores.stumpings + EXCLUDED.stumpings,
+		run_outs = cricket_fielding_scores.run_outs + EXCLUDED.run_outs
+`
+
+// ApplyCricketFielding adds (sign = 1) or removes (sign = -1) the fielder's share of a dismissal
+func (q *Queries) ApplyCricketFielding(ctx context.Context, delivery models.CricketDelivery, fieldingTeamID int32, sign int) error {
+	if !delivery.IsWicket || delivery.FielderID == nil || delivery.WicketType == nil {
+		return nil
+	}
+	dismissal, ok := GetCricketDismissal(*delivery.WicketType)
+	if !ok || dismissal.FieldingCredit == "" {
+		return nil
+	}
+
+	catches, stumpings, runOuts := 0, 0, 0
+	switch dismissal.FieldingCredit {
+	case "catches":
+		catches = sign
+	case "stumpings":
+		stumpings = sign
+	case "run_outs":
+		runOuts = sign
+	}
+
+	_, err := q.db.ExecContext(ctx, applyCricketFielding,
+		delivery.MatchID,
+		fieldingTeamID,
+		*delivery.FielderID,
+		delivery.InningNumber,
+		catches,
+		stumpings,
+		runOuts,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to update fielding score: %w", err)
+	}
+	return nil
+}
+
+const getCricketFieldingScores = `
+	SELECT id, public_id, match_id, team_id, player_id, inning_number, catches, stumpings, run_outs
+	FROM cricket_fielding_scores
+	WHERE match_id = $1 AND team_id <> $2
+		AND (catches > 0 OR stumpings > 0 OR run_outs > 0)
+	ORDER BY inning_number, id
+`
+
+// GetCricketFieldingScores returns the fielding figures of the side bowling at the given batting team
+func (q *Queries) GetCricketFieldingScores(ctx context.Context, matchID, battingTeamID int32) ([]models.CricketFieldingScore, error) {
+	rows, err := q.db.QueryContext(ctx, getCricketFieldingScores, matchID, battingTeamID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var scores []models.CricketFieldingScore
+	for rows.Next() {
+		var i models.CricketFieldingScore
+		err := rows.Scan(
+			&i.ID,
+			&i.PublicID,
+			&i.MatchID,
+			&i.TeamID,
+			&i.PlayerID,
+			&i.InningNumber,
+			&i.Catches,
+			&i.Stumpings,
+			&i.RunOuts,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		scores = append(scores, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
+
+const updateCricketInningWickets = `
+	UPDATE cricket_score
+	SET wickets = wickets + $4
+	WHERE match_id = $1 AND team_id = $2 AND inning_number = $3
+	RETURNING id, public_id, match_id, team_id, inning_number, score, wickets, overs, run_rate,
+		target_run_rate, follow_on, is_inning_completed, declared, inning_status
+`
+
+// UpdateCricketInningWickets changes the wickets of an inning for a dismissal off no delivery
+func (q *Queries) UpdateCricketInningWickets(ctx context.Context, matchID, teamID int32, inningNumber int, delta int) (*models.CricketScore, error) {
+	var i models.CricketScore
+	row := q.db.QueryRowContext(ctx, updateCricketInningWickets, matchID, teamID, inningNumber, delta)
+	err := row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.TeamID,
+		&i.InningNumber,
+		&i.Score,
+		&i.Wickets,
+		&i.Overs,
+		&i.RunRate,
+		&i.TargetRunRate,
+		&i.FollowOn,
+		&i.IsInningCompleted,
+		&i.Declared,
+		&i.InningStatus,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const getCricketRetiredHurtWicket = `
+	SELECT id, public_id, match_id, team_id, batsman_id, bowler_id, inning_number,
+		wickets_number, wicket_type, ball_number, fielder_id, score
+	FROM wickets
+	WHERE match_id = $1 AND team_id = $2 AND inning_number = $3 AND batsman_id = $4
+		AND wicket_type = 'Retired Hurt'
+`
+
+// GetCricketRetiredHurtWicket returns nil when the batsman has not retired hurt
+func (q *Queries) GetCricketRetiredHurtWicket(ctx context.Context, matchID, teamID int32, inningNumber int, batsmanID int32) (*models.Wicket, error) {
+	var wicket models.Wicket
+	row := q.db.QueryRowContext(ctx, getCricketRetiredHurtWicket, matchID, teamID, inningNumber, batsmanID)
+	err := row.Scan(
+		&wicket.ID,
+		&wicket.PublicID,
+		&wicket.MatchID,
+		&wicket.TeamID,
+		&wicket.BatsmanID,
+		&wicket.BowlerID,
+		&wicket.InningNumber,
+		&wicket.WicketsNumber,
+		&wicket.WicketType,
+		&wicket.BallNumber,
+		&wicket.FielderID,
+		&wicket.Score,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &wicket, nil
+}
+
+const resumeCricketBatsman = `
+	UPDATE batsman_score
+	SET is_currently_batting = true,
+		is_striker = $5
+	WHERE match_id = $1 AND team_id = $2 AND inning_number = $3 AND batsman_id = $4
+	RETURNING id, public_id, match_id, team_id, batsman_id, inning_number, position, runs_scored,
+		balls_faced, fours, sixes, batting_status, is_striker, is_currently_batting
+`
+
+// ResumeCricketBatsman brings a retired hurt batsman back to the crease
+func (q *Queries) ResumeCricketBatsman(ctx context.Context, matchID, teamID int32, inningNumber int, batsmanID int32, isStriker bool) (*models.BatsmanScore, error) {
+	var bat models.BatsmanScore
+	row := q.db.QueryRowContext(ctx, resumeCricketBatsman, matchID, teamID, inningNumber, batsmanID, isStriker)
+	err := row.Scan(
+		&bat.ID,
+		&bat.PublicID,
+		&bat.MatchID,
+		&bat.TeamID,
+		&bat.BatsmanID,
+		&bat.InningNumber,
+		&bat.Position,
+		&bat.RunsScored,
+		&bat.BallsFaced,
+		&bat.Fours,
+		&bat.Sixes,
+		&bat.BattingStatus,
+		&bat.IsStriker,
+		&bat.IsCurrentlyBatting,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &bat, nil
+}
+
+const countRetiredCricketBatsmen = `
+	SELECT COUNT(*)
+	FROM wickets w
+	JOIN batsman_score bs ON bs.match_id = w.match_id AND bs.team_id = w.team_id
+		AND bs.inning_number = w.inning_number AND bs.batsman_id = w.batsman_id
+	WHERE w.match_id = $1 AND w.team_id = $2 AND w.inning_number = $3
+		AND w.batsman_id IN ($4, $5)
+		AND w.wicket_type IN ('Retired Out', 'Retired Hurt')
+		AND bs.is_currently_batting = false
+`
+
+// CountRetiredCricketBatsmen returns how many of the two batsmen have retired and not resumed
+func (q *Queries) CountRetiredCricketBatsmen(ctx context.Context, matchID, teamID int32, inningNumber int, strikerID, nonStrikerID int32) (int, error) {
+	var count int
+	row := q.db.QueryRowContext(ctx, countRetiredCricketBatsmen, matchID, teamID, inningNumber, strikerID, nonStrikerID)
+	if err := row.Scan(&count); err != nil {
+		return 0, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,72 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"khelogames/database/models"
+)
+
+const declareCricketInning = `
+	UPDATE cricket_score
+	SET declared = true
+	WHERE match_id = $1 AND team_id = $2 AND inning_number = $3 AND is_inning_completed = false
+`
+
+// DeclareCricketInning marks a running inning as declared, the inning is closed separately
+func (q *Queries) DeclareCricketInning(ctx context.Context, matchID, teamID int32, inningNumber int) error {
+	result, err := q.db.ExecContext(ctx, declareCricketInning, matchID, teamID, inningNumber)
+	if err != nil {
+		return fmt.Errorf("Failed to declare inning: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("Failed to declare inning: %w", err)
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+const updateCricketMatchDay = `
+	UPDATE matches
+	SET day_number = $2,
+		sub_status = $3
+	WHERE id = $1 AND status_code = 'in_progress'
+	RETURNING *
+`
+
+// UpdateCricketMatchDay moves a match in progress to a day of play and session break,
+// a nil sub status means play is on
+func (q *Queries) UpdateCricketMatchDay(ctx context.Context, matchID int64, dayNumber int, subStatus *string) (*models.Match, error) {
+	row := q.db.QueryRowContext(ctx, updateCricketMatchDay, matchID, dayNumber, subStatus)
+	var i models.Match
+	err := row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.TournamentID,
+		&i.AwayTeamID,
+		&i.HomeTeamID,
+		&i.StartTimestamp,
+		&i.EndTimestamp,
+		&i.Type,
+		&i.StatusCode,
+		&i.Result,
+		&i.Stage,
+		&i.KnockoutLevelID,
+		&i.MatchFormat,
+		&i.DayNumber,
+		&i.SubStatus,
+		&i.LocationID,
+		&i.LocationLocked,
+		&i.GameID,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"khelogames/database/models"
+)
+
+const getCricketMatchDeliveries = `
+	SELECT ` + cricketDeliveryColumns + ` FROM cricket_deliveries
+	WHERE match_id = $1 AND is_undone = false
+	ORDER BY inning_number ASC, id ASC
+`
+
+// GetCricketMatchDeliveries returns the deliveries of every inning of the match in the order they were bowled
+func (q *Queries) GetCricketMatchDeliveries(ctx context.Context, matchID int32) ([]models.CricketDelivery, error) {
+	rows, err := q.db.QueryContext(ctx, getCricketMatchDeliveries, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []models.CricketDelivery
+	for rows.Next() {
+		var i models.CricketDelivery
+		if err := scanCricketDelivery(rows, &i); err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		deliveries = append(deliveries, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+const getCricketMatchWickets = `
+	SELECT id, public_id, match_id, team_id, batsman_id, bowler_id, inning_number,
+		wickets_number, wicket_type, ball_number, fielder_id, score
+	FROM wickets
+	WHERE match_id = $1
+	ORDER BY inning_number ASC, wickets_number ASC
+`
+
+// GetCricketMatchWickets returns the wickets of every inning of the match in the order they fell
+func (q *Queries) GetCricketMatchWickets(ctx context.Context, matchID int32) ([]models.Wicket, error) {
+	rows, err := q.db.QueryContext(ctx, getCricketMatchWickets, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var wickets []models.Wicket
+	for rows.Next() {
+		var wicket models.Wicket
+		err := rows.Scan(
+			&wicket.ID,
+			&wicket.PublicID,
+			&wicket.MatchID,
+			&wicket.TeamID,
+			&wicket.BatsmanID,
+			&wicket.BowlerID,
+			&wicket.InningNumber,
+			&wicket.WicketsNumber,
+			&wicket.WicketType,
+			&wicket.BallNumber,
+			&wicket.FielderID,
+			&wicket.Score,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		wickets = append(wickets, wicket)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return wickets, nil
+}
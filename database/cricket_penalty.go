@@ -0,0 +1,110 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"khelogames/database/models"
+)
+
+const addCricketPenaltyRuns = `
+	INSERT INTO cricket_penalty_runs (
+		match_id,
+		team_id,
+		inning_number,
+		runs
+	) VALUES ($1, $2, $3, $4)
+	RETURNING id, public_id, match_id, team_id, inning_number, runs, created_at
+`
+
+// AddCricketPenaltyRuns records penalty runs awarded to a team, a nil inning number leaves them
+// to be added to the next inning the team starts
+func (q *Queries) AddCricketPenaltyRuns(ctx context.Context, matchID, teamID int32, inningNumber *int, runs int) (*models.CricketPenaltyRuns, error) {
+	var i models.CricketPenaltyRuns
+	row := q.db.QueryRowContext(ctx, addCricketPenaltyRuns, matchID, teamID, inningNumber, runs)
+	err := row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.TeamID,
+		&i.InningNumber,
+		&i.Runs,
+		&i.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const getCricketMatchPenaltyRuns = `
+	SELECT id, public_id, match_id, team_id, inning_number, runs, created_at
+	FROM cricket_penalty_runs
+	WHERE match_id = $1 AND inning_number IS NOT NULL
+	ORDER BY created_at, id
+`
+
+// GetCricketMatchPenaltyRuns returns the penalty runs added to the innings of the match in the
+// order they were awarded
+func (q *Queries) GetCricketMatchPenaltyRuns(ctx context.Context, matchID int32) ([]models.CricketPenaltyRuns, error) {
+	rows, err := q.db.QueryContext(ctx, getCricketMatchPenaltyRuns, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var penalties []models.CricketPenaltyRuns
+	for rows.Next() {
+		var i models.CricketPenaltyRuns
+		err := rows.Scan(
+			&i.ID,
+			&i.PublicID,
+			&i.MatchID,
+			&i.TeamID,
+			&i.InningNumber,
+			&i.Runs,
+			&i.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		penalties = append(penalties, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return penalties, nil
+}
+
+const addCricketInningPenaltyRuns = `
+	UPDATE cricket_score
+	SET score = score + $4
+	WHERE match_id = $1 AND team_id = $2 AND inning_number = $3
+	RETURNING id, public_id, match_id, team_id, inning_number, score, wickets, overs, run_rate,
+		target_run_rate, follow_on, is_inning_completed, declared, inning_status
+`
+
+// AddCricketInningPenaltyRuns adds penalty runs to the score of an inning
+func (q *Queries) AddCricketInningPenaltyRuns(ctx context.Context, matchID, teamID int32, inningNumber int, runs int) (*models.CricketScore, error) {
+	var i models.CricketScore
+	row := q.db.QueryRowContext(ctx, addCricketInningPenaltyRuns, matchID, teamID, inningNumber, runs)
+	err := row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.TeamID,
+		&i.InningNumber,
+		&i.Score,
+		&i.Wickets,
+		&i.Overs,
+		&i.RunRate,
+		&i.TargetRunRate,
+		&i.FollowOn,
+		&i.IsInningCompleted,
+		&i.Declared,
+		&i.InningStatus,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
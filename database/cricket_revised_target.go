@@ -0,0 +1,183 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"khelogames/database/models"
+)
+
+const addCricketInterruption = `
+	INSERT INTO cricket_interruptions (
+		match_id,
+		team_id,
+		inning_number,
+		balls_bowled,
+		wickets,
+		overs_lost
+	) VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id, public_id, match_id, team_id, inning_number, balls_bowled, wickets, overs_lost, created_at
+`
+
+type AddCricketInterruptionParams struct {
+	MatchID      int32 `json:"match_id"`
+	TeamID       int32 `json:"team_id"`
+	InningNumber int   `json:"inning_number"`
+	BallsBowled  int   `json:"balls_bowled"`
+	Wickets      int   `json:"wickets"`
+	OversLost    int   `json:"overs_lost"`
+}
+
+func (q *Queries) AddCricketInterruption(ctx context.Context, arg AddCricketInterruptionParams) (*models.CricketInterruption, error) {
+	var i models.CricketInterruption
+	row := q.db.QueryRowContext(ctx, addCricketInterruption,
+		arg.MatchID,
+		arg.TeamID,
+		arg.InningNumber,
+		arg.BallsBowled,
+		arg.Wickets,
+		arg.OversLost,
+	)
+	err := row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.TeamID,
+		&i.InningNumber,
+		&i.BallsBowled,
+		&i.Wickets,
+		&i.OversLost,
+		&i.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const getCricketInterruptions = `
+	SELECT id, public_id, match_id, team_id, inning_number, balls_bowled, wickets, overs_lost, created_at
+	FROM cricket_interruptions
+	WHERE match_id = $1
+	ORDER BY inning_number, id
+`
+
+func (q *Queries) GetCricketInterruptions(ctx context.Context, matchID int32) ([]models.CricketInterruption, error) {
+	rows, err := q.db.QueryContext(ctx, getCricketInterruptions, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var interruptions []models.CricketInterruption
+	for rows.Next() {
+		var i models.CricketInterruption
+		err := rows.Scan(
+			&i.ID,
+			&i.PublicID,
+			&i.MatchID,
+			&i.TeamID,
+			&i.InningNumber,
+			&i.BallsBowled,
+			&i.Wickets,
+			&i.OversLost,
+			&i.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		interruptions = append(interruptions, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return interruptions, nil
+}
+
+const cricketRevisedTargetColumns = `
+	id, public_id, match_id, first_inning_overs, second_inning_overs, first_inning_resources,
+	second_inning_resources, revised_target, par_score, updated_at
+`
+
+func scanCricketRevisedTarget(row interface{ Scan(dest ...any) error }, i *models.CricketRevisedTarget) error {
+	return row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.FirstInningOvers,
+		&i.SecondInningOvers,
+		&i.FirstInningResources,
+		&i.SecondInningResources,
+		&i.RevisedTarget,
+		&i.ParScore,
+		&i.UpdatedAt,
+	)
+}
+
+const upsertCricketRevisedTarget = `
+	INSERT INTO cricket_revised_targets (
+		match_id,
+		first_inning_overs,
+		second_inning_overs,
+		first_inning_resources,
+		second_inning_resources,
+		revised_target,
+		par_score
+	) VALUES ($1, $2, $3, $4, $5, $6, $7)
+	ON CONFLICT (match_id) DO UPDATE SET
+		first_inning_overs = EXCLUDED.first_inning_overs,
+		second_inning_overs = EXCLUDED.second_inning_overs,
+		first_inning_resources = EXCLUDED.first_inning_resources,
+		second_inning_resources = EXCLUDED.second_inning_resources,
+		revised_target = EXCLUDED.revised_target,
+		par_score = EXCLUDED.par_score,
+		updated_at = CURRENT_TIMESTAMP
+	RETURNING ` + cricketRevisedTargetColumns
+
+type UpsertCricketRevisedTargetParams struct {
+	MatchID               int32   `json:"match_id"`
+	FirstInningOvers      int     `json:"first_inning_overs"`
+	SecondInningOvers     int     `json:"second_inning_overs"`
+	FirstInningResources  float64 `json:"first_inning_resources"`
+	SecondInningResources float64 `json:"second_inning_resources"`
+	RevisedTarget         int     `json:"revised_target"`
+	ParScore              int     `json:"par_score"`
+}
+
+func (q *Queries) UpsertCricketRevisedTarget(ctx context.Context, arg UpsertCricketRevisedTargetParams) (*models.CricketRevisedTarget, error) {
+	var i models.CricketRevisedTarget
+	row := q.db.QueryRowContext(ctx, upsertCricketRevisedTarget,
+		arg.MatchID,
+		arg.FirstInningOvers,
+		arg.SecondInningOvers,
+		arg.FirstInningResources,
+		arg.SecondInningResources,
+		arg.RevisedTarget,
+		arg.ParScore,
+	)
+	err := scanCricketRevisedTarget(row, &i)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const getCricketRevisedTarget = `
+	SELECT ` + cricketRevisedTargetColumns + `
+	FROM cricket_revised_targets
+	WHERE match_id = $1
+`
+
+// GetCricketRevisedTarget returns nil when the match has not been shortened
+func (q *Queries) GetCricketRevisedTarget(ctx context.Context, matchID int32) (*models.CricketRevisedTarget, error) {
+	var i models.CricketRevisedTarget
+	row := q.db.QueryRowContext(ctx, getCricketRevisedTarget, matchID)
+	err := scanCricketRevisedTarget(row, &i)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
@@ -246,6 +246,13 @@ WITH matchID AS (
 ),
 teamID AS (
 	SELECT * FROM teams WHERE public_id = $2
+),
+pendingPenalty AS (
+	UPDATE cricket_penalty_runs pr
+	SET inning_number = $3
+	FROM matchID, teamID
+	WHERE pr.match_id = matchID.id AND pr.team_id = teamID.id AND pr.inning_number IS NULL
+	RETURNING pr.runs
 )
 INSERT INTO cricket_score (
     match_id,
@@ -265,7 +272,7 @@ SELECT
 	matchID.id,
 	teamID.id,
 	$3,
-	$4,
+	$4 + (SELECT COALESCE(SUM(runs), 0) FROM pendingPenalty),
 	$5,
 	$6,
 	CAST($7 AS numeric(5,2)),
@@ -293,6 +300,8 @@ type NewCricketScoreParams struct {
 	InningStatus      string    `json:"inning_status"`
 }
 
+// NewCricketScore starts an inning, penalty runs awarded to the team before it batted are added to
+// its score
 func (q *Queries) NewCricketScore(ctx context.Context, arg NewCricketScoreParams) (*models.CricketScore, error) {
 
 	row := q.db.QueryRowContext(ctx, newCricketScore,
@@ -164,55 +164,29 @@ func (q *Queries) GetCricketStanding(ctx context.Context, tournamentPublicID uui
 }
 
 const updateCricketStanding = `
-UPDATE cricket_standing AS ts
-SET 
-	matches = (
-        SELECT COUNT(*)
-        FROM matches ms
-        LEFT JOIN cricket_score cs_home
-            ON cs_home.match_id = ms.id
-            AND cs_home.team_id = ms.home_team_id
-        LEFT JOIN football_score cs_away
-            ON cs_away.match_id = ms.id
-            AND cs_away.team_id = ms.away_team_id
-        WHERE (ms.home_team_id = ts.team_id OR ms.away_team_id = ts.team_id)
-        AND ms.tournament_id = ts.tournament_id
+WITH results AS (
+    SELECT
+        COUNT(*) AS matches,
+        COUNT(*) FILTER (WHERE ms.result = $2) AS wins,
+        COUNT(*) FILTER (WHERE ms.result IS NOT NULL AND ms.result <> 0 AND ms.result <> $2) AS loss,
+        COUNT(*) FILTER (WHERE COALESCE(ms.result, 0) = 0) AS draw
+    FROM matches ms
+    WHERE (ms.home_team_id = $2 OR ms.away_team_id = $2)
+        AND ms.tournament_id = $1
         AND (LOWER(ms.stage) = 'group' OR LOWER(ms.stage) = 'league')
-        AND (ms.status_code) = 'finished'
-    ),
-    wins = COALESCE((
-        SELECT COUNT(*)
-        FROM matches AS ms
-        LEFT JOIN cricket_score AS cs_home ON ms.id = cs_home.match_id AND ms.home_team_id = ts.team_id
-        LEFT JOIN cricket_score AS cs_away ON ms.id = cs_away.match_id AND ms.away_team_id = cs_away.team_id
-        WHERE ((ms.home_team_id = ts.team_id AND cs_home.score > cs_away.score)
-        OR (ms.away_team_id = ts.team_id AND cs_away.score > cs_home.score))
-		AND (ms.status_code) = 'finished'
-    ), 0),
-    loss = COALESCE((
-        SELECT COUNT(*)
-        FROM matches AS ms
-        LEFT JOIN cricket_score cs_home ON ms.id = cs_home.match_id AND ms.home_team_id = cs_home.team_id
-        LEFT JOIN cricket_score cs_away ON ms.id = cs_away.match_id AND ms.away_team_id = cs_away.team_id
-        WHERE ((ms.home_team_id = ts.team_id AND cs_home.score < cs_away.score)
-        OR (ms.away_team_id = ts.team_id AND cs_away.score < cs_home.score))
-		AND (ms.status_code) = 'finished'
-    ), 0),
-    draw = COALESCE((
-        SELECT COUNT(*)
-        FROM matches AS ms
-        LEFT JOIN cricket_score AS cs_home ON ms.id = cs_home.match_id AND ms.home_team_id = ts.team_id
-        LEFT JOIN cricket_score AS cs_away ON ms.id = cs_away.match_id AND ms.away_team_id = cs_away.team_id
-        WHERE ((ms.home_team_id = ts.team_id AND cs_home.score = cs_away.score)
-        OR (ms.away_team_id = ts.team_id AND cs_away.score = cs_home.score))
-		AND (ms.status_code) = 'finished'
-    ), 0),
-    points = ((wins * 3) + draw)
-WHERE ts.tournament_id = t.id
-  AND ts.team_id = tm.id
-  AND t.id = $1
-  AND tm.id = $2
-RETURNING *
+        AND ms.status_code = 'finished'
+)
+UPDATE cricket_standing AS ts
+SET
+    matches = r.matches,
+    wins = r.wins,
+    loss = r.loss,
+    draw = r.draw,
+    points = (r.wins * 3) + r.draw
+FROM results r
+WHERE ts.tournament_id = $1
+  AND ts.team_id = $2
+RETURNING ts.id, ts.public_id, ts.tournament_id, ts.group_id, ts.team_id, ts.matches, ts.wins, ts.loss, ts.draw, ts.points
 `
 
 func (q *Queries) UpdateCricketStanding(ctx context.Context, tournamentID, teamID int32) (*models.CricketStanding, error) {
@@ -224,6 +198,7 @@ func (q *Queries) UpdateCricketStanding(ctx context.Context, tournamentID, teamI
 		&i.TournamentID,
 		&i.GroupID,
 		&i.TeamID,
+		&i.Matches,
 		&i.Wins,
 		&i.Loss,
 		&i.Draw,
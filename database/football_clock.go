@@ -0,0 +1,169 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"khelogames/database/models"
+
+	"github.com/google/uuid"
+)
+
+const footballMatchClockColumns = `
+	c.id, c.public_id, c.match_id, c.state, c.is_running, c.elapsed_seconds, c.started_at,
+	c.added_minutes, c.updated_at
+`
+
+func scanFootballMatchClock(row interface{ Scan(dest ...any) error }, i *models.FootballMatchClock) error {
+	return row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.State,
+		&i.IsRunning,
+		&i.ElapsedSeconds,
+		&i.StartedAt,
+		&i.AddedMinutes,
+		&i.UpdatedAt,
+	)
+}
+
+const getFootballMatchClock = `
+	SELECT ` + footballMatchClockColumns + `
+	FROM football_match_clocks c
+	WHERE c.match_id = $1
+`
+
+// GetFootballMatchClock returns nil when the clock of the match has not been started
+func (q *Queries) GetFootballMatchClock(ctx context.Context, matchID int32) (*models.FootballMatchClock, error) {
+	var i models.FootballMatchClock
+	row := q.db.QueryRowContext(ctx, getFootballMatchClock, matchID)
+	err := scanFootballMatchClock(row, &i)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const upsertFootballMatchClock = `
+	INSERT INTO football_match_clocks AS c (
+		match_id,
+		state,
+		is_running,
+		elapsed_seconds,
+		started_at,
+		added_minutes,
+		updated_at
+	) VALUES ($1, $2, $3, $4, $5, $6, $7)
+	ON CONFLICT (match_id) DO UPDATE SET
+		state = EXCLUDED.state,
+		is_running = EXCLUDED.is_running,
+		elapsed_seconds = EXCLUDED.elapsed_seconds,
+		started_at = EXCLUDED.started_at,
+		added_minutes = EXCLUDED.added_minutes,
+		updated_at = EXCLUDED.updated_at
+	RETURNING ` + footballMatchClockColumns
+
+type UpsertFootballMatchClockParams struct {
+	MatchID        int32  `json:"match_id"`
+	State          string `json:"state"`
+	IsRunning      bool   `json:"is_running"`
+	ElapsedSeconds int    `json:"elapsed_seconds"`
+	StartedAt      *int64 `json:"started_at"`
+	AddedMinutes   int    `json:"added_minutes"`
+	UpdatedAt      int64  `json:"updated_at"`
+}
+
+func (q *Queries) UpsertFootballMatchClock(ctx context.Context, arg UpsertFootballMatchClockParams) (*models.FootballMatchClock, error) {
+	row := q.db.QueryRowContext(ctx, upsertFootballMatchClock,
+		arg.MatchID,
+		arg.State,
+		arg.IsRunning,
+		arg.ElapsedSeconds,
+		arg.StartedAt,
+		arg.AddedMinutes,
+		arg.UpdatedAt,
+	)
+	var i models.FootballMatchClock
+	if err := scanFootballMatchClock(row, &i); err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const getRunningFootballMatchClocks = `
+	SELECT m.public_id, ` + footballMatchClockColumns + `
+	FROM football_match_clocks c
+	JOIN matches m ON m.id = c.match_id
+	WHERE c.is_running = true
+`
+
+// RunningFootballMatchClock is a running clock with the public id of its match
+type RunningFootballMatchClock struct {
+	MatchPublicID uuid.UUID                 `json:"match_public_id"`
+	Clock         models.FootballMatchClock `json:"clock"`
+}
+
+// GetRunningFootballMatchClocks returns the clocks of every match being played
+func (q *Queries) GetRunningFootballMatchClocks(ctx context.Context) ([]RunningFootballMatchClock, error) {
+	rows, err := q.db.QueryContext(ctx, getRunningFootballMatchClocks)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var clocks []RunningFootballMatchClock
+	for rows.Next() {
+		var i RunningFootballMatchClock
+		err := rows.Scan(
+			&i.MatchPublicID,
+			&i.Clock.ID,
+			&i.Clock.PublicID,
+			&i.Clock.MatchID,
+			&i.Clock.State,
+			&i.Clock.IsRunning,
+			&i.Clock.ElapsedSeconds,
+			&i.Clock.StartedAt,
+			&i.Clock.AddedMinutes,
+			&i.Clock.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		clocks = append(clocks, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return clocks, nil
+}
+
+const addFootballPeriodIncident = `
+	INSERT INTO football_incidents (
+		tournament_id,
+		match_id,
+		team_id,
+		periods,
+		incident_type,
+		incident_time,
+		description,
+		penalty_shootout_scored,
+		added_time
+	)
+	SELECT m.tournament_id, m.id, NULL, $2, 'period', $3, '', false, $4
+	FROM matches m
+	WHERE m.id = $1
+	RETURNING ` + footballIncidentColumns
+
+// AddFootballPeriodIncident records a period marker such as half_time in the match incidents
+func (q *Queries) AddFootballPeriodIncident(ctx context.Context, matchID int32, periods string, incidentTime int, addedTime int) (*models.FootballIncident, error) {
+	row := q.db.QueryRowContext(ctx, addFootballPeriodIncident, matchID, periods, incidentTime, addedTime)
+	var i models.FootballIncident
+	if err := scanFootballIncident(row, &i); err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
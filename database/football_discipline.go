@@ -0,0 +1,334 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"khelogames/database/models"
+
+	"github.com/google/uuid"
+)
+
+const upsertFootballDisciplinaryRules = `
+	INSERT INTO football_disciplinary_rules (
+		tournament_id,
+		yellow_card_threshold,
+		yellow_card_ban,
+		red_card_ban,
+		carry_over_to_knockout,
+		updated_at
+	)
+	SELECT t.id, $2, $3, $4, $5, NOW()
+	FROM tournaments t
+	WHERE t.public_id = $1
+	ON CONFLICT (tournament_id) DO UPDATE SET
+		yellow_card_threshold = EXCLUDED.yellow_card_threshold,
+		yellow_card_ban = EXCLUDED.yellow_card_ban,
+		red_card_ban = EXCLUDED.red_card_ban,
+		carry_over_to_knockout = EXCLUDED.carry_over_to_knockout,
+		updated_at = EXCLUDED.updated_at
+	RETURNING id, tournament_id, yellow_card_threshold, yellow_card_ban, red_card_ban, carry_over_to_knockout, updated_at
+`
+
+type UpsertFootballDisciplinaryRulesParams struct {
+	TournamentPublicID  uuid.UUID `json:"tournament_public_id"`
+	YellowCardThreshold int       `json:"yellow_card_threshold"`
+	YellowCardBan       int       `json:"yellow_card_ban"`
+	RedCardBan          int       `json:"red_card_ban"`
+	CarryOverToKnockout bool      `json:"carry_over_to_knockout"`
+}
+
+func scanFootballDisciplinaryRules(row *sql.Row) (*models.FootballDisciplinaryRules, error) {
+	var i models.FootballDisciplinaryRules
+	err := row.Scan(
+		&i.ID,
+		&i.TournamentID,
+		&i.YellowCardThreshold,
+		&i.YellowCardBan,
+		&i.RedCardBan,
+		&i.CarryOverToKnockout,
+		&i.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+func (q *Queries) UpsertFootballDisciplinaryRules(ctx context.Context, arg UpsertFootballDisciplinaryRulesParams) (*models.FootballDisciplinaryRules, error) {
+	row := q.db.QueryRowContext(ctx, upsertFootballDisciplinaryRules,
+		arg.TournamentPublicID,
+		arg.YellowCardThreshold,
+		arg.YellowCardBan,
+		arg.RedCardBan,
+		arg.CarryOverToKnockout,
+	)
+	return scanFootballDisciplinaryRules(row)
+}
+
+const getFootballDisciplinaryRules = `
+	SELECT id, tournament_id, yellow_card_threshold, yellow_card_ban, red_card_ban, carry_over_to_knockout, updated_at
+	FROM football_disciplinary_rules
+	WHERE tournament_id = $1
+`
+
+// GetFootballDisciplinaryRules returns nil when the tournament has not set its own rules
+func (q *Queries) GetFootballDisciplinaryRules(ctx context.Context, tournamentID int32) (*models.FootballDisciplinaryRules, error) {
+	row := q.db.QueryRowContext(ctx, getFootballDisciplinaryRules, tournamentID)
+	return scanFootballDisciplinaryRules(row)
+}
+
+const getFootballMatchCards = `
+	SELECT
+		fip.player_id,
+		fi.team_id,
+		COUNT(*) FILTER (WHERE fi.incident_type = 'yellow_card') AS yellow_cards,
+		COUNT(*) FILTER (WHERE fi.incident_type = 'red_card') AS red_cards
+	FROM football_incidents fi
+	JOIN football_incident_player fip ON fip.incident_id = fi.id
+	WHERE fi.match_id = $1
+		AND fi.incident_type IN ('yellow_card', 'red_card')
+		AND fi.is_cancelled = false
+		AND fi.team_id IS NOT NULL
+	GROUP BY fip.player_id, fi.team_id
+`
+
+// FootballMatchCards are the cards a player was shown in a match
+type FootballMatchCards struct {
+	PlayerID    int32 `json:"player_id"`
+	TeamID      int32 `json:"team_id"`
+	YellowCards int   `json:"yellow_cards"`
+	RedCards    int   `json:"red_cards"`
+}
+
+func (q *Queries) GetFootballMatchCards(ctx context.Context, matchID int32) ([]FootballMatchCards, error) {
+	rows, err := q.db.QueryContext(ctx, getFootballMatchCards, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var items []FootballMatchCards
+	for rows.Next() {
+		var i FootballMatchCards
+		if err := rows.Scan(&i.PlayerID, &i.TeamID, &i.YellowCards, &i.RedCards); err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFootballPreviousYellowCards = `
+	SELECT COUNT(*)
+	FROM football_incidents fi
+	JOIN football_incident_player fip ON fip.incident_id = fi.id
+	JOIN matches m ON m.id = fi.match_id
+	JOIN matches cur ON cur.id = $1
+	WHERE m.tournament_id = cur.tournament_id
+		AND fip.player_id = $2
+		AND fi.incident_type = 'yellow_card'
+		AND fi.is_cancelled = false
+		AND (m.start_timestamp, m.id) < (cur.start_timestamp, cur.id)
+		AND ($3 = false OR m.stage = 'knockout')
+		AND NOT EXISTS (
+			SELECT 1
+			FROM football_incidents red
+			JOIN football_incident_player rp ON rp.incident_id = red.id
+			WHERE red.match_id = m.id
+				AND rp.player_id = $2
+				AND red.incident_type = 'red_card'
+				AND red.is_cancelled = false
+		)
+`
+
+// GetFootballPreviousYellowCards counts the yellow cards the player carries into the match, cards
+// of matches the player was sent off in are left out. knockoutOnly counts the knockout stage alone.
+func (q *Queries) GetFootballPreviousYellowCards(ctx context.Context, matchID, playerID int32, knockoutOnly bool) (int, error) {
+	var count int
+	err := q.db.QueryRowContext(ctx, getFootballPreviousYellowCards, matchID, playerID, knockoutOnly).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return count, nil
+}
+
+const deleteFootballMatchSuspensions = `
+	DELETE FROM football_suspensions WHERE source_match_id = $1
+`
+
+// DeleteFootballMatchSuspensions clears the bans picked up in a match before they are worked out again
+func (q *Queries) DeleteFootballMatchSuspensions(ctx context.Context, matchID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteFootballMatchSuspensions, matchID)
+	if err != nil {
+		return fmt.Errorf("Failed to delete suspensions: %w", err)
+	}
+	return nil
+}
+
+const addFootballSuspension = `
+	INSERT INTO football_suspensions (
+		tournament_id,
+		player_id,
+		team_id,
+		source_match_id,
+		reason,
+		matches_banned,
+		created_at
+	) VALUES ($1, $2, $3, $4, $5, $6, NOW())
+	ON CONFLICT (source_match_id, player_id, reason) DO NOTHING
+	RETURNING id, public_id, tournament_id, player_id, team_id, source_match_id, reason, matches_banned, created_at
+`
+
+type AddFootballSuspensionParams struct {
+	TournamentID  int32  `json:"tournament_id"`
+	PlayerID      int32  `json:"player_id"`
+	TeamID        int32  `json:"team_id"`
+	SourceMatchID int32  `json:"source_match_id"`
+	Reason        string `json:"reason"`
+	MatchesBanned int    `json:"matches_banned"`
+}
+
+// AddFootballSuspension returns nil when the ban is already in the ledger
+func (q *Queries) AddFootballSuspension(ctx context.Context, arg AddFootballSuspensionParams) (*models.FootballSuspension, error) {
+	row := q.db.QueryRowContext(ctx, addFootballSuspension,
+		arg.TournamentID,
+		arg.PlayerID,
+		arg.TeamID,
+		arg.SourceMatchID,
+		arg.Reason,
+		arg.MatchesBanned,
+	)
+	var i models.FootballSuspension
+	err := row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.TournamentID,
+		&i.PlayerID,
+		&i.TeamID,
+		&i.SourceMatchID,
+		&i.Reason,
+		&i.MatchesBanned,
+		&i.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+// footballSuspensionJSON builds a suspension of the ledger with its player, served is the number of
+// matches sat out so far
+const footballSuspensionJSON = `
+	JSON_BUILD_OBJECT(
+		'public_id', s.public_id,
+		'tournament_id', s.tournament_id,
+		'team_id', s.team_id,
+		'reason', s.reason,
+		'matches_banned', s.matches_banned,
+		'matches_served', served.matches_served,
+		'source_match_public_id', src.public_id,
+		'created_at', s.created_at,
+		'player', JSON_BUILD_OBJECT(
+			'id', p.id,
+			'public_id', p.public_id,
+			'user_id', p.user_id,
+			'name', p.name,
+			'slug', p.slug,
+			'short_name', p.short_name,
+			'country', p.country,
+			'positions', p.positions,
+			'media_url', p.media_url
+		)
+	)
+`
+
+// getFootballSuspendedPlayers lists the players of both teams still banned when the match starts.
+// A ban is served by every finished match the team plays between the two; without carry over the
+// bans of the group stage do not reach the knockout.
+const getFootballSuspendedPlayers = `
+	WITH target AS (
+		SELECT * FROM matches WHERE public_id = $1
+	)
+	SELECT ` + footballSuspensionJSON + `
+	FROM football_suspensions s
+	JOIN target t ON t.tournament_id = s.tournament_id AND s.team_id IN (t.home_team_id, t.away_team_id)
+	JOIN matches src ON src.id = s.source_match_id
+	JOIN players p ON p.id = s.player_id
+	LEFT JOIN football_disciplinary_rules r ON r.tournament_id = s.tournament_id
+	CROSS JOIN LATERAL (
+		SELECT COUNT(*) AS matches_served
+		FROM matches m2
+		WHERE m2.tournament_id = s.tournament_id
+			AND s.team_id IN (m2.home_team_id, m2.away_team_id)
+			AND m2.status_code = 'finished'
+			AND (m2.start_timestamp, m2.id) > (src.start_timestamp, src.id)
+			AND (m2.start_timestamp, m2.id) < (t.start_timestamp, t.id)
+	) served
+	WHERE (src.start_timestamp, src.id) < (t.start_timestamp, t.id)
+		AND served.matches_served < s.matches_banned
+		AND (COALESCE(r.carry_over_to_knockout, false) OR t.stage <> 'knockout' OR src.stage = 'knockout')
+	ORDER BY s.team_id, p.name
+`
+
+func (q *Queries) GetFootballSuspendedPlayers(ctx context.Context, matchPublicID uuid.UUID) ([]map[string]interface{}, error) {
+	return q.getFootballSuspensions(ctx, getFootballSuspendedPlayers, matchPublicID)
+}
+
+const getFootballTournamentSuspensions = `
+	SELECT ` + footballSuspensionJSON + `
+	FROM football_suspensions s
+	JOIN tournaments tr ON tr.id = s.tournament_id
+	JOIN matches src ON src.id = s.source_match_id
+	JOIN players p ON p.id = s.player_id
+	CROSS JOIN LATERAL (
+		SELECT COUNT(*) AS matches_served
+		FROM matches m2
+		WHERE m2.tournament_id = s.tournament_id
+			AND s.team_id IN (m2.home_team_id, m2.away_team_id)
+			AND m2.status_code = 'finished'
+			AND (m2.start_timestamp, m2.id) > (src.start_timestamp, src.id)
+	) served
+	WHERE tr.public_id = $1
+	ORDER BY s.created_at DESC
+`
+
+// GetFootballTournamentSuspensions is the suspension ledger of the tournament
+func (q *Queries) GetFootballTournamentSuspensions(ctx context.Context, tournamentPublicID uuid.UUID) ([]map[string]interface{}, error) {
+	return q.getFootballSuspensions(ctx, getFootballTournamentSuspensions, tournamentPublicID)
+}
+
+func (q *Queries) getFootballSuspensions(ctx context.Context, query string, publicID uuid.UUID) ([]map[string]interface{}, error) {
+	rows, err := q.db.QueryContext(ctx, query, publicID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	items := []map[string]interface{}{}
+	for rows.Next() {
+		var jsonByte []byte
+		if err := rows.Scan(&jsonByte); err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		var item map[string]interface{}
+		if err := json.Unmarshal(jsonByte, &item); err != nil {
+			return nil, fmt.Errorf("Failed to unmarshal: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,168 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"khelogames/database/models"
+
+	"github.com/google/uuid"
+)
+
+const upsertFootballFormation = `
+	INSERT INTO football_formations (match_id, team_id, formation, updated_at)
+	VALUES ($1, $2, $3, NOW())
+	ON CONFLICT (match_id, team_id) DO UPDATE SET
+		formation = EXCLUDED.formation,
+		updated_at = EXCLUDED.updated_at
+	RETURNING id, public_id, match_id, team_id, formation, updated_at
+`
+
+func (q *Queries) UpsertFootballFormation(ctx context.Context, matchID, teamID int32, formation string) (*models.FootballFormation, error) {
+	var i models.FootballFormation
+	row := q.db.QueryRowContext(ctx, upsertFootballFormation, matchID, teamID, formation)
+	err := row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.TeamID,
+		&i.Formation,
+		&i.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const getFootballFormation = `
+	SELECT ff.id, ff.public_id, ff.match_id, ff.team_id, ff.formation, ff.updated_at
+	FROM football_formations ff
+	JOIN matches m ON m.id = ff.match_id
+	JOIN teams t ON t.id = ff.team_id
+	WHERE m.public_id = $1 AND t.public_id = $2
+`
+
+// GetFootballFormation returns nil when the team has not published a formation for the match
+func (q *Queries) GetFootballFormation(ctx context.Context, matchPublicID, teamPublicID uuid.UUID) (*models.FootballFormation, error) {
+	var i models.FootballFormation
+	row := q.db.QueryRowContext(ctx, getFootballFormation, matchPublicID, teamPublicID)
+	err := row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.TeamID,
+		&i.Formation,
+		&i.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const deleteFootballSquad = `
+	DELETE FROM football_squad WHERE match_id = $1 AND team_id = $2
+`
+
+// DeleteFootballSquad clears the squad of the team before a new lineup replaces it
+func (q *Queries) DeleteFootballSquad(ctx context.Context, matchID, teamID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteFootballSquad, matchID, teamID)
+	if err != nil {
+		return fmt.Errorf("Failed to delete football squad: %w", err)
+	}
+	return nil
+}
+
+const addFootballLineupPlayer = `
+	INSERT INTO football_squad (
+		match_id,
+		team_id,
+		player_id,
+		position,
+		is_substitute,
+		formation_slot
+	)
+	SELECT $1, $2, p.id, $4, $5, $6
+	FROM players p
+	WHERE p.public_id = $3
+	RETURNING *
+`
+
+type AddFootballLineupPlayerParams struct {
+	MatchID        int32     `json:"match_id"`
+	TeamID         int32     `json:"team_id"`
+	PlayerPublicID uuid.UUID `json:"player_public_id"`
+	Position       *string   `json:"position"`
+	IsSubstitute   bool      `json:"is_substitute"`
+	FormationSlot  *string   `json:"formation_slot"`
+}
+
+func (q *Queries) AddFootballLineupPlayer(ctx context.Context, arg AddFootballLineupPlayerParams) (*models.FootballSquad, error) {
+	row := q.db.QueryRowContext(ctx, addFootballLineupPlayer,
+		arg.MatchID,
+		arg.TeamID,
+		arg.PlayerPublicID,
+		arg.Position,
+		arg.IsSubstitute,
+		arg.FormationSlot,
+	)
+	var i models.FootballSquad
+	err := row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.TeamID,
+		&i.PlayerID,
+		&i.Position,
+		&i.IsSubstitute,
+		&i.Role,
+		&i.CreatedAT,
+		&i.FormationSlot,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const moveFootballFormationSlot = `
+	WITH sub AS (
+		SELECT fsp.player_in_id, fsp.player_out_id, fi.match_id
+		FROM football_substitutions_player fsp
+		JOIN football_incidents fi ON fi.id = fsp.incident_id
+		WHERE fsp.incident_id = $1
+	),
+	moving AS (
+		SELECT
+			CASE WHEN $2 THEN player_in_id ELSE player_out_id END AS from_id,
+			CASE WHEN $2 THEN player_out_id ELSE player_in_id END AS to_id,
+			match_id
+		FROM sub
+	),
+	slot AS (
+		SELECT fs.formation_slot, fs.position, mv.from_id, mv.to_id, mv.match_id
+		FROM football_squad fs
+		JOIN moving mv ON fs.match_id = mv.match_id AND fs.player_id = mv.from_id
+		WHERE fs.formation_slot IS NOT NULL
+	)
+	UPDATE football_squad fs
+	SET
+		formation_slot = CASE WHEN fs.player_id = slot.to_id THEN slot.formation_slot ELSE NULL END,
+		position = CASE WHEN fs.player_id = slot.to_id THEN slot.position ELSE fs.position END
+	FROM slot
+	WHERE fs.match_id = slot.match_id AND fs.player_id IN (slot.from_id, slot.to_id)
+`
+
+// MoveFootballFormationSlot hands the formation slot of the player going off to the player coming
+// on in the substitution; reverse hands it back when the substitution is undone
+func (q *Queries) MoveFootballFormationSlot(ctx context.Context, incidentID int64, reverse bool) error {
+	_, err := q.db.ExecContext(ctx, moveFootballFormationSlot, incidentID, reverse)
+	if err != nil {
+		return fmt.Errorf("Failed to move formation slot: %w", err)
+	}
+	return nil
+}
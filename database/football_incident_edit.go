@@ -0,0 +1,219 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"khelogames/database/models"
+
+	"github.com/google/uuid"
+)
+
+const updateFootballIncident = `
+	WITH teamID AS (
+		SELECT id FROM teams WHERE public_id = $2
+	),
+	assistID AS (
+		SELECT id FROM players WHERE public_id = $9
+	)
+	UPDATE football_incidents fi
+	SET
+		team_id = CASE WHEN $4 = 'period' THEN NULL ELSE COALESCE(teamID.id, fi.team_id) END,
+		periods = $3,
+		incident_type = $4,
+		incident_time = $5,
+		added_time = $6,
+		description = $7,
+		penalty_shootout_scored = $8,
+		assist_player_id = assistID.id
+	FROM (SELECT 1) AS one
+	LEFT JOIN teamID ON TRUE
+	LEFT JOIN assistID ON TRUE
+	WHERE fi.id = $1
+	RETURNING ` + footballIncidentColumns
+
+type UpdateFootballIncidentParams struct {
+	ID                    int64      `json:"id"`
+	TeamPublicID          *uuid.UUID `json:"team_public_id"`
+	Periods               string     `json:"periods"`
+	IncidentType          string     `json:"incident_type"`
+	IncidentTime          int        `json:"incident_time"`
+	AddedTime             int        `json:"added_time"`
+	Description           string     `json:"description"`
+	PenaltyShootoutScored bool       `json:"penalty_shootout_scored"`
+	AssistPlayerPublicID  *uuid.UUID `json:"assist_player_public_id"`
+}
+
+func (q *Queries) UpdateFootballIncident(ctx context.Context, arg UpdateFootballIncidentParams) (*models.FootballIncident, error) {
+	row := q.db.QueryRowContext(ctx, updateFootballIncident,
+		arg.ID,
+		arg.TeamPublicID,
+		arg.Periods,
+		arg.IncidentType,
+		arg.IncidentTime,
+		arg.AddedTime,
+		arg.Description,
+		arg.PenaltyShootoutScored,
+		arg.AssistPlayerPublicID,
+	)
+	var i models.FootballIncident
+	if err := scanFootballIncident(row, &i); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const updateFootballIncidentPlayer = `
+	UPDATE football_incident_player
+	SET player_id = p.id
+	FROM players p
+	WHERE football_incident_player.incident_id = $1 AND p.public_id = $2
+`
+
+func (q *Queries) UpdateFootballIncidentPlayer(ctx context.Context, incidentID int64, playerPublicID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, updateFootballIncidentPlayer, incidentID, playerPublicID)
+	if err != nil {
+		return fmt.Errorf("Failed to update incident player: %w", err)
+	}
+	return nil
+}
+
+const updateFootballSubsPlayer = `
+	UPDATE football_substitutions_player
+	SET player_in_id = player_in.id, player_out_id = player_out.id
+	FROM players player_in, players player_out
+	WHERE football_substitutions_player.incident_id = $1
+		AND player_in.public_id = $2
+		AND player_out.public_id = $3
+		AND player_in.id != player_out.id
+`
+
+func (q *Queries) UpdateFootballSubsPlayer(ctx context.Context, incidentID int64, playerInPublicID, playerOutPublicID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, updateFootballSubsPlayer, incidentID, playerInPublicID, playerOutPublicID)
+	if err != nil {
+		return fmt.Errorf("Failed to update substitution players: %w", err)
+	}
+	return nil
+}
+
+const restoreFootballVarCancelledIncident = `
+	UPDATE football_incidents
+	SET is_cancelled = false
+	WHERE id = (SELECT cancelled_incident_id FROM football_incidents WHERE id = $1)
+`
+
+const deleteFootballVarReviewsOf = `
+	DELETE FROM football_incidents WHERE cancelled_incident_id = $1
+`
+
+const deleteFootballIncidentPlayers = `
+	DELETE FROM football_incident_player WHERE incident_id = $1
+`
+
+const deleteFootballIncidentSubsPlayers = `
+	DELETE FROM football_substitutions_player WHERE incident_id = $1
+`
+
+const deleteFootballIncident = `
+	DELETE FROM football_incidents
+	WHERE id = $1
+	RETURNING ` + footballIncidentColumns
+
+// DeleteFootballIncident removes the incident with its players. Deleting a VAR review restores the
+// incident it cancelled, and the VAR reviews of a deleted incident are removed with it.
+func (q *Queries) DeleteFootballIncident(ctx context.Context, incidentID int64) (*models.FootballIncident, error) {
+	for _, query := range []string{
+		restoreFootballVarCancelledIncident,
+		deleteFootballVarReviewsOf,
+		deleteFootballIncidentPlayers,
+		deleteFootballIncidentSubsPlayers,
+	} {
+		if _, err := q.db.ExecContext(ctx, query, incidentID); err != nil {
+			return nil, fmt.Errorf("Failed to delete incident dependents: %w", err)
+		}
+	}
+
+	var i models.FootballIncident
+	row := q.db.QueryRowContext(ctx, deleteFootballIncident, incidentID)
+	if err := scanFootballIncident(row, &i); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const recomputeFootballScore = `
+	WITH scored AS (
+		SELECT
+			CASE
+				WHEN fi.incident_type = 'own_goal' AND fi.team_id = m.home_team_id THEN m.away_team_id
+				WHEN fi.incident_type = 'own_goal' THEN m.home_team_id
+				ELSE fi.team_id
+			END AS team_id,
+			fi.periods,
+			fi.incident_type,
+			fi.penalty_shootout_scored
+		FROM football_incidents fi
+		JOIN matches m ON m.id = fi.match_id
+		WHERE fi.match_id = $1 AND fi.team_id IS NOT NULL AND fi.is_cancelled = false
+	),
+	totals AS (
+		SELECT
+			fs.team_id,
+			COUNT(*) FILTER (WHERE s.incident_type IN ('goal', 'penalty', 'own_goal') AND s.periods = 'first_half') AS first_half,
+			COUNT(*) FILTER (WHERE s.incident_type IN ('goal', 'penalty', 'own_goal') AND s.periods IN ('second_half', 'extra_time_first_half', 'extra_time_second_half')) AS second_half,
+			COUNT(*) FILTER (WHERE s.incident_type = 'penalty_shootout') AS shootout_kicks,
+			COUNT(*) FILTER (WHERE s.incident_type = 'penalty_shootout' AND s.penalty_shootout_scored) AS shootout_goals
+		FROM football_score fs
+		LEFT JOIN scored s ON s.team_id = fs.team_id
+		WHERE fs.match_id = $1
+		GROUP BY fs.team_id
+	)
+	UPDATE football_score fs
+	SET
+		first_half = t.first_half,
+		second_half = t.second_half,
+		goals = t.first_half + t.second_half,
+		penalty_shootout = CASE WHEN t.shootout_kicks > 0 THEN t.shootout_goals ELSE NULL END
+	FROM totals t
+	WHERE fs.match_id = $1 AND fs.team_id = t.team_id
+	RETURNING fs.id, fs.public_id, fs.match_id, fs.team_id, fs.first_half, fs.second_half, fs.goals, fs.penalty_shootout
+`
+
+// RecomputeFootballScore rebuilds the score of both teams, penalty shootout included, from the
+// incidents of the match that are not cancelled
+func (q *Queries) RecomputeFootballScore(ctx context.Context, matchID int32) ([]models.FootballScore, error) {
+	rows, err := q.db.QueryContext(ctx, recomputeFootballScore, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to recompute football score: %w", err)
+	}
+	defer rows.Close()
+
+	var items []models.FootballScore
+	for rows.Next() {
+		var i models.FootballScore
+		err := rows.Scan(
+			&i.ID,
+			&i.PublicID,
+			&i.MatchID,
+			&i.TeamID,
+			&i.FirstHalf,
+			&i.SecondHalf,
+			&i.Goals,
+			&i.PenaltyShootOut,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -114,6 +114,32 @@ func (q *Queries) AddFootballIncidentPlayer(ctx context.Context, incidentID int6
 	return i, err
 }
 
+const footballIncidentColumns = `
+	id, public_id, tournament_id, match_id, team_id, periods, incident_type,
+	incident_time, added_time, description, penalty_shootout_scored, created_at,
+	assist_player_id, is_cancelled, cancelled_incident_id
+`
+
+func scanFootballIncident(row interface{ Scan(dest ...any) error }, i *models.FootballIncident) error {
+	return row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.TournamentID,
+		&i.MatchID,
+		&i.TeamID,
+		&i.Periods,
+		&i.IncidentType,
+		&i.IncidentTime,
+		&i.AddedTime,
+		&i.Description,
+		&i.PenaltyShootoutScored,
+		&i.CreatedAt,
+		&i.AssistPlayerID,
+		&i.IsCancelled,
+		&i.CancelledIncidentID,
+	)
+}
+
 const createFootballIncidents = `
 WITH tournamentID AS (
 	SELECT id FROM tournaments WHERE public_id = $1
@@ -123,6 +149,9 @@ matchID AS (
 ),
 teamID AS (
 	SELECT id FROM teams WHERE public_id = $3
+),
+assistID AS (
+	SELECT id FROM players WHERE public_id = $10
 )
 INSERT INTO football_incidents (
 	tournament_id,
@@ -132,7 +161,9 @@ INSERT INTO football_incidents (
     incident_type,
     incident_time,
     description,
-    penalty_shootout_scored
+    penalty_shootout_scored,
+    added_time,
+    assist_player_id
 )
 SELECT 
 	tournamentID.id,
@@ -145,11 +176,14 @@ SELECT
 	$5,
 	$6,
 	$7,
-	$8
+	$8,
+	$9,
+	assistID.id
 FROM tournamentID
 JOIN matchID ON TRUE
 LEFT JOIN teamID ON TRUE
-RETURNING *;
+LEFT JOIN assistID ON TRUE
+RETURNING ` + footballIncidentColumns + `;
 
 `
 
@@ -160,8 +194,10 @@ type CreateFootballIncidentsParams struct {
 	Periods               string     `json:"periods"`
 	IncidentType          string     `json:"incident_type"`
 	IncidentTime          int        `json:"incident_time"`
+	AddedTime             int        `json:"added_time"`
 	Description           string     `json:"description"`
 	PenaltyShootoutScored bool       `json:"penalty_shootout_scored"`
+	AssistPlayerPublicID  *uuid.UUID `json:"assist_player_public_id"`
 }
 
 func (q *Queries) CreateFootballIncidents(ctx context.Context, arg CreateFootballIncidentsParams) (*models.FootballIncident, error) {
@@ -174,21 +210,11 @@ func (q *Queries) CreateFootballIncidents(ctx context.Context, arg CreateFootbal
 		arg.IncidentTime,
 		arg.Description,
 		arg.PenaltyShootoutScored,
+		arg.AddedTime,
+		arg.AssistPlayerPublicID,
 	)
 	var i models.FootballIncident
-	err := row.Scan(
-		&i.ID,
-		&i.PublicID,
-		&i.TournamentID,
-		&i.MatchID,
-		&i.TeamID,
-		&i.Periods,
-		&i.IncidentType,
-		&i.IncidentTime,
-		&i.Description,
-		&i.PenaltyShootoutScored,
-		&i.CreatedAt,
-	)
+	err := scanFootballIncident(row, &i)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -208,6 +234,9 @@ SELECT
     fi.periods, 
     fi.incident_type, 
     fi.incident_time, 
+    fi.added_time,
+    fi.is_cancelled,
+    fi.cancelled_incident_id,
     fi.description, 
     fi.penalty_shootout_scored,
     NULL::jsonb AS players
@@ -229,6 +258,9 @@ SELECT
     fi.periods, 
     fi.incident_type, 
     fi.incident_time, 
+    fi.added_time,
+    fi.is_cancelled,
+    fi.cancelled_incident_id,
     fi.description, 
     fi.penalty_shootout_scored,
     CASE
@@ -275,7 +307,22 @@ SELECT
                             'country', player_incident.country,
                             'positions', player_incident.positions,
                             'media_url', player_incident.media_url
-                        )
+                        ),
+                        'assist_player', CASE
+                            WHEN assist_player.id IS NOT NULL THEN
+                                JSON_BUILD_OBJECT(
+                                    'id', assist_player.id,
+                                    'public_id', assist_player.public_id,
+                                    'user_id', assist_player.user_id,
+                                    'name', assist_player.name,
+                                    'slug', assist_player.slug,
+                                    'short_name', assist_player.short_name,
+                                    'country', assist_player.country,
+                                    'positions', assist_player.positions,
+                                    'media_url', assist_player.media_url
+                                )
+                            ELSE NULL
+                        END
                     )
                 ELSE NULL
             END
@@ -294,6 +341,8 @@ LEFT JOIN
     players AS player_in ON player_in.id = fis.player_in_id
 LEFT JOIN 
     players AS player_out ON player_out.id = fis.player_out_id
+LEFT JOIN
+    players AS assist_player ON assist_player.id = fi.assist_player_id
 WHERE
     m.public_id = $1 AND
     (fi.periods IS NULL OR fi.periods NOT IN ('half_time', 'full_time', 'extra_time'))
@@ -311,6 +360,9 @@ type GetFootballIncidentWithPlayerRow struct {
 	Periods               string      `json:"periods"`
 	IncidentType          string      `json:"incident_type"`
 	IncidentTime          int64       `json:"incident_time"`
+	AddedTime             int         `json:"added_time"`
+	IsCancelled           bool        `json:"is_cancelled"`
+	CancelledIncidentID   *int64      `json:"cancelled_incident_id"`
 	Description           string      `json:"description"`
 	PenaltyShootoutScored bool        `json:"penalty_shootout_scored"`
 	Players               interface{} `json:"players"`
@@ -334,6 +386,9 @@ func (q *Queries) GetFootballIncidentWithPlayer(ctx context.Context, matchPublic
 			&i.Periods,
 			&i.IncidentType,
 			&i.IncidentTime,
+			&i.AddedTime,
+			&i.IsCancelled,
+			&i.CancelledIncidentID,
 			&i.Description,
 			&i.PenaltyShootoutScored,
 			&i.Players,
@@ -380,11 +435,12 @@ func (q *Queries) GetFootballScoreByIncidentTime(ctx context.Context, incidentID
 	return items, nil
 }
 
+// getFootballShootoutScoreByTeam is the shootout tally of the team up to and including the kick
 const getFootballShootoutScoreByTeam = `
-SELECT SUM ( CASE WHEN team_id=$1 AND incident_type='penalty_shootout' AND penalty_shootout_scored='t' THEN 1 ELSE 0 END )
+SELECT COALESCE(SUM ( CASE WHEN incident_type='penalty_shootout' AND penalty_shootout_scored='t' THEN 1 ELSE 0 END ), 0)
 FROM football_incidents fi
 JOIN matches m ON  m.id = fi.match_id
-WHERE fi.public_id=$1 AND m.public_id = $2 AND fi.team_id = $3
+WHERE fi.id <= (SELECT id FROM football_incidents WHERE public_id=$1) AND m.public_id = $2 AND fi.team_id = $3 AND fi.is_cancelled = false
 `
 
 func (q *Queries) GetFootballShootoutScoreByTeam(ctx context.Context, incidentPublicID, matchPublicID uuid.UUID, teamID int32) ([]int64, error) {
@@ -411,9 +467,9 @@ func (q *Queries) GetFootballShootoutScoreByTeam(ctx context.Context, incidentPu
 }
 
 const getFootballIncidentByTeam = `
-	SELECT *
+	SELECT ` + footballIncidentColumns + `
 	FROM football_incidents fi
-	WHERE fi.match_id = $1 AND fi.team_id = $2;
+	WHERE fi.match_id = $1 AND fi.team_id = $2 AND fi.is_cancelled = false;
 `
 
 func (q *Queries) GetFootballIncidentByTeam(ctx context.Context, matchID int64, teamID int32) (*[]models.FootballIncident, error) {
@@ -424,19 +480,7 @@ func (q *Queries) GetFootballIncidentByTeam(ctx context.Context, matchID int64,
 	var stats []models.FootballIncident
 	for row.Next() {
 		var stat models.FootballIncident
-		err := row.Scan(
-			&stat.ID,
-			&stat.PublicID,
-			&stat.MatchID,
-			&stat.TournamentID,
-			&stat.TeamID,
-			&stat.Periods,
-			&stat.IncidentType,
-			&stat.IncidentTime,
-			&stat.Description,
-			&stat.PenaltyShootoutScored,
-			&stat.CreatedAt,
-		)
+		err := scanFootballIncident(row, &stat)
 		if err != nil {
 			return nil, err
 		}
@@ -48,6 +48,7 @@ func (q *Queries) AddFootballSquad(ctx context.Context, matchPublicID, teamPubli
 		&i.IsSubstitute,
 		&i.Role,
 		&i.CreatedAT,
+		&i.FormationSlot,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -61,7 +62,7 @@ func (q *Queries) AddFootballSquad(ctx context.Context, matchPublicID, teamPubli
 const getFootballMatchSquad = `
 	SELECT
 		JSON_BUILD_OBJECT(
-			'id', cs.id, 'public_id', cs.public_id, 'match_id', cs.match_id, 'team_id', cs.team_id, 'player_id', cs.player_id, 'position', cs.position, 'is_substitute', cs.is_substitute,  'role', cs.role, 'created_at', cs.created_at,
+			'id', cs.id, 'public_id', cs.public_id, 'match_id', cs.match_id, 'team_id', cs.team_id, 'player_id', cs.player_id, 'position', cs.position, 'is_substitute', cs.is_substitute,  'role', cs.role, 'created_at', cs.created_at, 'formation_slot', cs.formation_slot,
 			'player', JSON_BUILD_OBJECT(
 				'id',pl.id,
 				'public_id', pl.public_id,
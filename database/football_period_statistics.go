@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"khelogames/database/models"
+)
+
+const getFootballIncidentCounts = `
+	SELECT team_id, periods, incident_type, COUNT(*)
+	FROM football_incidents
+	WHERE match_id = $1 AND team_id IS NOT NULL AND is_cancelled = false
+	GROUP BY team_id, periods, incident_type
+`
+
+// GetFootballIncidentCounts returns the number of incidents of each type a team had in each
+// period of the match, incidents cancelled by a VAR review are left out
+func (q *Queries) GetFootballIncidentCounts(ctx context.Context, matchID int32) ([]models.FootballIncidentCount, error) {
+	rows, err := q.db.QueryContext(ctx, getFootballIncidentCounts, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []models.FootballIncidentCount
+	for rows.Next() {
+		var i models.FootballIncidentCount
+		if err := rows.Scan(&i.TeamID, &i.Periods, &i.IncidentType, &i.Count); err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		counts = append(counts, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Failed to iterate rows: %w", err)
+	}
+	return counts, nil
+}
+
+const upsertFootballPossession = `
+	INSERT INTO football_possession (
+		match_id,
+		team_id,
+		periods,
+		possession,
+		updated_at
+	) VALUES ($1, $2, $3, $4, NOW())
+	ON CONFLICT (match_id, team_id, periods) DO UPDATE SET
+		possession = EXCLUDED.possession,
+		updated_at = EXCLUDED.updated_at
+	RETURNING id, match_id, team_id, periods, possession, updated_at
+`
+
+type UpsertFootballPossessionParams struct {
+	MatchID    int32  `json:"match_id"`
+	TeamID     int32  `json:"team_id"`
+	Periods    string `json:"periods"`
+	Possession int    `json:"possession"`
+}
+
+func (q *Queries) UpsertFootballPossession(ctx context.Context, arg UpsertFootballPossessionParams) (*models.FootballPossession, error) {
+	row := q.db.QueryRowContext(ctx, upsertFootballPossession,
+		arg.MatchID,
+		arg.TeamID,
+		arg.Periods,
+		arg.Possession,
+	)
+	var i models.FootballPossession
+	err := row.Scan(
+		&i.ID,
+		&i.MatchID,
+		&i.TeamID,
+		&i.Periods,
+		&i.Possession,
+		&i.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const getFootballPossession = `
+	SELECT id, match_id, team_id, periods, possession, updated_at
+	FROM football_possession
+	WHERE match_id = $1
+`
+
+func (q *Queries) GetFootballPossession(ctx context.Context, matchID int32) ([]models.FootballPossession, error) {
+	rows, err := q.db.QueryContext(ctx, getFootballPossession, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var possession []models.FootballPossession
+	for rows.Next() {
+		var i models.FootballPossession
+		err := rows.Scan(
+			&i.ID,
+			&i.MatchID,
+			&i.TeamID,
+			&i.Periods,
+			&i.Possession,
+			&i.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		possession = append(possession, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Failed to iterate rows: %w", err)
+	}
+	return possession, nil
+}
@@ -0,0 +1,268 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"khelogames/database/models"
+
+	"github.com/google/uuid"
+)
+
+const getFootballPlayerMatchPerformances = footballPlayerMatchStatsCTE + `,
+
+-- OWN GOALS: kept apart from the goals, they count for the opponent
+own_goals AS (
+  SELECT
+    fip.player_id,
+    COUNT(*) AS own_goals
+  FROM football_incident_player fip
+  JOIN football_incidents fi ON fi.id = fip.incident_id AND fi.is_cancelled = false
+  JOIN match_context mc ON fi.match_id = mc.match_id
+  WHERE fi.incident_type = 'own_goal'
+  GROUP BY fip.player_id
+)
+
+SELECT
+  a.player_id,
+  fs.team_id,
+  COALESCE(fs.position, ''),
+  COALESCE(fs.formation_slot, ''),
+  a.minutes_played,
+  a.goals_scored,
+  a.assists,
+  COALESCE(og.own_goals, 0),
+  a.yellow_cards,
+  a.red_cards
+FROM aggregated a
+JOIN football_squad fs ON fs.player_id = a.player_id
+JOIN match_context mc ON fs.match_id = mc.match_id
+LEFT JOIN own_goals og ON og.player_id = a.player_id
+`
+
+// FootballPlayerMatchPerformance is what a player of the squad did in a match, the formation slot
+// is the one held at the final whistle
+type FootballPlayerMatchPerformance struct {
+	PlayerID      int32  `json:"player_id"`
+	TeamID        int32  `json:"team_id"`
+	Position      string `json:"position"`
+	FormationSlot string `json:"formation_slot"`
+	MinutesPlayed int    `json:"minutes_played"`
+	GoalsScored   int    `json:"goals_scored"`
+	Assists       int    `json:"assists"`
+	OwnGoals      int    `json:"own_goals"`
+	YellowCards   int    `json:"yellow_cards"`
+	RedCards      int    `json:"red_cards"`
+}
+
+func (q *Queries) GetFootballPlayerMatchPerformances(ctx context.Context, matchPublicID uuid.UUID) ([]FootballPlayerMatchPerformance, error) {
+	rows, err := q.db.QueryContext(ctx, getFootballPlayerMatchPerformances, matchPublicID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var items []FootballPlayerMatchPerformance
+	for rows.Next() {
+		var i FootballPlayerMatchPerformance
+		err := rows.Scan(
+			&i.PlayerID,
+			&i.TeamID,
+			&i.Position,
+			&i.FormationSlot,
+			&i.MinutesPlayed,
+			&i.GoalsScored,
+			&i.Assists,
+			&i.OwnGoals,
+			&i.YellowCards,
+			&i.RedCards,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan row: %w", err)
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteFootballPlayerRatings = `
+	DELETE FROM football_player_ratings
+	WHERE match_id = $1
+`
+
+// DeleteFootballPlayerRatings clears the ratings of the match so they can be rated again
+func (q *Queries) DeleteFootballPlayerRatings(ctx context.Context, matchID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteFootballPlayerRatings, matchID)
+	if err != nil {
+		return fmt.Errorf("Failed to delete player ratings: %w", err)
+	}
+	return nil
+}
+
+const addFootballPlayerRating = `
+	INSERT INTO football_player_ratings (
+		match_id,
+		tournament_id,
+		player_id,
+		team_id,
+		rating,
+		minutes_played,
+		goals,
+		assists,
+		own_goals,
+		yellow_cards,
+		red_cards,
+		clean_sheet,
+		is_man_of_the_match
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	RETURNING id, public_id, match_id, tournament_id, player_id, team_id, rating, minutes_played, goals, assists,
+		own_goals, yellow_cards, red_cards, clean_sheet, is_man_of_the_match, created_at
+`
+
+type AddFootballPlayerRatingParams struct {
+	MatchID         int32   `json:"match_id"`
+	TournamentID    int32   `json:"tournament_id"`
+	PlayerID        int32   `json:"player_id"`
+	TeamID          int32   `json:"team_id"`
+	Rating          float64 `json:"rating"`
+	MinutesPlayed   int     `json:"minutes_played"`
+	Goals           int     `json:"goals"`
+	Assists         int     `json:"assists"`
+	OwnGoals        int     `json:"own_goals"`
+	YellowCards     int     `json:"yellow_cards"`
+	RedCards        int     `json:"red_cards"`
+	CleanSheet      bool    `json:"clean_sheet"`
+	IsManOfTheMatch bool    `json:"is_man_of_the_match"`
+}
+
+func (q *Queries) AddFootballPlayerRating(ctx context.Context, arg AddFootballPlayerRatingParams) (*models.FootballPlayerRating, error) {
+	row := q.db.QueryRowContext(ctx, addFootballPlayerRating,
+		arg.MatchID,
+		arg.TournamentID,
+		arg.PlayerID,
+		arg.TeamID,
+		arg.Rating,
+		arg.MinutesPlayed,
+		arg.Goals,
+		arg.Assists,
+		arg.OwnGoals,
+		arg.YellowCards,
+		arg.RedCards,
+		arg.CleanSheet,
+		arg.IsManOfTheMatch,
+	)
+	var i models.FootballPlayerRating
+	err := row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.TournamentID,
+		&i.PlayerID,
+		&i.TeamID,
+		&i.Rating,
+		&i.MinutesPlayed,
+		&i.Goals,
+		&i.Assists,
+		&i.OwnGoals,
+		&i.YellowCards,
+		&i.RedCards,
+		&i.CleanSheet,
+		&i.IsManOfTheMatch,
+		&i.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+// footballPlayerJSON is the player of a rating as sent to the clients
+const footballPlayerJSON = `
+	JSON_BUILD_OBJECT(
+		'id', p.id,
+		'public_id', p.public_id,
+		'user_id', p.user_id,
+		'name', p.name,
+		'slug', p.slug,
+		'short_name', p.short_name,
+		'country', p.country,
+		'positions', p.positions,
+		'media_url', p.media_url
+	)
+`
+
+const getFootballMatchRatings = `
+	SELECT JSON_BUILD_OBJECT(
+		'public_id', r.public_id,
+		'team_id', r.team_id,
+		'rating', r.rating,
+		'minutes_played', r.minutes_played,
+		'goals', r.goals,
+		'assists', r.assists,
+		'own_goals', r.own_goals,
+		'yellow_cards', r.yellow_cards,
+		'red_cards', r.red_cards,
+		'clean_sheet', r.clean_sheet,
+		'is_man_of_the_match', r.is_man_of_the_match,
+		'player', ` + footballPlayerJSON + `
+	)
+	FROM football_player_ratings r
+	JOIN matches m ON m.id = r.match_id
+	JOIN players p ON p.id = r.player_id
+	WHERE m.public_id = $1
+	ORDER BY r.is_man_of_the_match DESC, r.rating DESC, p.name
+`
+
+// GetFootballMatchRatings lists the ratings of a finished match, the man of the match first
+func (q *Queries) GetFootballMatchRatings(ctx context.Context, matchPublicID uuid.UUID) ([]map[string]interface{}, error) {
+	return q.getFootballRatings(ctx, getFootballMatchRatings, matchPublicID)
+}
+
+const getFootballTournamentRatings = `
+	SELECT JSON_BUILD_OBJECT(
+		'team_id', r.team_id,
+		'matches', COUNT(*),
+		'average_rating', ROUND(AVG(r.rating), 2),
+		'man_of_the_match', COUNT(*) FILTER (WHERE r.is_man_of_the_match),
+		'player', ` + footballPlayerJSON + `
+	)
+	FROM football_player_ratings r
+	JOIN tournaments t ON t.id = r.tournament_id
+	JOIN players p ON p.id = r.player_id
+	WHERE t.public_id = $1
+	GROUP BY p.id, r.team_id
+	ORDER BY AVG(r.rating) DESC, COUNT(*) DESC, p.name
+`
+
+// GetFootballTournamentRatings returns the average rating of every player of the tournament
+func (q *Queries) GetFootballTournamentRatings(ctx context.Context, tournamentPublicID uuid.UUID) ([]map[string]interface{}, error) {
+	return q.getFootballRatings(ctx, getFootballTournamentRatings, tournamentPublicID)
+}
+
+func (q *Queries) getFootballRatings(ctx context.Context, query string, publicID uuid.UUID) ([]map[string]interface{}, error) {
+	rows, err := q.db.QueryContext(ctx, query, publicID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	items := []map[string]interface{}{}
+	for rows.Next() {
+		var jsonByte []byte
+		if err := rows.Scan(&jsonByte); err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		var item map[string]interface{}
+		if err := json.Unmarshal(jsonByte, &item); err != nil {
+			return nil, fmt.Errorf("Failed to unmarshal: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -59,6 +59,8 @@ const getFootballPlayerStatsQuery = `
 	SELECT JSON_BUILD_OBJECT(
 		'id', fps.id, 'public_id', fps.public_id, 'player_id', fps.player_id, 'player_position', fps.player_position, 'matches', fps.matches, 'minutes_played', fps.minutes_played, 'goals_scored', fps.goals_scored,
 		'goals_conceded', fps.goals_conceded, 'clean_sheet', fps.clean_sheet, 'assists', fps.assists, 'yellow_cards', fps.yellow_cards, 'red_cards', fps.red_cards, 'avergae', fps.average,
+		'goals_per_90', CASE WHEN fps.minutes_played > 0 THEN ROUND(fps.goals_scored * 90.0 / fps.minutes_played, 2) ELSE 0 END,
+		'assists_per_90', CASE WHEN fps.minutes_played > 0 THEN ROUND(fps.assists * 90.0 / fps.minutes_played, 2) ELSE 0 END,
 		'created_at', fps.created_at, 'updated_at', fps.updated_at,
 		'player', JSON_BUILD_OBJECT(
 				'id', p.id,
@@ -99,11 +101,24 @@ func (q *Queries) GetFootballPlayerStats(ctx context.Context, playerPublicID uui
 	return &stats, nil
 }
 
-const addAndUpdateFootballPlayerStats = `
+// footballPlayerMatchStatsCTE aggregates what each player of the match in $1 adds to the player
+// stats, ending with the aggregated CTE
+const footballPlayerMatchStatsCTE = `
 WITH match_context AS (
   SELECT id AS match_id FROM matches WHERE public_id = $1
 ),
 
+-- MATCH LENGTH: the match runs 120 minutes once it went to extra time
+match_length AS (
+  SELECT
+    CASE WHEN EXISTS (
+      SELECT 1
+      FROM football_incidents fi
+      JOIN match_context mc ON fi.match_id = mc.match_id
+      WHERE fi.periods LIKE 'extra_time%'
+    ) THEN 120 ELSE 90 END AS minutes
+),
+
 -- SUBSTITUTIONS: one row per substitution event with time
 subs AS (
   SELECT
@@ -111,10 +126,22 @@ subs AS (
     fsp.player_out_id,
     fi.incident_time
   FROM football_substitutions_player fsp
-  JOIN football_incidents fi ON fi.id = fsp.incident_id
+  JOIN football_incidents fi ON fi.id = fsp.incident_id AND fi.is_cancelled = false
   JOIN match_context mc ON fi.match_id = mc.match_id
 ),
 
+-- SENDINGS OFF: the minute each player was shown a red card
+sent_off AS (
+  SELECT
+    fip.player_id,
+    MIN(fi.incident_time) AS incident_time
+  FROM football_incident_player fip
+  JOIN football_incidents fi ON fi.id = fip.incident_id AND fi.is_cancelled = false
+  JOIN match_context mc ON fi.match_id = mc.match_id
+  WHERE fi.incident_type = 'red_card'
+  GROUP BY fip.player_id
+),
+
 -- ALL PLAYERS in the match (starters + subs flag)
 all_players AS (
   SELECT fs.player_id, fs.is_substitute
@@ -122,25 +149,40 @@ all_players AS (
   JOIN match_context mc ON fs.match_id = mc.match_id
 ),
 
--- MINUTES: choose at most one subs record per player (if any).
--- Use LATERAL to fetch the relevant substitution row (if there are multiples).
-minutes_played AS (
+-- ON MINUTE: starters come on at kick off, substitutes when they replace a player.
+-- Substitutes who never came on have no on minute.
+came_on AS (
   SELECT
     ap.player_id,
     CASE
-      WHEN ap.is_substitute = FALSE AND s.player_out_id IS NOT NULL THEN s.incident_time
-      WHEN ap.is_substitute = TRUE  AND s.player_in_id  IS NOT NULL THEN 90 - s.incident_time
-      WHEN ap.is_substitute = FALSE THEN 90
-      ELSE 0
-    END AS minutes
+      WHEN ap.is_substitute = FALSE THEN 0
+      ELSE (SELECT MIN(s.incident_time) FROM subs s WHERE s.player_in_id = ap.player_id)
+    END AS on_minute
   FROM all_players ap
-  LEFT JOIN LATERAL (
-    SELECT player_in_id, player_out_id, incident_time
-    FROM subs s
-    WHERE s.player_in_id = ap.player_id OR s.player_out_id = ap.player_id
-    ORDER BY incident_time -- choose earliest/only relevant event
-    LIMIT 1
-  ) s ON true
+),
+
+-- MINUTES: time between coming on and going off, a player goes off when substituted,
+-- sent off or at the final whistle
+minutes_played AS (
+  SELECT
+    co.player_id,
+    CASE
+      WHEN co.on_minute IS NULL THEN 0
+      ELSE GREATEST(
+        LEAST(
+          COALESCE(
+            (SELECT MIN(s.incident_time) FROM subs s WHERE s.player_out_id = co.player_id AND s.incident_time >= co.on_minute),
+            ml.minutes
+          ),
+          COALESCE(so.incident_time, ml.minutes),
+          ml.minutes
+        ) - co.on_minute,
+        0
+      )
+    END AS minutes
+  FROM came_on co
+  CROSS JOIN match_length ml
+  LEFT JOIN sent_off so ON so.player_id = co.player_id
 ),
 
 -- INCIDENT PIVOT: pivot incident rows into columns per player
@@ -156,18 +198,29 @@ incident_pivot AS (
     SUM(CASE WHEN LOWER(fi.incident_type) IN ('corner','corner_kick','corner_kicks') THEN 1 ELSE 0 END) AS corner_kicks,
     SUM(CASE WHEN LOWER(fi.incident_type) IN ('foul') THEN 1 ELSE 0 END)               AS fouls
   FROM football_incident_player fip
-  JOIN football_incidents fi ON fi.id = fip.incident_id
+  JOIN football_incidents fi ON fi.id = fip.incident_id AND fi.is_cancelled = false
   JOIN match_context mc ON fi.match_id = mc.match_id
   GROUP BY fip.player_id
 ),
 
+-- ASSISTS: the assisting player is kept on the goal incident
+assist_pivot AS (
+  SELECT
+    fi.assist_player_id AS player_id,
+    COUNT(*) AS assists
+  FROM football_incidents fi
+  JOIN match_context mc ON fi.match_id = mc.match_id
+  WHERE fi.assist_player_id IS NOT NULL AND fi.is_cancelled = false
+  GROUP BY fi.assist_player_id
+),
+
 -- AGGREGATED: join minutes + pivoted incident counts (one row per player)
 aggregated AS (
   SELECT
     mp.player_id,
     COALESCE(mp.minutes, 0) AS minutes_played,
     COALESCE(ip.goals_scored, 0) AS goals_scored,
-    COALESCE(ip.assists, 0) AS assists,
+    COALESCE(ip.assists, 0) + COALESCE(ap.assists, 0) AS assists,
     COALESCE(ip.goals_conceded, 0) AS goals_conceded,
     COALESCE(ip.clean_sheets, 0) AS clean_sheets,
     COALESCE(ip.yellow_cards, 0) AS yellow_cards,
@@ -176,7 +229,11 @@ aggregated AS (
     COALESCE(ip.fouls, 0) AS fouls
   FROM minutes_played mp
   LEFT JOIN incident_pivot ip ON mp.player_id = ip.player_id
-),
+  LEFT JOIN assist_pivot ap ON mp.player_id = ap.player_id
+)
+`
+
+const addAndUpdateFootballPlayerStats = footballPlayerMatchStatsCTE + `,
 
 -- UPDATE existing player stats (returns updated player_ids)
 updated AS (
@@ -265,3 +322,84 @@ func (q *Queries) AddORUpdateFootballPlayerStats(ctx context.Context, mathchPubl
 
 	return &playerStats, nil
 }
+
+const getFootballPlayerMatchStats = footballPlayerMatchStatsCTE + `
+SELECT player_id, minutes_played, goals_scored, goals_conceded, clean_sheets, assists, yellow_cards, red_cards
+FROM aggregated
+`
+
+// FootballPlayerMatchStats is what a single match adds to the stats of a player
+type FootballPlayerMatchStats struct {
+	PlayerID      int32 `json:"player_id"`
+	MinutesPlayed int   `json:"minutes_played"`
+	GoalsScored   int   `json:"goals_scored"`
+	GoalsConceded int   `json:"goals_conceded"`
+	CleanSheets   int   `json:"clean_sheets"`
+	Assists       int   `json:"assists"`
+	YellowCards   int   `json:"yellow_cards"`
+	RedCards      int   `json:"red_cards"`
+}
+
+func (q *Queries) GetFootballPlayerMatchStats(ctx context.Context, matchPublicID uuid.UUID) ([]FootballPlayerMatchStats, error) {
+	rows, err := q.db.QueryContext(ctx, getFootballPlayerMatchStats, matchPublicID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var items []FootballPlayerMatchStats
+	for rows.Next() {
+		var i FootballPlayerMatchStats
+		err := rows.Scan(
+			&i.PlayerID,
+			&i.MinutesPlayed,
+			&i.GoalsScored,
+			&i.GoalsConceded,
+			&i.CleanSheets,
+			&i.Assists,
+			&i.YellowCards,
+			&i.RedCards,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan row: %w", err)
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const adjustFootballPlayerStats = `
+	UPDATE football_player_stats
+	SET
+		minutes_played = GREATEST(minutes_played + $9 * $2, 0),
+		goals_scored = GREATEST(goals_scored + $9 * $3, 0),
+		goals_conceded = GREATEST(goals_conceded + $9 * $4, 0),
+		clean_sheet = GREATEST(clean_sheet + $9 * $5, 0),
+		assists = GREATEST(assists + $9 * $6, 0),
+		yellow_cards = GREATEST(yellow_cards + $9 * $7, 0),
+		red_cards = GREATEST(red_cards + $9 * $8, 0),
+		updated_at = NOW()
+	WHERE player_id = $1
+`
+
+// AdjustFootballPlayerStats adds the stats of a match to the player, a sign of -1 takes them back
+func (q *Queries) AdjustFootballPlayerStats(ctx context.Context, stats FootballPlayerMatchStats, sign int) error {
+	_, err := q.db.ExecContext(ctx, adjustFootballPlayerStats,
+		stats.PlayerID,
+		stats.MinutesPlayed,
+		stats.GoalsScored,
+		stats.GoalsConceded,
+		stats.CleanSheets,
+		stats.Assists,
+		stats.YellowCards,
+		stats.RedCards,
+		sign,
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to adjust player stats: %w", err)
+	}
+	return nil
+}
@@ -203,7 +203,7 @@ func (q *Queries) UpdateSecondHalfScore(ctx context.Context, arg UpdateSecondHal
 const updatePenaltyShootoutScore = `
 UPDATE football_score
 SET 
-    penalty_shootout = COALESCE(penalty_shootout, 0) + 1,
+    penalty_shootout = COALESCE(penalty_shootout, 0) + 1
 WHERE 
     match_id = $1 AND team_id = $2
 RETURNING *
@@ -0,0 +1,192 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"khelogames/database/models"
+
+	"github.com/google/uuid"
+)
+
+const addFootballShootoutIncident = `
+	INSERT INTO football_incidents (
+		tournament_id,
+		match_id,
+		team_id,
+		periods,
+		incident_type,
+		incident_time,
+		added_time,
+		description,
+		penalty_shootout_scored
+	)
+	SELECT m.tournament_id, m.id, $2, 'penalty_shootout', 'penalty_shootout', $3, 0, $4, $5
+	FROM matches m
+	WHERE m.id = $1
+	RETURNING ` + footballIncidentColumns
+
+// AddFootballShootoutIncident records a kick of the shootout in the incidents of the match
+func (q *Queries) AddFootballShootoutIncident(ctx context.Context, matchID, teamID int32, incidentTime int, description string, scored bool) (*models.FootballIncident, error) {
+	row := q.db.QueryRowContext(ctx, addFootballShootoutIncident, matchID, teamID, incidentTime, description, scored)
+	var i models.FootballIncident
+	if err := scanFootballIncident(row, &i); err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const footballShootoutKickColumns = `
+	id, public_id, match_id, team_id, incident_id, kick_number, taker_id, keeper_id, outcome, created_at
+`
+
+func scanFootballShootoutKick(row interface{ Scan(dest ...any) error }, i *models.FootballShootoutKick) error {
+	return row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.TeamID,
+		&i.IncidentID,
+		&i.KickNumber,
+		&i.TakerID,
+		&i.KeeperID,
+		&i.Outcome,
+		&i.CreatedAt,
+	)
+}
+
+const addFootballShootoutKick = `
+	INSERT INTO football_shootout_kicks (
+		match_id,
+		team_id,
+		incident_id,
+		kick_number,
+		taker_id,
+		keeper_id,
+		outcome
+	)
+	SELECT
+		$1,
+		$2,
+		$3,
+		COALESCE((SELECT MAX(kick_number) FROM football_shootout_kicks WHERE match_id = $1), 0) + 1,
+		$4,
+		$5,
+		$6
+	RETURNING ` + footballShootoutKickColumns
+
+type AddFootballShootoutKickParams struct {
+	MatchID    int32  `json:"match_id"`
+	TeamID     int32  `json:"team_id"`
+	IncidentID int64  `json:"incident_id"`
+	TakerID    int32  `json:"taker_id"`
+	KeeperID   *int32 `json:"keeper_id"`
+	Outcome    string `json:"outcome"`
+}
+
+func (q *Queries) AddFootballShootoutKick(ctx context.Context, arg AddFootballShootoutKickParams) (*models.FootballShootoutKick, error) {
+	row := q.db.QueryRowContext(ctx, addFootballShootoutKick,
+		arg.MatchID,
+		arg.TeamID,
+		arg.IncidentID,
+		arg.TakerID,
+		arg.KeeperID,
+		arg.Outcome,
+	)
+	var i models.FootballShootoutKick
+	if err := scanFootballShootoutKick(row, &i); err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const getFootballShootoutKicks = `
+	SELECT ` + footballShootoutKickColumns + `
+	FROM football_shootout_kicks
+	WHERE match_id = $1
+	ORDER BY kick_number
+`
+
+// GetFootballShootoutKicks returns the kicks of the shootout in the order they were taken
+func (q *Queries) GetFootballShootoutKicks(ctx context.Context, matchID int32) ([]models.FootballShootoutKick, error) {
+	rows, err := q.db.QueryContext(ctx, getFootballShootoutKicks, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var kicks []models.FootballShootoutKick
+	for rows.Next() {
+		var i models.FootballShootoutKick
+		if err := scanFootballShootoutKick(rows, &i); err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		kicks = append(kicks, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return kicks, nil
+}
+
+const getFootballShootoutKickDetails = `
+	SELECT JSON_BUILD_OBJECT(
+		'public_id', k.public_id,
+		'team_id', k.team_id,
+		'kick_number', k.kick_number,
+		'outcome', k.outcome,
+		'incident_public_id', fi.public_id,
+		'created_at', k.created_at,
+		'taker', JSON_BUILD_OBJECT(
+			'id', tp.id,
+			'public_id', tp.public_id,
+			'name', tp.name,
+			'slug', tp.slug,
+			'short_name', tp.short_name,
+			'positions', tp.positions,
+			'media_url', tp.media_url
+		),
+		'keeper', CASE WHEN kp.id IS NULL THEN NULL ELSE JSON_BUILD_OBJECT(
+			'id', kp.id,
+			'public_id', kp.public_id,
+			'name', kp.name,
+			'slug', kp.slug,
+			'short_name', kp.short_name,
+			'positions', kp.positions,
+			'media_url', kp.media_url
+		) END
+	)
+	FROM football_shootout_kicks k
+	JOIN matches m ON m.id = k.match_id
+	JOIN football_incidents fi ON fi.id = k.incident_id
+	JOIN players tp ON tp.id = k.taker_id
+	LEFT JOIN players kp ON kp.id = k.keeper_id
+	WHERE m.public_id = $1
+	ORDER BY k.kick_number
+`
+
+// GetFootballShootoutKickDetails lists the kicks of the shootout with the taker and the keeper
+func (q *Queries) GetFootballShootoutKickDetails(ctx context.Context, matchPublicID uuid.UUID) ([]map[string]interface{}, error) {
+	rows, err := q.db.QueryContext(ctx, getFootballShootoutKickDetails, matchPublicID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	items := []map[string]interface{}{}
+	for rows.Next() {
+		var jsonByte []byte
+		if err := rows.Scan(&jsonByte); err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		var item map[string]interface{}
+		if err := json.Unmarshal(jsonByte, &item); err != nil {
+			return nil, fmt.Errorf("Failed to unmarshal: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
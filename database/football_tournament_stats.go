@@ -59,7 +59,7 @@ INNER JOIN football_incident_player AS fip ON fip.incident_id = fi.id
 INNER JOIN players p ON p.id = fip.player_id
 LEFT JOIN teams tm ON tm.id = fi.team_id
 LEFT JOIN tournaments t ON t.id = m.tournament_id
-WHERE t.public_id = $1 AND p.id IS NOT NULL
+WHERE t.public_id = $1 AND p.id IS NOT NULL AND fi.is_cancelled = false
 GROUP BY p.id, p.name, tm.name
 HAVING COUNT(*) FILTER(WHERE fi.incident_type = 'goal') > 0
 ORDER BY goals DESC;
@@ -82,7 +82,7 @@ const getFootballTournamentYellowCards = `
 	JOIN players p ON p.id = fip.player_id
 	LEFT JOIN teams tm ON tm.id = fi.team_id
 	LEFT JOIN tournaments t ON t.id = m.tournament_id
-	WHERE t.public_id = $1 AND p.id IS NOT NULL
+	WHERE t.public_id = $1 AND p.id IS NOT NULL AND fi.is_cancelled = false
 	GROUP BY p.id, p.name, tm.name
 	HAVING COUNT(*) FILTER(WHERE fi.incident_type = 'yellow_card') > 0
 	ORDER BY yellow_cards DESC;
@@ -105,7 +105,7 @@ const getFootballTournamentRedCards = `
 	JOIN players p ON p.id = fip.player_id
 	LEFT JOIN teams tm ON tm.id = fi.team_id
 	LEFT JOIN tournaments t ON t.id = m.tournament_id
-	WHERE t.public_id = $1 AND p.id IS NOT NULL
+	WHERE t.public_id = $1 AND p.id IS NOT NULL AND fi.is_cancelled = false
 	GROUP BY p.id, p.name, tm.name
 	HAVING COUNT(*) FILTER(WHERE fi.incident_type = 'red_cards') > 0
 	ORDER BY red_cards DESC;
@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"khelogames/database/models"
+
+	"github.com/google/uuid"
+)
+
+const getFootballIncidentByPublicID = `
+	SELECT ` + footballIncidentColumns + `
+	FROM football_incidents
+	WHERE public_id = $1
+`
+
+func (q *Queries) GetFootballIncidentByPublicID(ctx context.Context, publicID uuid.UUID) (*models.FootballIncident, error) {
+	var i models.FootballIncident
+	row := q.db.QueryRowContext(ctx, getFootballIncidentByPublicID, publicID)
+	if err := scanFootballIncident(row, &i); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const cancelFootballIncident = `
+	UPDATE football_incidents
+	SET is_cancelled = true
+	WHERE id = $1 AND is_cancelled = false
+	RETURNING ` + footballIncidentColumns
+
+// CancelFootballIncident returns nil when the incident was already cancelled
+func (q *Queries) CancelFootballIncident(ctx context.Context, incidentID int64) (*models.FootballIncident, error) {
+	var i models.FootballIncident
+	row := q.db.QueryRowContext(ctx, cancelFootballIncident, incidentID)
+	if err := scanFootballIncident(row, &i); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const addFootballVarReviewIncident = `
+	INSERT INTO football_incidents (
+		tournament_id,
+		match_id,
+		team_id,
+		periods,
+		incident_type,
+		incident_time,
+		added_time,
+		description,
+		penalty_shootout_scored,
+		cancelled_incident_id
+	)
+	SELECT m.tournament_id, m.id, $2, $3, 'var_review', $4, $5, $6, false, $7
+	FROM matches m
+	WHERE m.id = $1
+	RETURNING ` + footballIncidentColumns
+
+type AddFootballVarReviewIncidentParams struct {
+	MatchID             int32  `json:"match_id"`
+	TeamID              *int32 `json:"team_id"`
+	Periods             string `json:"periods"`
+	IncidentTime        int    `json:"incident_time"`
+	AddedTime           int    `json:"added_time"`
+	Description         string `json:"description"`
+	CancelledIncidentID int64  `json:"cancelled_incident_id"`
+}
+
+// AddFootballVarReviewIncident records the VAR review that cancelled an earlier incident
+func (q *Queries) AddFootballVarReviewIncident(ctx context.Context, arg AddFootballVarReviewIncidentParams) (*models.FootballIncident, error) {
+	row := q.db.QueryRowContext(ctx, addFootballVarReviewIncident,
+		arg.MatchID,
+		arg.TeamID,
+		arg.Periods,
+		arg.IncidentTime,
+		arg.AddedTime,
+		arg.Description,
+		arg.CancelledIncidentID,
+	)
+	var i models.FootballIncident
+	if err := scanFootballIncident(row, &i); err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
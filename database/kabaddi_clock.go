@@ -0,0 +1,135 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"khelogames/database/models"
+
+	"github.com/google/uuid"
+)
+
+const kabaddiMatchClockColumns = `
+	c.id, c.public_id, c.match_id, c.state, c.is_running, c.elapsed_seconds, c.started_at, c.updated_at
+`
+
+func scanKabaddiMatchClock(row interface{ Scan(dest ...any) error }, i *models.KabaddiMatchClock) error {
+	return row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.State,
+		&i.IsRunning,
+		&i.ElapsedSeconds,
+		&i.StartedAt,
+		&i.UpdatedAt,
+	)
+}
+
+const getKabaddiMatchClock = `
+	SELECT ` + kabaddiMatchClockColumns + `
+	FROM kabaddi_match_clocks c
+	WHERE c.match_id = $1
+`
+
+// GetKabaddiMatchClock returns nil when the clock of the match has not been started
+func (q *Queries) GetKabaddiMatchClock(ctx context.Context, matchID int32) (*models.KabaddiMatchClock, error) {
+	var i models.KabaddiMatchClock
+	row := q.db.QueryRowContext(ctx, getKabaddiMatchClock, matchID)
+	err := scanKabaddiMatchClock(row, &i)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const upsertKabaddiMatchClock = `
+	INSERT INTO kabaddi_match_clocks AS c (
+		match_id,
+		state,
+		is_running,
+		elapsed_seconds,
+		started_at,
+		updated_at
+	) VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (match_id) DO UPDATE SET
+		state = EXCLUDED.state,
+		is_running = EXCLUDED.is_running,
+		elapsed_seconds = EXCLUDED.elapsed_seconds,
+		started_at = EXCLUDED.started_at,
+		updated_at = EXCLUDED.updated_at
+	RETURNING ` + kabaddiMatchClockColumns
+
+type UpsertKabaddiMatchClockParams struct {
+	MatchID        int32  `json:"match_id"`
+	State          string `json:"state"`
+	IsRunning      bool   `json:"is_running"`
+	ElapsedSeconds int    `json:"elapsed_seconds"`
+	StartedAt      *int64 `json:"started_at"`
+	UpdatedAt      int64  `json:"updated_at"`
+}
+
+func (q *Queries) UpsertKabaddiMatchClock(ctx context.Context, arg UpsertKabaddiMatchClockParams) (*models.KabaddiMatchClock, error) {
+	row := q.db.QueryRowContext(ctx, upsertKabaddiMatchClock,
+		arg.MatchID,
+		arg.State,
+		arg.IsRunning,
+		arg.ElapsedSeconds,
+		arg.StartedAt,
+		arg.UpdatedAt,
+	)
+	var i models.KabaddiMatchClock
+	if err := scanKabaddiMatchClock(row, &i); err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const getRunningKabaddiMatchClocks = `
+	SELECT m.public_id, ` + kabaddiMatchClockColumns + `
+	FROM kabaddi_match_clocks c
+	JOIN matches m ON m.id = c.match_id
+	WHERE c.is_running = true
+`
+
+// RunningKabaddiMatchClock is a running clock with the public id of its match
+type RunningKabaddiMatchClock struct {
+	MatchPublicID uuid.UUID                `json:"match_public_id"`
+	Clock         models.KabaddiMatchClock `json:"clock"`
+}
+
+// GetRunningKabaddiMatchClocks returns the clocks of every half being played
+func (q *Queries) GetRunningKabaddiMatchClocks(ctx context.Context) ([]RunningKabaddiMatchClock, error) {
+	rows, err := q.db.QueryContext(ctx, getRunningKabaddiMatchClocks)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var clocks []RunningKabaddiMatchClock
+	for rows.Next() {
+		var i RunningKabaddiMatchClock
+		err := rows.Scan(
+			&i.MatchPublicID,
+			&i.Clock.ID,
+			&i.Clock.PublicID,
+			&i.Clock.MatchID,
+			&i.Clock.State,
+			&i.Clock.IsRunning,
+			&i.Clock.ElapsedSeconds,
+			&i.Clock.StartedAt,
+			&i.Clock.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		clocks = append(clocks, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return clocks, nil
+}
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"khelogames/database/models"
+)
+
+const kabaddiPlayerStatsColumns = `
+	kps.id, kps.public_id, kps.player_id, kps.matches, kps.raids, kps.successful_raids,
+	kps.empty_raids, kps.raid_points, kps.bonus_points, kps.tackles, kps.tackle_points,
+	kps.super_tackles, kps.created_at, kps.updated_at
+`
+
+func scanKabaddiPlayerStats(row interface{ Scan(dest ...any) error }, i *models.KabaddiPlayerStats) error {
+	return row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.PlayerID,
+		&i.Matches,
+		&i.Raids,
+		&i.SuccessfulRaids,
+		&i.EmptyRaids,
+		&i.RaidPoints,
+		&i.BonusPoints,
+		&i.Tackles,
+		&i.TacklePoints,
+		&i.SuperTackles,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+}
+
+const addOrUpdateKabaddiPlayerStats = `
+	INSERT INTO kabaddi_player_stats AS kps (
+		player_id, matches, raids, successful_raids, empty_raids, raid_points,
+		bonus_points, tackles, tackle_points, super_tackles
+	)
+	VALUES ($1, 1, $2, $3, $4, $5, $6, $7, $8, $9)
+	ON CONFLICT (player_id) DO UPDATE SET
+		matches          = kps.matches + 1,
+		raids            = kps.raids + EXCLUDED.raids,
+		successful_raids = kps.successful_raids + EXCLUDED.successful_raids,
+		empty_raids      = kps.empty_raids + EXCLUDED.empty_raids,
+		raid_points      = kps.raid_points + EXCLUDED.raid_points,
+		bonus_points     = kps.bonus_points + EXCLUDED.bonus_points,
+		tackles          = kps.tackles + EXCLUDED.tackles,
+		tackle_points    = kps.tackle_points + EXCLUDED.tackle_points,
+		super_tackles    = kps.super_tackles + EXCLUDED.super_tackles,
+		updated_at       = NOW()
+	RETURNING ` + kabaddiPlayerStatsColumns
+
+// AddOrUpdateKabaddiPlayerStats adds a finished match to the career stats of a player
+func (q *Queries) AddOrUpdateKabaddiPlayerStats(ctx context.Context, playerID int32, arg KabaddiPlayerMatchStats) (*models.KabaddiPlayerStats, error) {
+	row := q.db.QueryRowContext(ctx, addOrUpdateKabaddiPlayerStats,
+		playerID,
+		arg.Raids,
+		arg.SuccessfulRaids,
+		arg.EmptyRaids,
+		arg.RaidPoints,
+		arg.BonusPoints,
+		arg.Tackles,
+		arg.TacklePoints,
+		arg.SuperTackles,
+	)
+	var i models.KabaddiPlayerStats
+	if err := scanKabaddiPlayerStats(row, &i); err != nil {
+		return nil, fmt.Errorf("failed to upsert kabaddi player stats: %w", err)
+	}
+	return &i, nil
+}
+
+const getKabaddiPlayerStats = `
+	SELECT ` + kabaddiPlayerStatsColumns + `
+	FROM kabaddi_player_stats kps
+	WHERE kps.player_id = $1
+`
+
+// GetKabaddiPlayerStats returns the stats of a player, nil before their first finished match
+func (q *Queries) GetKabaddiPlayerStats(ctx context.Context, playerID int32) (*models.KabaddiPlayerStats, error) {
+	row := q.db.QueryRowContext(ctx, getKabaddiPlayerStats, playerID)
+	var i models.KabaddiPlayerStats
+	if err := scanKabaddiPlayerStats(row, &i); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get kabaddi player stats: %w", err)
+	}
+	return &i, nil
+}
@@ -0,0 +1,225 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"khelogames/database/models"
+)
+
+const kabaddiRaidColumns = `
+	kr.id, kr.public_id, kr.match_id, kr.half, kr.raid_number, kr.match_minute, kr.raiding_team_id,
+	kr.raider_player_id, kr.defender_player_id, kr.outcome, kr.touch_points, kr.bonus_point,
+	kr.tackled, kr.super_tackle, kr.do_or_die, kr.all_out, kr.raiding_points, kr.defending_points,
+	kr.home_on_court, kr.away_on_court, kr.created_at
+`
+
+func scanKabaddiRaid(row interface{ Scan(dest ...any) error }, i *models.KabaddiRaid) error {
+	return row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.Half,
+		&i.RaidNumber,
+		&i.MatchMinute,
+		&i.RaidingTeamID,
+		&i.RaiderPlayerID,
+		&i.DefenderPlayerID,
+		&i.Outcome,
+		&i.TouchPoints,
+		&i.BonusPoint,
+		&i.Tackled,
+		&i.SuperTackle,
+		&i.DoOrDie,
+		&i.AllOut,
+		&i.RaidingPoints,
+		&i.DefendingPoints,
+		&i.HomeOnCourt,
+		&i.AwayOnCourt,
+		&i.CreatedAt,
+	)
+}
+
+const addKabaddiRaid = `
+	INSERT INTO kabaddi_raids AS kr (
+		match_id,
+		half,
+		raid_number,
+		match_minute,
+		raiding_team_id,
+		raider_player_id,
+		defender_player_id,
+		outcome,
+		touch_points,
+		bonus_point,
+		tackled,
+		super_tackle,
+		do_or_die,
+		all_out,
+		raiding_points,
+		defending_points,
+		home_on_court,
+		away_on_court
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+	RETURNING ` + kabaddiRaidColumns
+
+type AddKabaddiRaidParams struct {
+	MatchID          int32  `json:"match_id"`
+	Half             string `json:"half"`
+	RaidNumber       int    `json:"raid_number"`
+	MatchMinute      int    `json:"match_minute"`
+	RaidingTeamID    int32  `json:"raiding_team_id"`
+	RaiderPlayerID   int32  `json:"raider_player_id"`
+	DefenderPlayerID *int32 `json:"defender_player_id"`
+	Outcome          string `json:"outcome"`
+	TouchPoints      int    `json:"touch_points"`
+	BonusPoint       bool   `json:"bonus_point"`
+	Tackled          bool   `json:"tackled"`
+	SuperTackle      bool   `json:"super_tackle"`
+	DoOrDie          bool   `json:"do_or_die"`
+	AllOut           bool   `json:"all_out"`
+	RaidingPoints    int    `json:"raiding_points"`
+	DefendingPoints  int    `json:"defending_points"`
+	HomeOnCourt      int    `json:"home_on_court"`
+	AwayOnCourt      int    `json:"away_on_court"`
+}
+
+func (q *Queries) AddKabaddiRaid(ctx context.Context, arg AddKabaddiRaidParams) (*models.KabaddiRaid, error) {
+	row := q.db.QueryRowContext(ctx, addKabaddiRaid,
+		arg.MatchID,
+		arg.Half,
+		arg.RaidNumber,
+		arg.MatchMinute,
+		arg.RaidingTeamID,
+		arg.RaiderPlayerID,
+		arg.DefenderPlayerID,
+		arg.Outcome,
+		arg.TouchPoints,
+		arg.BonusPoint,
+		arg.Tackled,
+		arg.SuperTackle,
+		arg.DoOrDie,
+		arg.AllOut,
+		arg.RaidingPoints,
+		arg.DefendingPoints,
+		arg.HomeOnCourt,
+		arg.AwayOnCourt,
+	)
+	var i models.KabaddiRaid
+	if err := scanKabaddiRaid(row, &i); err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const getKabaddiRaids = `
+	SELECT ` + kabaddiRaidColumns + `
+	FROM kabaddi_raids kr
+	WHERE kr.match_id = $1
+	ORDER BY kr.raid_number
+`
+
+// GetKabaddiRaids returns the raids of a match in the order they were played
+func (q *Queries) GetKabaddiRaids(ctx context.Context, matchID int32) ([]models.KabaddiRaid, error) {
+	rows, err := q.db.QueryContext(ctx, getKabaddiRaids, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var raids []models.KabaddiRaid
+	for rows.Next() {
+		var i models.KabaddiRaid
+		if err := scanKabaddiRaid(rows, &i); err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		raids = append(raids, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return raids, nil
+}
+
+const getKabaddiMatchPlayerStats = `
+	WITH raiders AS (
+		SELECT
+			raider_player_id AS player_id,
+			COUNT(*) AS raids,
+			COUNT(*) FILTER (WHERE outcome = 'successful') AS successful_raids,
+			COUNT(*) FILTER (WHERE outcome = 'empty') AS empty_raids,
+			SUM(touch_points + CASE WHEN bonus_point THEN 1 ELSE 0 END) AS raid_points,
+			COUNT(*) FILTER (WHERE bonus_point) AS bonus_points
+		FROM kabaddi_raids
+		WHERE match_id = $1
+		GROUP BY raider_player_id
+	),
+	defenders AS (
+		SELECT
+			defender_player_id AS player_id,
+			COUNT(*) AS tackles,
+			COUNT(*) + COUNT(*) FILTER (WHERE super_tackle) AS tackle_points,
+			COUNT(*) FILTER (WHERE super_tackle) AS super_tackles
+		FROM kabaddi_raids
+		WHERE match_id = $1 AND tackled AND defender_player_id IS NOT NULL
+		GROUP BY defender_player_id
+	)
+	SELECT
+		COALESCE(r.player_id, d.player_id),
+		COALESCE(r.raids, 0),
+		COALESCE(r.successful_raids, 0),
+		COALESCE(r.empty_raids, 0),
+		COALESCE(r.raid_points, 0),
+		COALESCE(r.bonus_points, 0),
+		COALESCE(d.tackles, 0),
+		COALESCE(d.tackle_points, 0),
+		COALESCE(d.super_tackles, 0)
+	FROM raiders r
+	FULL OUTER JOIN defenders d ON d.player_id = r.player_id
+`
+
+// KabaddiPlayerMatchStats is what a player did in the raids of one match
+type KabaddiPlayerMatchStats struct {
+	Raids           int `json:"raids"`
+	SuccessfulRaids int `json:"successful_raids"`
+	EmptyRaids      int `json:"empty_raids"`
+	RaidPoints      int `json:"raid_points"`
+	BonusPoints     int `json:"bonus_points"`
+	Tackles         int `json:"tackles"`
+	TacklePoints    int `json:"tackle_points"`
+	SuperTackles    int `json:"super_tackles"`
+}
+
+// GetKabaddiMatchPlayerStats returns the raid and tackle stats of every player who raided or
+// tackled in the match
+func (q *Queries) GetKabaddiMatchPlayerStats(ctx context.Context, matchID int32) (map[int32]KabaddiPlayerMatchStats, error) {
+	rows, err := q.db.QueryContext(ctx, getKabaddiMatchPlayerStats, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make(map[int32]KabaddiPlayerMatchStats)
+	for rows.Next() {
+		var playerID int32
+		var i KabaddiPlayerMatchStats
+		err := rows.Scan(
+			&playerID,
+			&i.Raids,
+			&i.SuccessfulRaids,
+			&i.EmptyRaids,
+			&i.RaidPoints,
+			&i.BonusPoints,
+			&i.Tackles,
+			&i.TacklePoints,
+			&i.SuperTackles,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		stats[playerID] = i
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
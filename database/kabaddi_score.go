@@ -0,0 +1,124 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"khelogames/database/models"
+
+	"github.com/google/uuid"
+)
+
+const kabaddiScoreColumns = `
+	ks.id, ks.public_id, ks.match_id, ks.team_id, ks.raid_points, ks.tackle_points,
+	ks.all_out_points, ks.points, ks.created_at
+`
+
+func scanKabaddiScore(row interface{ Scan(dest ...any) error }, i *models.KabaddiScore) error {
+	return row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.TeamID,
+		&i.RaidPoints,
+		&i.TacklePoints,
+		&i.AllOutPoints,
+		&i.Points,
+		&i.CreatedAt,
+	)
+}
+
+const newKabaddiScore = `
+	INSERT INTO kabaddi_score AS ks (
+		match_id,
+		team_id
+	) VALUES ($1, $2)
+	RETURNING ` + kabaddiScoreColumns
+
+func (q *Queries) NewKabaddiScore(ctx context.Context, matchID, teamID int32) (*models.KabaddiScore, error) {
+	row := q.db.QueryRowContext(ctx, newKabaddiScore, matchID, teamID)
+	var i models.KabaddiScore
+	if err := scanKabaddiScore(row, &i); err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const getKabaddiScore = `
+	SELECT ` + kabaddiScoreColumns + `
+	FROM kabaddi_score ks
+	WHERE ks.match_id = $1 AND ks.team_id = $2
+`
+
+// GetKabaddiScore returns nil when the match has not been started
+func (q *Queries) GetKabaddiScore(ctx context.Context, matchID, teamID int32) (*models.KabaddiScore, error) {
+	row := q.db.QueryRowContext(ctx, getKabaddiScore, matchID, teamID)
+	var i models.KabaddiScore
+	if err := scanKabaddiScore(row, &i); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const getKabaddiMatchScore = `
+	SELECT ` + kabaddiScoreColumns + `
+	FROM kabaddi_score ks
+	JOIN matches m ON m.id = ks.match_id
+	WHERE m.public_id = $1
+`
+
+// KabaddiMatchScore is the score of both teams of a match, nil for a team whose score has not been
+// opened yet
+type KabaddiMatchScore struct {
+	Home *models.KabaddiScore `json:"home"`
+	Away *models.KabaddiScore `json:"away"`
+}
+
+func (q *Queries) GetKabaddiMatchScore(ctx context.Context, matchPublicID uuid.UUID, homeTeamID, awayTeamID int32) (*KabaddiMatchScore, error) {
+	rows, err := q.db.QueryContext(ctx, getKabaddiMatchScore, matchPublicID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var score KabaddiMatchScore
+	for rows.Next() {
+		var i models.KabaddiScore
+		if err := scanKabaddiScore(rows, &i); err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		switch i.TeamID {
+		case homeTeamID:
+			score.Home = &i
+		case awayTeamID:
+			score.Away = &i
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return &score, nil
+}
+
+const addKabaddiScorePoints = `
+	UPDATE kabaddi_score AS ks
+	SET
+		raid_points = ks.raid_points + $3,
+		tackle_points = ks.tackle_points + $4,
+		all_out_points = ks.all_out_points + $5,
+		points = ks.points + $3 + $4 + $5
+	WHERE ks.match_id = $1 AND ks.team_id = $2
+	RETURNING ` + kabaddiScoreColumns
+
+// AddKabaddiScorePoints adds the points a team won in a raid to its score
+func (q *Queries) AddKabaddiScorePoints(ctx context.Context, matchID, teamID int32, raidPoints, tacklePoints, allOutPoints int) (*models.KabaddiScore, error) {
+	row := q.db.QueryRowContext(ctx, addKabaddiScorePoints, matchID, teamID, raidPoints, tacklePoints, allOutPoints)
+	var i models.KabaddiScore
+	if err := scanKabaddiScore(row, &i); err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
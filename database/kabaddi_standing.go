@@ -0,0 +1,236 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"khelogames/database/models"
+
+	"github.com/google/uuid"
+)
+
+const kabaddiStandingColumns = `
+	ks.id, ks.public_id, ks.tournament_id, ks.group_id, ks.team_id, ks.matches, ks.wins, ks.loss,
+	ks.draw, ks.points_for, ks.points_against, ks.point_difference, ks.points
+`
+
+func scanKabaddiStanding(row interface{ Scan(dest ...any) error }, i *models.KabaddiStanding) error {
+	return row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.TournamentID,
+		&i.GroupID,
+		&i.TeamID,
+		&i.Matches,
+		&i.Wins,
+		&i.Loss,
+		&i.Draw,
+		&i.PointsFor,
+		&i.PointsAgainst,
+		&i.PointDifference,
+		&i.Points,
+	)
+}
+
+const createKabaddiStanding = `
+WITH tournamentID AS (
+    SELECT * FROM tournaments WHERE public_id = $1
+),
+teamID AS (
+    SELECT * FROM teams WHERE public_id = $3
+)
+INSERT INTO kabaddi_standing AS ks (
+    tournament_id,
+    group_id,
+    team_id
+)
+SELECT
+    tournamentID.id,
+    $2,
+    teamID.id
+FROM tournamentID, teamID
+RETURNING ` + kabaddiStandingColumns
+
+func (q *Queries) CreateKabaddiStanding(ctx context.Context, tournamentPublicID uuid.UUID, groupID int32, teamPublicID uuid.UUID) (*models.KabaddiStanding, error) {
+	row := q.db.QueryRowContext(ctx, createKabaddiStanding, tournamentPublicID, groupID, teamPublicID)
+	var i models.KabaddiStanding
+	err := scanKabaddiStanding(row, &i)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const getKabaddiStanding = `
+	SELECT
+		JSON_AGG(
+			JSON_BUILD_OBJECT(
+				'id', ks.id,
+				'public_id', ks.public_id,
+				'tournament_id', ks.tournament_id,
+				'group_id', ks.group_id,
+				'team_id', ks.team_id,
+				'matches', ks.matches,
+				'wins', ks.wins,
+				'loss', ks.loss,
+				'draw', ks.draw,
+				'points_for', ks.points_for,
+				'points_against', ks.points_against,
+				'point_difference', ks.point_difference,
+				'points', ks.points,
+				'tournament', JSON_BUILD_OBJECT(
+					'id', t.id,
+					'public_id', t.public_id,
+					'user_id', t.user_id,
+					'name', t.name,
+					'slug', t.slug,
+					'country', t.country,
+					'status', t.status,
+					'level', t.level,
+					'start_timestamp', t.start_timestamp,
+					'game_id', t.game_id
+				),
+				'group', CASE
+					WHEN g.id IS NOT NULL THEN JSON_BUILD_OBJECT(
+						'id', g.id,
+						'name', g.name
+					)
+					ELSE NULL
+				END,
+				'teams', JSON_BUILD_OBJECT(
+					'id', tm.id,
+					'public_id', tm.public_id,
+					'user_id', tm.user_id,
+					'name', tm.name,
+					'slug', tm.slug,
+					'short_name', tm.shortname,
+					'media_url', tm.media_url,
+					'gender', tm.gender,
+					'national', tm.national,
+					'country', tm.country,
+					'type', tm.type,
+					'player_count', tm.player_count,
+					'game_id', tm.game_id
+				)
+			)
+			ORDER BY ks.points DESC, ks.point_difference DESC
+		) AS standing_data
+	FROM kabaddi_standing ks
+	LEFT JOIN groups g ON ks.group_id = g.id
+	JOIN tournaments t ON t.id = ks.tournament_id
+	JOIN teams tm ON ks.team_id = tm.id
+	WHERE t.public_id = $1;
+`
+
+type GetKabaddiStandingR struct {
+	StandingData interface{} `json:"standing_data"`
+}
+
+// GetKabaddiStanding returns the standing of every team of the tournament as JSON, a nil
+// StandingData when no standing has been created
+func (q *Queries) GetKabaddiStanding(ctx context.Context, tournamentPublicID uuid.UUID) (*GetKabaddiStandingR, error) {
+	row := q.db.QueryRowContext(ctx, getKabaddiStanding, tournamentPublicID)
+	var standings GetKabaddiStandingR
+	if err := row.Scan(&standings.StandingData); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &standings, nil
+}
+
+const getKabaddiTeamTournamentResults = `
+	SELECT
+		COALESCE(own.points, 0),
+		COALESCE(opp.points, 0)
+	FROM matches m
+	LEFT JOIN kabaddi_score own ON own.match_id = m.id AND own.team_id = $2
+	LEFT JOIN kabaddi_score opp ON opp.match_id = m.id AND opp.team_id <> $2
+	WHERE m.tournament_id = $1
+	AND (m.home_team_id = $2 OR m.away_team_id = $2)
+	AND (LOWER(m.stage) = 'group' OR LOWER(m.stage) = 'league')
+	AND m.status_code = 'finished'
+`
+
+// KabaddiTeamResult is the score of a team and of its opponent in a finished match
+type KabaddiTeamResult struct {
+	PointsFor     int `json:"points_for"`
+	PointsAgainst int `json:"points_against"`
+}
+
+// GetKabaddiTeamTournamentResults returns the finished group and league matches of a team in the
+// tournament
+func (q *Queries) GetKabaddiTeamTournamentResults(ctx context.Context, tournamentID, teamID int32) ([]KabaddiTeamResult, error) {
+	rows, err := q.db.QueryContext(ctx, getKabaddiTeamTournamentResults, tournamentID, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var results []KabaddiTeamResult
+	for rows.Next() {
+		var i KabaddiTeamResult
+		if err := rows.Scan(&i.PointsFor, &i.PointsAgainst); err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		results = append(results, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+const updateKabaddiStanding = `
+	UPDATE kabaddi_standing AS ks
+	SET
+		matches = $3,
+		wins = $4,
+		loss = $5,
+		draw = $6,
+		points_for = $7,
+		points_against = $8,
+		point_difference = $7 - $8,
+		points = $9
+	WHERE ks.tournament_id = $1 AND ks.team_id = $2
+	RETURNING ` + kabaddiStandingColumns
+
+type UpdateKabaddiStandingParams struct {
+	TournamentID  int32 `json:"tournament_id"`
+	TeamID        int32 `json:"team_id"`
+	Matches       int   `json:"matches"`
+	Wins          int   `json:"wins"`
+	Loss          int   `json:"loss"`
+	Draw          int   `json:"draw"`
+	PointsFor     int   `json:"points_for"`
+	PointsAgainst int   `json:"points_against"`
+	Points        int   `json:"points"`
+}
+
+// UpdateKabaddiStanding returns nil when the team has no standing in the tournament
+func (q *Queries) UpdateKabaddiStanding(ctx context.Context, arg UpdateKabaddiStandingParams) (*models.KabaddiStanding, error) {
+	row := q.db.QueryRowContext(ctx, updateKabaddiStanding,
+		arg.TournamentID,
+		arg.TeamID,
+		arg.Matches,
+		arg.Wins,
+		arg.Loss,
+		arg.Draw,
+		arg.PointsFor,
+		arg.PointsAgainst,
+		arg.Points,
+	)
+	var i models.KabaddiStanding
+	err := scanKabaddiStanding(row, &i)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
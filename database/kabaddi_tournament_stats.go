@@ -0,0 +1,105 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// getKabaddiTournamentPlayerStat runs a leaderboard query returning player_id, name, team name and
+// the stat of each player of the tournament
+func (q *Queries) getKabaddiTournamentPlayerStat(ctx context.Context, query string, tournamentPublicID uuid.UUID) ([]map[string]interface{}, error) {
+	rows, err := q.db.QueryContext(ctx, query, tournamentPublicID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []map[string]interface{}
+	for rows.Next() {
+		var playerID int64
+		var playerName, teamName string
+		var statValue int
+		if err := rows.Scan(&playerID, &playerName, &teamName, &statValue); err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		stats = append(stats, map[string]interface{}{
+			"player_id":   playerID,
+			"player_name": playerName,
+			"team_name":   teamName,
+			"stat_value":  statValue,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// player raid points, touch and bonus points
+const getKabaddiTournamentRaidPoints = `
+	SELECT
+		p.id AS player_id,
+		p.name,
+		tm.name AS team_name,
+		SUM(kr.touch_points + CASE WHEN kr.bonus_point THEN 1 ELSE 0 END) AS raid_points
+	FROM kabaddi_raids kr
+	JOIN matches m ON m.id = kr.match_id
+	JOIN tournaments t ON t.id = m.tournament_id
+	JOIN players p ON p.id = kr.raider_player_id
+	JOIN teams tm ON tm.id = kr.raiding_team_id
+	WHERE t.public_id = $1
+	GROUP BY p.id, p.name, tm.name
+	HAVING SUM(kr.touch_points + CASE WHEN kr.bonus_point THEN 1 ELSE 0 END) > 0
+	ORDER BY raid_points DESC
+	LIMIT 20;
+`
+
+func (q *Queries) GetKabaddiTournamentRaidPoints(ctx context.Context, tournamentPublicID uuid.UUID) ([]map[string]interface{}, error) {
+	return q.getKabaddiTournamentPlayerStat(ctx, getKabaddiTournamentRaidPoints, tournamentPublicID)
+}
+
+// player tackle points, two for a super tackle
+const getKabaddiTournamentTacklePoints = `
+	SELECT
+		p.id AS player_id,
+		p.name,
+		tm.name AS team_name,
+		COUNT(*) + COUNT(*) FILTER (WHERE kr.super_tackle) AS tackle_points
+	FROM kabaddi_raids kr
+	JOIN matches m ON m.id = kr.match_id
+	JOIN tournaments t ON t.id = m.tournament_id
+	JOIN players p ON p.id = kr.defender_player_id
+	JOIN teams tm ON tm.id = CASE WHEN kr.raiding_team_id = m.home_team_id THEN m.away_team_id ELSE m.home_team_id END
+	WHERE t.public_id = $1 AND kr.tackled
+	GROUP BY p.id, p.name, tm.name
+	ORDER BY tackle_points DESC
+	LIMIT 20;
+`
+
+func (q *Queries) GetKabaddiTournamentTacklePoints(ctx context.Context, tournamentPublicID uuid.UUID) ([]map[string]interface{}, error) {
+	return q.getKabaddiTournamentPlayerStat(ctx, getKabaddiTournamentTacklePoints, tournamentPublicID)
+}
+
+// player super tackles
+const getKabaddiTournamentSuperTackles = `
+	SELECT
+		p.id AS player_id,
+		p.name,
+		tm.name AS team_name,
+		COUNT(*) AS super_tackles
+	FROM kabaddi_raids kr
+	JOIN matches m ON m.id = kr.match_id
+	JOIN tournaments t ON t.id = m.tournament_id
+	JOIN players p ON p.id = kr.defender_player_id
+	JOIN teams tm ON tm.id = CASE WHEN kr.raiding_team_id = m.home_team_id THEN m.away_team_id ELSE m.home_team_id END
+	WHERE t.public_id = $1 AND kr.super_tackle
+	GROUP BY p.id, p.name, tm.name
+	ORDER BY super_tackles DESC
+	LIMIT 20;
+`
+
+func (q *Queries) GetKabaddiTournamentSuperTackles(ctx context.Context, tournamentPublicID uuid.UUID) ([]map[string]interface{}, error) {
+	return q.getKabaddiTournamentPlayerStat(ctx, getKabaddiTournamentSuperTackles, tournamentPublicID)
+}
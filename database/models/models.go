@@ -55,20 +55,61 @@ type BatsmanScore struct {
 }
 
 type CricketScore struct {
-	ID                int64     `json:"id"`
-	PublicID          uuid.UUID `json:"public_id"`
-	MatchID           int32     `json:"match_id"`
-	TeamID            int32     `json:"team_id"`
-	InningNumber      int       `json:"inning_number"`
-	Score             int       `json:"score"`
-	Wickets           int       `json:"wickets"`
-	Overs             int       `json:"overs"`
-	RunRate           string    `json:"run_rate"`
-	TargetRunRate     string    `json:"target_run_rate"`
-	FollowOn          bool      `json:"follow_on"`
-	IsInningCompleted bool      `json:"is_inning_completed"`
-	Declared          bool      `json:"declared"`
-	InningStatus      string    `json:"inning_status"`
+	ID                int64          `json:"id"`
+	PublicID          uuid.UUID      `json:"public_id"`
+	MatchID           int32          `json:"match_id"`
+	TeamID            int32          `json:"team_id"`
+	InningNumber      int            `json:"inning_number"`
+	Score             int            `json:"score"`
+	Wickets           int            `json:"wickets"`
+	Overs             int            `json:"overs"`
+	RunRate           string         `json:"run_rate"`
+	TargetRunRate     string         `json:"target_run_rate"`
+	FollowOn          bool           `json:"follow_on"`
+	IsInningCompleted bool           `json:"is_inning_completed"`
+	Declared          bool           `json:"declared"`
+	InningStatus      string         `json:"inning_status"`
+	Extras            *CricketExtras `json:"extras,omitempty"`
+}
+
+// CricketExtras is the extras breakdown of an inning, derived from the deliveries
+type CricketExtras struct {
+	MatchID      int32 `json:"match_id"`
+	TeamID       int32 `json:"team_id"`
+	InningNumber int   `json:"inning_number"`
+	Wides        int   `json:"wides"`
+	NoBalls      int   `json:"no_balls"`
+	Byes         int   `json:"byes"`
+	LegByes      int   `json:"leg_byes"`
+	Penalty      int   `json:"penalty"`
+	Total        int   `json:"total"`
+}
+
+// CricketInterruption is a stoppage in a limited overs inning during which overs were lost
+type CricketInterruption struct {
+	ID           int64     `json:"id"`
+	PublicID     uuid.UUID `json:"public_id"`
+	MatchID      int32     `json:"match_id"`
+	TeamID       int32     `json:"team_id"`
+	InningNumber int       `json:"inning_number"`
+	BallsBowled  int       `json:"balls_bowled"`
+	Wickets      int       `json:"wickets"`
+	OversLost    int       `json:"overs_lost"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// CricketRevisedTarget is the DLS revision of a match shortened by interruptions
+type CricketRevisedTarget struct {
+	ID                    int64     `json:"id"`
+	PublicID              uuid.UUID `json:"public_id"`
+	MatchID               int32     `json:"match_id"`
+	FirstInningOvers      int       `json:"first_inning_overs"`
+	SecondInningOvers     int       `json:"second_inning_overs"`
+	FirstInningResources  float64   `json:"first_inning_resources"`
+	SecondInningResources float64   `json:"second_inning_resources"`
+	RevisedTarget         int       `json:"revised_target"`
+	ParScore              int       `json:"par_score"`
+	UpdatedAt             time.Time `json:"updated_at"`
 }
 
 type CricketToss struct {
@@ -191,9 +232,27 @@ type FootballIncident struct {
 	Periods               string    `json:"periods"`
 	IncidentType          string    `json:"incident_type"`
 	IncidentTime          int       `json:"incident_time"`
+	AddedTime             int       `json:"added_time"`
 	Description           string    `json:"description"`
 	PenaltyShootoutScored bool      `json:"penalty_shootout_scored"`
 	CreatedAt             int64     `json:"created_at"`
+	AssistPlayerID        *int32    `json:"assist_player_id"`
+	IsCancelled           bool      `json:"is_cancelled"`
+	CancelledIncidentID   *int64    `json:"cancelled_incident_id"`
+}
+
+// FootballMatchClock is the server side clock of a football match. ElapsedSeconds holds the
+// time played in the current period before StartedAt, the unix time the clock last resumed.
+type FootballMatchClock struct {
+	ID             int64     `json:"id"`
+	PublicID       uuid.UUID `json:"public_id"`
+	MatchID        int32     `json:"match_id"`
+	State          string    `json:"state"`
+	IsRunning      bool      `json:"is_running"`
+	ElapsedSeconds int       `json:"elapsed_seconds"`
+	StartedAt      *int64    `json:"started_at"`
+	AddedMinutes   int       `json:"added_minutes"`
+	UpdatedAt      int64     `json:"updated_at"`
 }
 
 type FootballIncidentPlayer struct {
@@ -477,6 +536,70 @@ type Wicket struct {
 	Score         *int      `json:"score"`
 }
 
+// CricketFieldingScore holds the dismissals a fielder took part in during an inning
+type CricketFieldingScore struct {
+	ID           int64     `json:"id"`
+	PublicID     uuid.UUID `json:"public_id"`
+	MatchID      int32     `json:"match_id"`
+	TeamID       int32     `json:"team_id"`
+	PlayerID     int32     `json:"player_id"`
+	InningNumber int       `json:"inning_number"`
+	Catches      int       `json:"catches"`
+	Stumpings    int       `json:"stumpings"`
+	RunOuts      int       `json:"run_outs"`
+}
+
+// CricketDelivery is a single ball of an inning. Batting, bowling and inning
+// aggregates are derived from these rows, undone rows are kept for redo.
+type CricketDelivery struct {
+	ID                 int64     `json:"id"`
+	PublicID           uuid.UUID `json:"public_id"`
+	MatchID            int32     `json:"match_id"`
+	TeamID             int32     `json:"team_id"`
+	InningNumber       int       `json:"inning_number"`
+	OverNumber         int       `json:"over_number"`
+	BallNumber         int       `json:"ball_number"`
+	BowlerID           int32     `json:"bowler_id"`
+	StrikerID          int32     `json:"striker_id"`
+	NonStrikerID       int32     `json:"non_striker_id"`
+	Runs               int       `json:"runs"`
+	ExtrasType         *string   `json:"extras_type"`
+	ExtrasRuns         int       `json:"extras_runs"`
+	IsLegal            bool      `json:"is_legal"`
+	IsWicket           bool      `json:"is_wicket"`
+	WicketType         *string   `json:"wicket_type"`
+	DismissedBatsmanID *int32    `json:"dismissed_batsman_id"`
+	FielderID          *int32    `json:"fielder_id"`
+	IsUndone           bool      `json:"is_undone"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// CricketPenaltyRuns are penalty runs awarded to a team outside of a delivery. InningNumber is
+// nil until the team starts the inning the runs are added to.
+type CricketPenaltyRuns struct {
+	ID           int64     `json:"id"`
+	PublicID     uuid.UUID `json:"public_id"`
+	MatchID      int32     `json:"match_id"`
+	TeamID       int32     `json:"team_id"`
+	InningNumber *int      `json:"inning_number"`
+	Runs         int       `json:"runs"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// CricketCommentary is a templated line of commentary generated from a delivery
+type CricketCommentary struct {
+	ID           int64     `json:"id"`
+	PublicID     uuid.UUID `json:"public_id"`
+	MatchID      int32     `json:"match_id"`
+	DeliveryID   int64     `json:"delivery_id"`
+	InningNumber int       `json:"inning_number"`
+	OverNumber   int       `json:"over_number"`
+	BallNumber   int       `json:"ball_number"`
+	EventType    string    `json:"event_type"`
+	Commentary   string    `json:"commentary"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
 type GetPlayerByTeam struct {
 	ID         int64     `json:"id"`
 	PublicID   uuid.UUID `json:"public_id"`
@@ -516,15 +639,105 @@ type SearchTeam struct {
 }
 
 type FootballSquad struct {
-	ID           int64     `json:"id"`
-	PublicID     uuid.UUID `json:"public_id"`
-	MatchID      *int32    `json:"match_id"`
-	TeamID       int32     `json:"team_id"`
-	PlayerID     int32     `json:"player_id"`
-	Position     *string   `json:"position"`
-	IsSubstitute bool      `json:"is_substitute"`
-	Role         *string   `json:"role"`
-	CreatedAT    time.Time `json:"created_at"`
+	ID            int64     `json:"id"`
+	PublicID      uuid.UUID `json:"public_id"`
+	MatchID       *int32    `json:"match_id"`
+	TeamID        int32     `json:"team_id"`
+	PlayerID      int32     `json:"player_id"`
+	Position      *string   `json:"position"`
+	IsSubstitute  bool      `json:"is_substitute"`
+	Role          *string   `json:"role"`
+	CreatedAT     time.Time `json:"created_at"`
+	FormationSlot *string   `json:"formation_slot"`
+}
+
+// FootballDisciplinaryRules is the disciplinary ruleset of a football tournament
+type FootballDisciplinaryRules struct {
+	ID                  int64     `json:"id"`
+	TournamentID        int32     `json:"tournament_id"`
+	YellowCardThreshold int       `json:"yellow_card_threshold"`
+	YellowCardBan       int       `json:"yellow_card_ban"`
+	RedCardBan          int       `json:"red_card_ban"`
+	CarryOverToKnockout bool      `json:"carry_over_to_knockout"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// FootballSuspension is a ban a player picked up in SourceMatchID, it is served by sitting out
+// the next MatchesBanned matches of the team
+type FootballSuspension struct {
+	ID            int64     `json:"id"`
+	PublicID      uuid.UUID `json:"public_id"`
+	TournamentID  int32     `json:"tournament_id"`
+	PlayerID      int32     `json:"player_id"`
+	TeamID        int32     `json:"team_id"`
+	SourceMatchID int32     `json:"source_match_id"`
+	Reason        string    `json:"reason"`
+	MatchesBanned int       `json:"matches_banned"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// FootballIncidentCount is the number of incidents of one type a team had in a period
+type FootballIncidentCount struct {
+	TeamID       int32  `json:"team_id"`
+	Periods      string `json:"periods"`
+	IncidentType string `json:"incident_type"`
+	Count        int    `json:"count"`
+}
+
+// FootballPossession is the ball possession of a team in a period, entered by the scorer
+type FootballPossession struct {
+	ID         int64     `json:"id"`
+	MatchID    int32     `json:"match_id"`
+	TeamID     int32     `json:"team_id"`
+	Periods    string    `json:"periods"`
+	Possession int       `json:"possession"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// FootballPlayerRating is the rating of a player for a finished match with the performance it
+// was worked out from
+type FootballPlayerRating struct {
+	ID              int64     `json:"id"`
+	PublicID        uuid.UUID `json:"public_id"`
+	MatchID         int32     `json:"match_id"`
+	TournamentID    int32     `json:"tournament_id"`
+	PlayerID        int32     `json:"player_id"`
+	TeamID          int32     `json:"team_id"`
+	Rating          float64   `json:"rating"`
+	MinutesPlayed   int       `json:"minutes_played"`
+	Goals           int       `json:"goals"`
+	Assists         int       `json:"assists"`
+	OwnGoals        int       `json:"own_goals"`
+	YellowCards     int       `json:"yellow_cards"`
+	RedCards        int       `json:"red_cards"`
+	CleanSheet      bool      `json:"clean_sheet"`
+	IsManOfTheMatch bool      `json:"is_man_of_the_match"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// FootballShootoutKick is a kick of a penalty shootout, KickNumber is its place in the order of
+// kicks of both teams
+type FootballShootoutKick struct {
+	ID         int64     `json:"id"`
+	PublicID   uuid.UUID `json:"public_id"`
+	MatchID    int32     `json:"match_id"`
+	TeamID     int32     `json:"team_id"`
+	IncidentID int64     `json:"incident_id"`
+	KickNumber int       `json:"kick_number"`
+	TakerID    int32     `json:"taker_id"`
+	KeeperID   *int32    `json:"keeper_id"`
+	Outcome    string    `json:"outcome"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// FootballFormation is the formation a team lines up in for a match
+type FootballFormation struct {
+	ID        int64     `json:"id"`
+	PublicID  uuid.UUID `json:"public_id"`
+	MatchID   int32     `json:"match_id"`
+	TeamID    int32     `json:"team_id"`
+	Formation string    `json:"formation"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type CricketSquad struct {
@@ -706,6 +919,43 @@ type BadmintonSetsPoints struct {
 	CreatedAt     time.Time `json:"created_at"`
 }
 
+type BadmintonService struct {
+	ID               int64     `json:"id"`
+	MatchID          int32     `json:"match_id"`
+	SetNumber        int       `json:"set_number"`
+	ServerTeamID     int32     `json:"server_team_id"`
+	ServerPlayerID   int32     `json:"server_player_id"`
+	ReceiverTeamID   int32     `json:"receiver_team_id"`
+	ReceiverPlayerID int32     `json:"receiver_player_id"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// BadmintonScoringProfile is the scoring format of a tournament, or of a single match when
+// MatchID is set
+type BadmintonScoringProfile struct {
+	ID            int64     `json:"id"`
+	TournamentID  *int32    `json:"tournament_id"`
+	MatchID       *int32    `json:"match_id"`
+	PointsPerGame int       `json:"points_per_game"`
+	WinBy         int       `json:"win_by"`
+	MaxPoints     int       `json:"max_points"`
+	GamesToWin    int       `json:"games_to_win"`
+	IntervalAt    int       `json:"interval_at"`
+	HomeHandicap  int       `json:"home_handicap"`
+	AwayHandicap  int       `json:"away_handicap"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// BadmintonPlayerMatchResult is how a player did in one finished badminton match
+type BadmintonPlayerMatchResult struct {
+	MatchID        int32 `json:"match_id"`
+	Won            bool  `json:"won"`
+	SetsWon        int   `json:"sets_won"`
+	SetsLost       int   `json:"sets_lost"`
+	PointsScored   int   `json:"points_scored"`
+	PointsConceded int   `json:"points_conceded"`
+}
+
 type BadmintonPlayerStats struct {
 	ID             int64     `json:"id"`
 	PublicID       uuid.UUID `json:"public_id"`
@@ -724,3 +974,169 @@ type BadmintonPlayerStats struct {
 	CreatedAt      time.Time `json:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at"`
 }
+
+type TableTennisScore struct {
+	ID        int64     `json:"id"`
+	PublicID  uuid.UUID `json:"public_id"`
+	MatchID   int32     `json:"match_id"`
+	SetNumber int       `json:"set_number"`
+	HomeScore int       `json:"home_score"`
+	AwayScore int       `json:"away_score"`
+	SetStatus string    `json:"set_status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TableTennisPoint struct {
+	ID            int64     `json:"id"`
+	PublicID      uuid.UUID `json:"public_id"`
+	MatchID       int32     `json:"match_id"`
+	SetNumber     int       `json:"set_number"`
+	ScoringTeamID int32     `json:"scoring_team_id"`
+	ServerTeamID  int32     `json:"server_team_id"`
+	HomeScore     int       `json:"home_score"`
+	AwayScore     int       `json:"away_score"`
+	PointNumber   int       `json:"point_number"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type TableTennisPlayerStats struct {
+	ID             int64     `json:"id"`
+	PublicID       uuid.UUID `json:"public_id"`
+	PlayerID       int32     `json:"player_id"`
+	PlayType       string    `json:"play_type"`
+	Matches        int       `json:"matches"`
+	Wins           int       `json:"wins"`
+	Losses         int       `json:"losses"`
+	SetsWon        int       `json:"sets_won"`
+	SetsLost       int       `json:"sets_lost"`
+	PointsScored   int       `json:"points_scored"`
+	PointsConceded int       `json:"points_conceded"`
+	WinPercentage  string    `json:"win_percentage"`
+	CurrentStreak  int       `json:"current_streak"`
+	BestStreak     int       `json:"best_streak"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type VolleyballScore struct {
+	ID        int64     `json:"id"`
+	PublicID  uuid.UUID `json:"public_id"`
+	MatchID   int32     `json:"match_id"`
+	SetNumber int       `json:"set_number"`
+	HomeScore int       `json:"home_score"`
+	AwayScore int       `json:"away_score"`
+	SetStatus string    `json:"set_status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// VolleyballPoint is a rally of a set, with the side and player who served it
+type VolleyballPoint struct {
+	ID             int64     `json:"id"`
+	PublicID       uuid.UUID `json:"public_id"`
+	MatchID        int32     `json:"match_id"`
+	SetNumber      int       `json:"set_number"`
+	ScoringTeamID  int32     `json:"scoring_team_id"`
+	ServingTeamID  int32     `json:"serving_team_id"`
+	ServerPlayerID *int32    `json:"server_player_id"`
+	HomeScore      int       `json:"home_score"`
+	AwayScore      int       `json:"away_score"`
+	PointNumber    int       `json:"point_number"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type VolleyballPlayerStats struct {
+	ID            int64     `json:"id"`
+	PublicID      uuid.UUID `json:"public_id"`
+	PlayerID      int32     `json:"player_id"`
+	Matches       int       `json:"matches"`
+	Wins          int       `json:"wins"`
+	Losses        int       `json:"losses"`
+	SetsWon       int       `json:"sets_won"`
+	SetsLost      int       `json:"sets_lost"`
+	ServicePoints int       `json:"service_points"`
+	WinPercentage string    `json:"win_percentage"`
+	CurrentStreak int       `json:"current_streak"`
+	BestStreak    int       `json:"best_streak"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type KabaddiScore struct {
+	ID           int64     `json:"id"`
+	PublicID     uuid.UUID `json:"public_id"`
+	MatchID      int32     `json:"match_id"`
+	TeamID       int32     `json:"team_id"`
+	RaidPoints   int       `json:"raid_points"`
+	TacklePoints int       `json:"tackle_points"`
+	AllOutPoints int       `json:"all_out_points"`
+	Points       int       `json:"points"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type KabaddiRaid struct {
+	ID               int64     `json:"id"`
+	PublicID         uuid.UUID `json:"public_id"`
+	MatchID          int32     `json:"match_id"`
+	Half             string    `json:"half"`
+	RaidNumber       int       `json:"raid_number"`
+	MatchMinute      int       `json:"match_minute"`
+	RaidingTeamID    int32     `json:"raiding_team_id"`
+	RaiderPlayerID   int32     `json:"raider_player_id"`
+	DefenderPlayerID *int32    `json:"defender_player_id"`
+	Outcome          string    `json:"outcome"`
+	TouchPoints      int       `json:"touch_points"`
+	BonusPoint       bool      `json:"bonus_point"`
+	Tackled          bool      `json:"tackled"`
+	SuperTackle      bool      `json:"super_tackle"`
+	DoOrDie          bool      `json:"do_or_die"`
+	AllOut           bool      `json:"all_out"`
+	RaidingPoints    int       `json:"raiding_points"`
+	DefendingPoints  int       `json:"defending_points"`
+	HomeOnCourt      int       `json:"home_on_court"`
+	AwayOnCourt      int       `json:"away_on_court"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type KabaddiMatchClock struct {
+	ID             int64     `json:"id"`
+	PublicID       uuid.UUID `json:"public_id"`
+	MatchID        int32     `json:"match_id"`
+	State          string    `json:"state"`
+	IsRunning      bool      `json:"is_running"`
+	ElapsedSeconds int       `json:"elapsed_seconds"`
+	StartedAt      *int64    `json:"started_at"`
+	UpdatedAt      int64     `json:"updated_at"`
+}
+
+type KabaddiStanding struct {
+	ID              int64     `json:"id"`
+	PublicID        uuid.UUID `json:"public_id"`
+	TournamentID    int32     `json:"tournament_id"`
+	GroupID         *int32    `json:"group_id"`
+	TeamID          int32     `json:"team_id"`
+	Matches         int       `json:"matches"`
+	Wins            int       `json:"wins"`
+	Loss            int       `json:"loss"`
+	Draw            int       `json:"draw"`
+	PointsFor       int       `json:"points_for"`
+	PointsAgainst   int       `json:"points_against"`
+	PointDifference int       `json:"point_difference"`
+	Points          int       `json:"points"`
+}
+
+type KabaddiPlayerStats struct {
+	ID              int64     `json:"id"`
+	PublicID        uuid.UUID `json:"public_id"`
+	PlayerID        int32     `json:"player_id"`
+	Matches         int       `json:"matches"`
+	Raids           int       `json:"raids"`
+	SuccessfulRaids int       `json:"successful_raids"`
+	EmptyRaids      int       `json:"empty_raids"`
+	RaidPoints      int       `json:"raid_points"`
+	BonusPoints     int       `json:"bonus_points"`
+	Tackles         int       `json:"tackles"`
+	TacklePoints    int       `json:"tackle_points"`
+	SuperTackles    int       `json:"super_tackles"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
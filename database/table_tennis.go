@@ -0,0 +1,412 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"khelogames/database/models"
+
+	"github.com/google/uuid"
+)
+
+const addTableTennisScore = `
+	INSERT INTO table_tennis_score (
+		match_id,
+		set_number
+	)
+	VALUES (
+		$1, $2
+	) RETURNING *;
+`
+
+func (q *Queries) AddTableTennisScore(ctx context.Context, matchID int32, setNumber int) (*models.TableTennisScore, error) {
+	row := q.db.QueryRowContext(ctx, addTableTennisScore, matchID, setNumber)
+	var i models.TableTennisScore
+	err := row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.SetNumber,
+		&i.HomeScore,
+		&i.AwayScore,
+		&i.SetStatus,
+		&i.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const updateTableTennisScore = `
+	UPDATE table_tennis_score ts
+	SET
+		home_score = CASE WHEN m.home_team_id = $3 THEN ts.home_score + 1 ELSE ts.home_score END,
+		away_score = CASE WHEN m.away_team_id = $3 THEN ts.away_score + 1 ELSE ts.away_score END
+	FROM matches m
+	WHERE ts.match_id = m.id
+	AND ts.match_id = $1
+	AND ts.set_number = $2
+	RETURNING ts.*;
+`
+
+// UpdateTableTennisScore adds a point to the scoring team in the game
+func (q *Queries) UpdateTableTennisScore(ctx context.Context, matchID int32, setNumber int, scoringTeamID int32) (*models.TableTennisScore, error) {
+	row := q.db.QueryRowContext(ctx, updateTableTennisScore, matchID, setNumber, scoringTeamID)
+	var i models.TableTennisScore
+	err := row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.SetNumber,
+		&i.HomeScore,
+		&i.AwayScore,
+		&i.SetStatus,
+		&i.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const getTableTennisSetScore = `
+	SELECT * FROM table_tennis_score
+	WHERE match_id = $1 AND set_number = $2
+`
+
+// GetTableTennisSetScore returns the score of a game, nil when the game has not started
+func (q *Queries) GetTableTennisSetScore(ctx context.Context, matchID int32, setNumber int) (*models.TableTennisScore, error) {
+	row := q.db.QueryRowContext(ctx, getTableTennisSetScore, matchID, setNumber)
+	var i models.TableTennisScore
+	err := row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.SetNumber,
+		&i.HomeScore,
+		&i.AwayScore,
+		&i.SetStatus,
+		&i.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const getTableTennisMatchSetsScore = `
+	SELECT ts.*
+	FROM table_tennis_score ts
+	JOIN matches m ON m.id = ts.match_id
+	WHERE m.public_id = $1
+	ORDER BY ts.set_number;
+`
+
+func (q *Queries) GetTableTennisMatchSetsScore(ctx context.Context, matchPublicID uuid.UUID) ([]models.TableTennisScore, error) {
+	rows, err := q.db.QueryContext(ctx, getTableTennisMatchSetsScore, matchPublicID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var scores []models.TableTennisScore
+	for rows.Next() {
+		var i models.TableTennisScore
+		err := rows.Scan(
+			&i.ID,
+			&i.PublicID,
+			&i.MatchID,
+			&i.SetNumber,
+			&i.HomeScore,
+			&i.AwayScore,
+			&i.SetStatus,
+			&i.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		scores = append(scores, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
+
+const getTableTennisMatchScore = `
+	SELECT
+		COUNT(*) FILTER (WHERE ts.set_status = 'finished' AND ts.home_score > ts.away_score) AS home_sets_won,
+		COUNT(*) FILTER (WHERE ts.set_status = 'finished' AND ts.away_score > ts.home_score) AS away_sets_won
+	FROM table_tennis_score ts
+	JOIN matches m ON m.id = ts.match_id
+	WHERE m.public_id = $1
+`
+
+// GetTableTennisMatchScore returns the games won by each side
+func (q *Queries) GetTableTennisMatchScore(ctx context.Context, matchPublicID uuid.UUID) (*SetsWon, error) {
+	row := q.db.QueryRowContext(ctx, getTableTennisMatchScore, matchPublicID)
+	var result SetsWon
+	err := row.Scan(&result.HomeSetsWon, &result.AwaySetsWon)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &result, nil
+}
+
+const updateTableTennisSetStatus = `
+	UPDATE table_tennis_score
+	SET set_status = $3
+	WHERE match_id = $1 AND set_number = $2
+	RETURNING *;
+`
+
+func (q *Queries) UpdateTableTennisSetStatus(ctx context.Context, matchID int32, setNumber int, setStatus string) (*models.TableTennisScore, error) {
+	row := q.db.QueryRowContext(ctx, updateTableTennisSetStatus, matchID, setNumber, setStatus)
+	var i models.TableTennisScore
+	err := row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.SetNumber,
+		&i.HomeScore,
+		&i.AwayScore,
+		&i.SetStatus,
+		&i.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const addTableTennisPoint = `
+	INSERT INTO table_tennis_points (
+		match_id,
+		set_number,
+		scoring_team_id,
+		server_team_id,
+		home_score,
+		away_score,
+		point_number
+	)
+	VALUES (
+		$1, $2, $3, $4, $5, $6, $7
+	) RETURNING *;
+`
+
+type AddTableTennisPointParams struct {
+	MatchID       int32
+	SetNumber     int
+	ScoringTeamID int32
+	ServerTeamID  int32
+	HomeScore     int
+	AwayScore     int
+	PointNumber   int
+}
+
+func (q *Queries) AddTableTennisPoint(ctx context.Context, arg AddTableTennisPointParams) (*models.TableTennisPoint, error) {
+	row := q.db.QueryRowContext(ctx, addTableTennisPoint,
+		arg.MatchID,
+		arg.SetNumber,
+		arg.ScoringTeamID,
+		arg.ServerTeamID,
+		arg.HomeScore,
+		arg.AwayScore,
+		arg.PointNumber,
+	)
+	var i models.TableTennisPoint
+	err := row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.SetNumber,
+		&i.ScoringTeamID,
+		&i.ServerTeamID,
+		&i.HomeScore,
+		&i.AwayScore,
+		&i.PointNumber,
+		&i.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const getTableTennisPoints = `
+	SELECT * FROM table_tennis_points
+	WHERE match_id = $1 AND set_number = $2
+	ORDER BY point_number;
+`
+
+// GetTableTennisPoints returns the points of a game in the order they were played
+func (q *Queries) GetTableTennisPoints(ctx context.Context, matchID int32, setNumber int) ([]models.TableTennisPoint, error) {
+	rows, err := q.db.QueryContext(ctx, getTableTennisPoints, matchID, setNumber)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var points []models.TableTennisPoint
+	for rows.Next() {
+		var i models.TableTennisPoint
+		err := rows.Scan(
+			&i.ID,
+			&i.PublicID,
+			&i.MatchID,
+			&i.SetNumber,
+			&i.ScoringTeamID,
+			&i.ServerTeamID,
+			&i.HomeScore,
+			&i.AwayScore,
+			&i.PointNumber,
+			&i.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		points = append(points, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return points, nil
+}
+
+const addOrUpdateTableTennisPlayerStats = `
+	INSERT INTO table_tennis_player_stats (
+		player_id, play_type, matches, wins, losses,
+		sets_won, sets_lost, points_scored, points_conceded,
+		win_percentage, current_streak, best_streak
+	)
+	VALUES ($1, $2, 1, $3, $4, $5, $6, $7, $8, $3 * 100, $3, $3)
+	ON CONFLICT (player_id, play_type) DO UPDATE SET
+		matches         = table_tennis_player_stats.matches + 1,
+		wins            = table_tennis_player_stats.wins + EXCLUDED.wins,
+		losses          = table_tennis_player_stats.losses + EXCLUDED.losses,
+		sets_won        = table_tennis_player_stats.sets_won + EXCLUDED.sets_won,
+		sets_lost       = table_tennis_player_stats.sets_lost + EXCLUDED.sets_lost,
+		points_scored   = table_tennis_player_stats.points_scored + EXCLUDED.points_scored,
+		points_conceded = table_tennis_player_stats.points_conceded + EXCLUDED.points_conceded,
+		win_percentage  = ROUND(
+			((table_tennis_player_stats.wins + EXCLUDED.wins)::NUMERIC /
+			 (table_tennis_player_stats.matches + 1)::NUMERIC) * 100, 2
+		),
+		current_streak  = CASE
+			WHEN EXCLUDED.wins = 1 THEN table_tennis_player_stats.current_streak + 1
+			ELSE 0
+		END,
+		best_streak = GREATEST(
+			table_tennis_player_stats.best_streak,
+			CASE
+				WHEN EXCLUDED.wins = 1 THEN table_tennis_player_stats.current_streak + 1
+				ELSE table_tennis_player_stats.best_streak
+			END
+		),
+		updated_at = NOW()
+	RETURNING *;
+`
+
+type AddOrUpdateTableTennisPlayerStatsParams struct {
+	PlayerID       int32
+	PlayType       string
+	Wins           int // 1 if won, 0 if lost
+	Losses         int
+	SetsWon        int
+	SetsLost       int
+	PointsScored   int
+	PointsConceded int
+}
+
+func (q *Queries) AddOrUpdateTableTennisPlayerStats(ctx context.Context, arg AddOrUpdateTableTennisPlayerStatsParams) (*models.TableTennisPlayerStats, error) {
+	row := q.db.QueryRowContext(ctx, addOrUpdateTableTennisPlayerStats,
+		arg.PlayerID,
+		arg.PlayType,
+		arg.Wins,
+		arg.Losses,
+		arg.SetsWon,
+		arg.SetsLost,
+		arg.PointsScored,
+		arg.PointsConceded,
+	)
+	var stat models.TableTennisPlayerStats
+	err := row.Scan(
+		&stat.ID,
+		&stat.PublicID,
+		&stat.PlayerID,
+		&stat.PlayType,
+		&stat.Matches,
+		&stat.Wins,
+		&stat.Losses,
+		&stat.SetsWon,
+		&stat.SetsLost,
+		&stat.PointsScored,
+		&stat.PointsConceded,
+		&stat.WinPercentage,
+		&stat.CurrentStreak,
+		&stat.BestStreak,
+		&stat.CreatedAt,
+		&stat.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert table tennis player stats: %w", err)
+	}
+	return &stat, nil
+}
+
+const getTableTennisPlayerStats = `
+	SELECT * FROM table_tennis_player_stats
+	WHERE player_id = $1;
+`
+
+func (q *Queries) GetTableTennisPlayerStats(ctx context.Context, playerID int32) ([]models.TableTennisPlayerStats, error) {
+	rows, err := q.db.QueryContext(ctx, getTableTennisPlayerStats, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table tennis player stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []models.TableTennisPlayerStats
+	for rows.Next() {
+		var stat models.TableTennisPlayerStats
+		err := rows.Scan(
+			&stat.ID,
+			&stat.PublicID,
+			&stat.PlayerID,
+			&stat.PlayType,
+			&stat.Matches,
+			&stat.Wins,
+			&stat.Losses,
+			&stat.SetsWon,
+			&stat.SetsLost,
+			&stat.PointsScored,
+			&stat.PointsConceded,
+			&stat.WinPercentage,
+			&stat.CurrentStreak,
+			&stat.BestStreak,
+			&stat.CreatedAt,
+			&stat.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, stat)
+	}
+	return stats, nil
+}
@@ -16,19 +16,20 @@ SELECT
 			'public_id', p.public_id,
 			'name', p.name
 		),
-		'goals', COUNT(fi.id)
+		'goals', SUM(r.goals),
+		'average_rating', ROUND(AVG(r.rating), 2),
+		'man_of_the_match', COUNT(*) FILTER (WHERE r.is_man_of_the_match)
 	)
 FROM matches m
-JOIN football_incidents fi ON fi.match_id = m.id  AND fi.incident_type = 'goals'
-JOIN football_incident_player fip ON fip.incident_id = fi.id
+JOIN football_player_ratings r ON r.match_id = m.id
 JOIN players p 
-	ON p.id = fip.player_id
+	ON p.id = r.player_id
 WHERE m.game_id = 1 AND m.status_code = 'finished'
 	AND m.start_timestamp BETWEEN 
 		(EXTRACT(EPOCH FROM NOW()) * 1000) - (7 * 24 * 60 * 60 * 1000)
 		AND (EXTRACT(EPOCH FROM NOW()) * 1000)
 GROUP BY p.id, p.public_id, p.name
-ORDER BY COUNT(fi.id) DESC
+ORDER BY AVG(r.rating) DESC, SUM(r.goals) DESC
 LIMIT 5;
 `
 
@@ -227,3 +228,152 @@ func (q *Queries) GetBadmintonTopPerformer(ctx context.Context) ([]map[string]in
 	}
 	return topPerformers, nil
 }
+
+const getTableTennisTopPerformer = `
+	SELECT JSON_BUILD_OBJECT(
+		'player', json_build_object(
+			'name', p.name,
+			'public_id', p.public_id,
+			'media_url', p.media_url
+		),
+		'current_streak', tps.current_streak
+	)
+	FROM table_tennis_player_stats tps
+	JOIN players p ON tps.player_id = p.id
+	WHERE tps.play_type = 'singles'
+	ORDER BY 
+	(CASE WHEN matches >= 5 THEN 1 ELSE 0 END) DESC,
+	current_streak DESC,
+	wins DESC
+	LIMIT 5;
+`
+
+func (q *Queries) GetTableTennisTopPerformer(ctx context.Context) ([]map[string]interface{}, error) {
+	rows, err := q.db.QueryContext(ctx, getTableTennisTopPerformer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table tennis top performer: %w", err)
+	}
+	defer rows.Close()
+
+	var topPerformers []map[string]interface{}
+	for rows.Next() {
+		var topPerformer map[string]interface{}
+		var jsonByte []byte
+		err := rows.Scan(&jsonByte)
+		if err != nil {
+			log.Printf("Failed to scan row: %v", err)
+			return nil, err
+		}
+		err = json.Unmarshal(jsonByte, &topPerformer)
+		if err != nil {
+			log.Printf("Failed to unmarshal: %v", err)
+			return nil, err
+		}
+		topPerformers = append(topPerformers, topPerformer)
+	}
+	//check row iteration error
+	if err := rows.Err(); err != nil {
+		log.Printf("Rows iteration error: %v", err)
+		return nil, err
+	}
+	return topPerformers, nil
+}
+
+const getVolleyballTopPerformer = `
+	SELECT JSON_BUILD_OBJECT(
+		'player', json_build_object(
+			'name', p.name,
+			'public_id', p.public_id,
+			'media_url', p.media_url
+		),
+		'service_points', vps.service_points
+	)
+	FROM volleyball_player_stats vps
+	JOIN players p ON vps.player_id = p.id
+	ORDER BY 
+	(CASE WHEN matches >= 5 THEN 1 ELSE 0 END) DESC,
+	service_points DESC,
+	wins DESC
+	LIMIT 5;
+`
+
+func (q *Queries) GetVolleyballTopPerformer(ctx context.Context) ([]map[string]interface{}, error) {
+	rows, err := q.db.QueryContext(ctx, getVolleyballTopPerformer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get volleyball top performer: %w", err)
+	}
+	defer rows.Close()
+
+	var topPerformers []map[string]interface{}
+	for rows.Next() {
+		var topPerformer map[string]interface{}
+		var jsonByte []byte
+		err := rows.Scan(&jsonByte)
+		if err != nil {
+			log.Printf("Failed to scan row: %v", err)
+			return nil, err
+		}
+		err = json.Unmarshal(jsonByte, &topPerformer)
+		if err != nil {
+			log.Printf("Failed to unmarshal: %v", err)
+			return nil, err
+		}
+		topPerformers = append(topPerformers, topPerformer)
+	}
+	//check row iteration error
+	if err := rows.Err(); err != nil {
+		log.Printf("Rows iteration error: %v", err)
+		return nil, err
+	}
+	return topPerformers, nil
+}
+
+const getKabaddiTopPerformer = `
+	SELECT JSON_BUILD_OBJECT(
+		'player', json_build_object(
+			'name', p.name,
+			'public_id', p.public_id,
+			'media_url', p.media_url
+		),
+		'raid_points', kps.raid_points,
+		'tackle_points', kps.tackle_points
+	)
+	FROM kabaddi_player_stats kps
+	JOIN players p ON kps.player_id = p.id
+	ORDER BY 
+	(CASE WHEN matches >= 5 THEN 1 ELSE 0 END) DESC,
+	raid_points + tackle_points DESC,
+	super_tackles DESC
+	LIMIT 5;
+`
+
+func (q *Queries) GetKabaddiTopPerformer(ctx context.Context) ([]map[string]interface{}, error) {
+	rows, err := q.db.QueryContext(ctx, getKabaddiTopPerformer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kabaddi top performer: %w", err)
+	}
+	defer rows.Close()
+
+	var topPerformers []map[string]interface{}
+	for rows.Next() {
+		var topPerformer map[string]interface{}
+		var jsonByte []byte
+		err := rows.Scan(&jsonByte)
+		if err != nil {
+			log.Printf("Failed to scan row: %v", err)
+			return nil, err
+		}
+		err = json.Unmarshal(jsonByte, &topPerformer)
+		if err != nil {
+			log.Printf("Failed to unmarshal: %v", err)
+			return nil, err
+		}
+		topPerformers = append(topPerformers, topPerformer)
+	}
+	//check row iteration error
+	if err := rows.Err(); err != nil {
+		log.Printf("Rows iteration error: %v", err)
+		return nil, err
+	}
+	return topPerformers, nil
+}
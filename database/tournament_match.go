@@ -514,6 +514,88 @@ func (q *Queries) UpdateMatchResult(ctx context.Context, matchID, resultID int32
 	return &i, err
 }
 
+const reopenMatch = `
+UPDATE matches
+SET
+    result = NULL,
+    status_code = 'in_progress'
+WHERE id = $1
+RETURNING *
+`
+
+// ReopenMatch puts a finished match back in progress without a result
+func (q *Queries) ReopenMatch(ctx context.Context, matchID int32) (*models.Match, error) {
+	row := q.db.QueryRowContext(ctx, reopenMatch, matchID)
+	var i models.Match
+	err := row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.TournamentID,
+		&i.AwayTeamID,
+		&i.HomeTeamID,
+		&i.StartTimestamp,
+		&i.EndTimestamp,
+		&i.Type,
+		&i.StatusCode,
+		&i.Result,
+		&i.Stage,
+		&i.KnockoutLevelID,
+		&i.MatchFormat,
+		&i.DayNumber,
+		&i.SubStatus,
+		&i.LocationID,
+		&i.LocationLocked,
+		&i.GameID,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, err
+}
+
+const clearMatchResult = `
+UPDATE matches
+SET result = NULL
+WHERE id = $1
+RETURNING *
+`
+
+// ClearMatchResult takes the winning team off a match which turned out to be drawn
+func (q *Queries) ClearMatchResult(ctx context.Context, matchID int32) (*models.Match, error) {
+	row := q.db.QueryRowContext(ctx, clearMatchResult, matchID)
+	var i models.Match
+	err := row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.TournamentID,
+		&i.AwayTeamID,
+		&i.HomeTeamID,
+		&i.StartTimestamp,
+		&i.EndTimestamp,
+		&i.Type,
+		&i.StatusCode,
+		&i.Result,
+		&i.Stage,
+		&i.KnockoutLevelID,
+		&i.MatchFormat,
+		&i.DayNumber,
+		&i.SubStatus,
+		&i.LocationID,
+		&i.LocationLocked,
+		&i.GameID,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, err
+}
+
 const updateMatchSubStatus = `
 	UPDATE matches m
 	SET
@@ -0,0 +1,486 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"khelogames/database/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const addVolleyballScore = `
+	INSERT INTO volleyball_score (
+		match_id,
+		set_number
+	)
+	VALUES (
+		$1, $2
+	) RETURNING *;
+`
+
+func (q *Queries) AddVolleyballScore(ctx context.Context, matchID int32, setNumber int) (*models.VolleyballScore, error) {
+	row := q.db.QueryRowContext(ctx, addVolleyballScore, matchID, setNumber)
+	var i models.VolleyballScore
+	err := row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.SetNumber,
+		&i.HomeScore,
+		&i.AwayScore,
+		&i.SetStatus,
+		&i.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const updateVolleyballScore = `
+	UPDATE volleyball_score vs
+	SET
+		home_score = CASE WHEN m.home_team_id = $3 THEN vs.home_score + 1 ELSE vs.home_score END,
+		away_score = CASE WHEN m.away_team_id = $3 THEN vs.away_score + 1 ELSE vs.away_score END
+	FROM matches m
+	WHERE vs.match_id = m.id
+	AND vs.match_id = $1
+	AND vs.set_number = $2
+	RETURNING vs.*;
+`
+
+// UpdateVolleyballScore adds a point to the team winning the rally
+func (q *Queries) UpdateVolleyballScore(ctx context.Context, matchID int32, setNumber int, scoringTeamID int32) (*models.VolleyballScore, error) {
+	row := q.db.QueryRowContext(ctx, updateVolleyballScore, matchID, setNumber, scoringTeamID)
+	var i models.VolleyballScore
+	err := row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.SetNumber,
+		&i.HomeScore,
+		&i.AwayScore,
+		&i.SetStatus,
+		&i.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const getVolleyballSetScore = `
+	SELECT * FROM volleyball_score
+	WHERE match_id = $1 AND set_number = $2
+`
+
+// GetVolleyballSetScore returns the score of a set, nil when the set has not started
+func (q *Queries) GetVolleyballSetScore(ctx context.Context, matchID int32, setNumber int) (*models.VolleyballScore, error) {
+	row := q.db.QueryRowContext(ctx, getVolleyballSetScore, matchID, setNumber)
+	var i models.VolleyballScore
+	err := row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.SetNumber,
+		&i.HomeScore,
+		&i.AwayScore,
+		&i.SetStatus,
+		&i.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const getVolleyballMatchSetsScore = `
+	SELECT vs.*
+	FROM volleyball_score vs
+	JOIN matches m ON m.id = vs.match_id
+	WHERE m.public_id = $1
+	ORDER BY vs.set_number;
+`
+
+func (q *Queries) GetVolleyballMatchSetsScore(ctx context.Context, matchPublicID uuid.UUID) ([]models.VolleyballScore, error) {
+	rows, err := q.db.QueryContext(ctx, getVolleyballMatchSetsScore, matchPublicID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var scores []models.VolleyballScore
+	for rows.Next() {
+		var i models.VolleyballScore
+		err := rows.Scan(
+			&i.ID,
+			&i.PublicID,
+			&i.MatchID,
+			&i.SetNumber,
+			&i.HomeScore,
+			&i.AwayScore,
+			&i.SetStatus,
+			&i.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		scores = append(scores, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
+
+const getVolleyballMatchScore = `
+	SELECT
+		COUNT(*) FILTER (WHERE vs.set_status = 'finished' AND vs.home_score > vs.away_score) AS home_sets_won,
+		COUNT(*) FILTER (WHERE vs.set_status = 'finished' AND vs.away_score > vs.home_score) AS away_sets_won
+	FROM volleyball_score vs
+	JOIN matches m ON m.id = vs.match_id
+	WHERE m.public_id = $1
+`
+
+// GetVolleyballMatchScore returns the sets won by each side
+func (q *Queries) GetVolleyballMatchScore(ctx context.Context, matchPublicID uuid.UUID) (*SetsWon, error) {
+	row := q.db.QueryRowContext(ctx, getVolleyballMatchScore, matchPublicID)
+	var result SetsWon
+	err := row.Scan(&result.HomeSetsWon, &result.AwaySetsWon)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &result, nil
+}
+
+const updateVolleyballSetStatus = `
+	UPDATE volleyball_score
+	SET set_status = $3
+	WHERE match_id = $1 AND set_number = $2
+	RETURNING *;
+`
+
+func (q *Queries) UpdateVolleyballSetStatus(ctx context.Context, matchID int32, setNumber int, setStatus string) (*models.VolleyballScore, error) {
+	row := q.db.QueryRowContext(ctx, updateVolleyballSetStatus, matchID, setNumber, setStatus)
+	var i models.VolleyballScore
+	err := row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.SetNumber,
+		&i.HomeScore,
+		&i.AwayScore,
+		&i.SetStatus,
+		&i.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const addVolleyballPoint = `
+	INSERT INTO volleyball_points (
+		match_id,
+		set_number,
+		scoring_team_id,
+		serving_team_id,
+		server_player_id,
+		home_score,
+		away_score,
+		point_number
+	)
+	VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8
+	) RETURNING *;
+`
+
+type AddVolleyballPointParams struct {
+	MatchID        int32
+	SetNumber      int
+	ScoringTeamID  int32
+	ServingTeamID  int32
+	ServerPlayerID *int32
+	HomeScore      int
+	AwayScore      int
+	PointNumber    int
+}
+
+func (q *Queries) AddVolleyballPoint(ctx context.Context, arg AddVolleyballPointParams) (*models.VolleyballPoint, error) {
+	row := q.db.QueryRowContext(ctx, addVolleyballPoint,
+		arg.MatchID,
+		arg.SetNumber,
+		arg.ScoringTeamID,
+		arg.ServingTeamID,
+		arg.ServerPlayerID,
+		arg.HomeScore,
+		arg.AwayScore,
+		arg.PointNumber,
+	)
+	var i models.VolleyballPoint
+	err := row.Scan(
+		&i.ID,
+		&i.PublicID,
+		&i.MatchID,
+		&i.SetNumber,
+		&i.ScoringTeamID,
+		&i.ServingTeamID,
+		&i.ServerPlayerID,
+		&i.HomeScore,
+		&i.AwayScore,
+		&i.PointNumber,
+		&i.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to scan: %w", err)
+	}
+	return &i, nil
+}
+
+const getVolleyballPoints = `
+	SELECT * FROM volleyball_points
+	WHERE match_id = $1 AND set_number = $2
+	ORDER BY point_number;
+`
+
+// GetVolleyballPoints returns the rallies of a set in the order they were played
+func (q *Queries) GetVolleyballPoints(ctx context.Context, matchID int32, setNumber int) ([]models.VolleyballPoint, error) {
+	rows, err := q.db.QueryContext(ctx, getVolleyballPoints, matchID, setNumber)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var points []models.VolleyballPoint
+	for rows.Next() {
+		var i models.VolleyballPoint
+		err := rows.Scan(
+			&i.ID,
+			&i.PublicID,
+			&i.MatchID,
+			&i.SetNumber,
+			&i.ScoringTeamID,
+			&i.ServingTeamID,
+			&i.ServerPlayerID,
+			&i.HomeScore,
+			&i.AwayScore,
+			&i.PointNumber,
+			&i.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		points = append(points, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return points, nil
+}
+
+const deleteVolleyballLineup = `
+	DELETE FROM volleyball_lineups
+	WHERE match_id = $1 AND team_id = $2 AND set_number = $3
+`
+
+const addVolleyballLineup = `
+	INSERT INTO volleyball_lineups (match_id, team_id, set_number, position, player_id)
+	SELECT $1, $2, $3, p.position, p.player_id
+	FROM UNNEST($4::INTEGER[]) WITH ORDINALITY AS p(player_id, position)
+`
+
+// SetVolleyballLineup replaces the starting lineup of a side in a set, the players given in order
+// of their court position
+func (q *Queries) SetVolleyballLineup(ctx context.Context, matchID, teamID int32, setNumber int, playerIDs []int32) error {
+	_, err := q.db.ExecContext(ctx, deleteVolleyballLineup, matchID, teamID, setNumber)
+	if err != nil {
+		return err
+	}
+	_, err = q.db.ExecContext(ctx, addVolleyballLineup, matchID, teamID, setNumber, pq.Array(playerIDs))
+	return err
+}
+
+const getVolleyballLineup = `
+	SELECT player_id FROM volleyball_lineups
+	WHERE match_id = $1 AND team_id = $2 AND set_number = $3
+	ORDER BY position
+`
+
+// GetVolleyballLineup returns the starting lineup of a side in a set in order of court position,
+// empty when none was set
+func (q *Queries) GetVolleyballLineup(ctx context.Context, matchID, teamID int32, setNumber int) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, getVolleyballLineup, matchID, teamID, setNumber)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	var playerIDs []int32
+	for rows.Next() {
+		var playerID int32
+		if err := rows.Scan(&playerID); err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		playerIDs = append(playerIDs, playerID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return playerIDs, nil
+}
+
+const getVolleyballServicePoints = `
+	SELECT server_player_id, COUNT(*)
+	FROM volleyball_points
+	WHERE match_id = $1
+	AND server_player_id IS NOT NULL
+	AND scoring_team_id = serving_team_id
+	GROUP BY server_player_id
+`
+
+// GetVolleyballServicePoints returns the points each player's side won on their serve in the match
+func (q *Queries) GetVolleyballServicePoints(ctx context.Context, matchID int32) (map[int32]int, error) {
+	rows, err := q.db.QueryContext(ctx, getVolleyballServicePoints, matchID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to query: %w", err)
+	}
+	defer rows.Close()
+
+	servicePoints := make(map[int32]int)
+	for rows.Next() {
+		var playerID int32
+		var points int
+		if err := rows.Scan(&playerID, &points); err != nil {
+			return nil, fmt.Errorf("Failed to scan: %w", err)
+		}
+		servicePoints[playerID] = points
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return servicePoints, nil
+}
+
+const addOrUpdateVolleyballPlayerStats = `
+	INSERT INTO volleyball_player_stats (
+		player_id, matches, wins, losses, sets_won, sets_lost,
+		service_points, win_percentage, current_streak, best_streak
+	)
+	VALUES ($1, 1, $2, $3, $4, $5, $6, $2 * 100, $2, $2)
+	ON CONFLICT (player_id) DO UPDATE SET
+		matches        = volleyball_player_stats.matches + 1,
+		wins           = volleyball_player_stats.wins + EXCLUDED.wins,
+		losses         = volleyball_player_stats.losses + EXCLUDED.losses,
+		sets_won       = volleyball_player_stats.sets_won + EXCLUDED.sets_won,
+		sets_lost      = volleyball_player_stats.sets_lost + EXCLUDED.sets_lost,
+		service_points = volleyball_player_stats.service_points + EXCLUDED.service_points,
+		win_percentage = ROUND(
+			((volleyball_player_stats.wins + EXCLUDED.wins)::NUMERIC /
+			 (volleyball_player_stats.matches + 1)::NUMERIC) * 100, 2
+		),
+		current_streak = CASE
+			WHEN EXCLUDED.wins = 1 THEN volleyball_player_stats.current_streak + 1
+			ELSE 0
+		END,
+		best_streak = GREATEST(
+			volleyball_player_stats.best_streak,
+			CASE
+				WHEN EXCLUDED.wins = 1 THEN volleyball_player_stats.current_streak + 1
+				ELSE volleyball_player_stats.best_streak
+			END
+		),
+		updated_at = NOW()
+	RETURNING *;
+`
+
+type AddOrUpdateVolleyballPlayerStatsParams struct {
+	PlayerID      int32
+	Wins          int // 1 if won, 0 if lost
+	Losses        int
+	SetsWon       int
+	SetsLost      int
+	ServicePoints int
+}
+
+func (q *Queries) AddOrUpdateVolleyballPlayerStats(ctx context.Context, arg AddOrUpdateVolleyballPlayerStatsParams) (*models.VolleyballPlayerStats, error) {
+	row := q.db.QueryRowContext(ctx, addOrUpdateVolleyballPlayerStats,
+		arg.PlayerID,
+		arg.Wins,
+		arg.Losses,
+		arg.SetsWon,
+		arg.SetsLost,
+		arg.ServicePoints,
+	)
+	var stat models.VolleyballPlayerStats
+	err := row.Scan(
+		&stat.ID,
+		&stat.PublicID,
+		&stat.PlayerID,
+		&stat.Matches,
+		&stat.Wins,
+		&stat.Losses,
+		&stat.SetsWon,
+		&stat.SetsLost,
+		&stat.ServicePoints,
+		&stat.WinPercentage,
+		&stat.CurrentStreak,
+		&stat.BestStreak,
+		&stat.CreatedAt,
+		&stat.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert volleyball player stats: %w", err)
+	}
+	return &stat, nil
+}
+
+const getVolleyballPlayerStats = `
+	SELECT * FROM volleyball_player_stats
+	WHERE player_id = $1;
+`
+
+// GetVolleyballPlayerStats returns the stats of a player, nil before their first finished match
+func (q *Queries) GetVolleyballPlayerStats(ctx context.Context, playerID int32) (*models.VolleyballPlayerStats, error) {
+	row := q.db.QueryRowContext(ctx, getVolleyballPlayerStats, playerID)
+	var stat models.VolleyballPlayerStats
+	err := row.Scan(
+		&stat.ID,
+		&stat.PublicID,
+		&stat.PlayerID,
+		&stat.Matches,
+		&stat.Wins,
+		&stat.Losses,
+		&stat.SetsWon,
+		&stat.SetsLost,
+		&stat.ServicePoints,
+		&stat.WinPercentage,
+		&stat.CurrentStreak,
+		&stat.BestStreak,
+		&stat.CreatedAt,
+		&stat.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get volleyball player stats: %w", err)
+	}
+	return &stat, nil
+}
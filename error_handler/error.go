@@ -1,6 +1,7 @@
 package errorhandler
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -129,3 +130,33 @@ func SuccessResponseWithStatus(
 		"data":    data,
 	})
 }
+
+// FieldError is a rule violation found below the handler layer which should reach
+// the client as a validation error on the given field
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// NewFieldError creates a validation error for a single request field
+func NewFieldError(field, message string) *FieldError {
+	return &FieldError{Field: field, Message: message}
+}
+
+// FieldErrorResponse sends a validation error response when err is a FieldError
+// and reports whether a response was written
+func FieldErrorResponse(
+	ctx *gin.Context,
+	err error,
+) bool {
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) {
+		return false
+	}
+	ValidationErrorResponse(ctx, map[string]string{fieldErr.Field: fieldErr.Message})
+	return true
+}
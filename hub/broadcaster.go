@@ -229,3 +229,32 @@ func (s *Hub) BroadcastBadmintonEvent(ctx *gin.Context, eventType string, payloa
 	}
 	return nil
 }
+
+// BroadcastMatchEvent sends an event to the clients subscribed to the MATCH topic of payload["match_public_id"]
+func (s *Hub) BroadcastMatchEvent(ctx context.Context, eventType string, payload map[string]interface{}) error {
+	content := map[string]interface{}{
+		"type":    eventType,
+		"payload": payload,
+	}
+
+	s.logger.Debugf("[BroadcastMatchEvent] Raw payload: %#v", payload)
+
+	if _, ok := payload["match_public_id"]; !ok {
+		return fmt.Errorf("missing match_public_id in match event payload")
+	}
+
+	body, err := json.Marshal(content)
+	if err != nil {
+		s.logger.Errorf("failed to marshal message: %v", err)
+		return err
+	}
+
+	s.logger.Debugf("[BroadcastMatchEvent] Marshaled JSON: %s", string(body))
+
+	select {
+	case s.MatchBroadcast <- body:
+	default:
+		s.logger.Warn("[BroadcastMatchEvent] matchBroadcast channel is full or blocked — message dropped")
+	}
+	return nil
+}
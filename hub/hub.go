@@ -175,3 +175,43 @@ func (s *Hub) StartBadmintonHub() {
 		}
 	}
 }
+
+// StartMatchHub delivers match events only to the clients subscribed to the match
+func (s *Hub) StartMatchHub() {
+	s.logger.Info("StartMatchHub started")
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Errorf("StartMatchHub panic: %v", r)
+		}
+	}()
+	for {
+		select {
+		case message := <-s.MatchBroadcast:
+			var data map[string]interface{}
+			err := json.Unmarshal(message, &data)
+			if err != nil {
+				s.logger.Error("Failed to unmarshal match message:", err)
+				continue
+			}
+
+			payload, ok := data["payload"].(map[string]interface{})
+			if !ok {
+				s.logger.Error("invalid payload structure")
+				continue
+			}
+			matchPublicID, _ := payload["match_public_id"].(string)
+
+			// writes share the lock with the other hubs, a connection takes one writer at a time
+			s.mu.Lock()
+			for client := range s.subscriber[matchPublicID] {
+				if err := client.Conn.WriteMessage(websocket.TextMessage, message); err != nil {
+					s.logger.Errorf("Failed to write match message to client: %v", err)
+					delete(s.subscriber[matchPublicID], client)
+					delete(s.Clients, client)
+					client.Conn.Close()
+				}
+			}
+			s.mu.Unlock()
+		}
+	}
+}
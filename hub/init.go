@@ -27,6 +27,7 @@ type Hub struct {
 	FootballBroadcast   chan []byte
 	TournamentBroadcast chan []byte
 	BadmintonBroadcast  chan []byte
+	MatchBroadcast      chan []byte
 
 	logger             *logger.Logger
 	store              *database.Store
@@ -47,6 +48,7 @@ func NewHub(store *database.Store, logger *logger.Logger, upgrader websocket.Upg
 		FootballBroadcast:   make(chan []byte),
 		TournamentBroadcast: make(chan []byte),
 		BadmintonBroadcast:  make(chan []byte),
+		MatchBroadcast:      make(chan []byte, 256),
 		logger:              logger,
 		store:               store,
 		upgrader:            upgrader,
@@ -62,6 +64,7 @@ func NewHub(store *database.Store, logger *logger.Logger, upgrader websocket.Upg
 	go h.StartFootballHub()
 	go h.StartTournamentHub()
 	go h.StartBadmintonHub()
+	go h.StartMatchHub()
 
 	h.logger.Info("Hub initialized successfully")
 	return h
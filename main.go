@@ -24,6 +24,9 @@ import (
 	"khelogames/api/sports/badminton"
 	"khelogames/api/sports/cricket"
 	"khelogames/api/sports/football"
+	"khelogames/api/sports/kabaddi"
+	tabletennis "khelogames/api/sports/table_tennis"
+	"khelogames/api/sports/volleyball"
 	"khelogames/api/tournaments"
 	db "khelogames/database"
 	"khelogames/logger"
@@ -99,6 +102,9 @@ func main() {
 
 	cricketServer := cricket.NewCricketServer(store, log, nil, txStore)
 	badmintonServer := badminton.NewBadmintonServer(store, log, nil, txStore)
+	tableTennisServer := tabletennis.NewTableTennisServer(store, log, nil, txStore)
+	volleyballServer := volleyball.NewVolleyballServer(store, log, nil, txStore)
+	kabaddiServer := kabaddi.NewKabaddiServer(store, log, nil, txStore)
 
 	// Initialize HTTP servers and handlers
 	authServer := auth.NewAuthServer(store, log, tokenMaker, config, txStore)
@@ -112,11 +118,20 @@ func main() {
 	// Create messenger server with cricket server as both updater and broadcaster
 	messengerServer := messenger.NewMessageServer(store, tokenMaker, clients, messageBroadCast, scoredBroadCast, upgrader, rabbitChan, log, nil)
 	playerServer := players.NewPlayerServer(store, log, tokenMaker, config)
-	sportsServer := sports.NewSportsServer(store, log, tokenMaker, config, footballServer, cricketServer, badmintonServer)
+	sportsServer := sports.NewSportsServer(store, log, tokenMaker, config)
+	sports.Register(footballServer)
+	sports.Register(cricketServer)
+	sports.Register(badmintonServer)
+	sports.Register(tableTennisServer)
+	sports.Register(volleyballServer)
+	sports.Register(kabaddiServer)
 	tournamentServer.SetScoreBroadcaster(hub)
 	cricketServer.SetScoreBroadcaster(hub)
 	footballServer.SetScoreBroadcaster(hub)
 	badmintonServer.SetScoreBroadcaster(hub)
+	tableTennisServer.SetScoreBroadcaster(hub)
+	volleyballServer.SetScoreBroadcaster(hub)
+	kabaddiServer.SetScoreBroadcaster(hub)
 	txStore.SetScoreBroadcaster(hub)
 
 	log.Info("Broadcasters initialized for cricket, football, tournament, and messenger")
@@ -126,6 +141,8 @@ func main() {
 		go hub.StartRabbitMQConsumer("chatHub")
 	}
 	// go hub.StartMessageHub()
+	go footballServer.StartFootballClockTicker(context.Background())
+	go kabaddiServer.StartKabaddiClockTicker(context.Background())
 
 	// Initialize Gin router
 	router := gin.Default()
@@ -139,6 +156,9 @@ func main() {
 		footballServer,
 		cricketServer,
 		badmintonServer,
+		tableTennisServer,
+		volleyballServer,
+		kabaddiServer,
 		teamsServer,
 		messengerServer,
 		playerServer,